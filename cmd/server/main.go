@@ -12,8 +12,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/constants"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/di"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/handler"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/jobs"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/pkg/database"
 	"go.uber.org/zap"
@@ -54,9 +56,104 @@ func main() {
 	userRoutes := api.Group("/user")
 	userRoutes.Use(middleware.Authentication(container.Logger))
 
+	// Register the routes for agreeing to policies
+	consentHandler := handler.NewConsentHandler(container.ConsentService, container.Logger)
+	consentHandler.RegisterRoutes(userRoutes)
+
+	// Profile routes additionally require the current mandatory policies to be accepted.
+	// Account routes for consents, membership, data exports and erasure don't, so that a
+	// user who declines the policies can still take their data or leave.
+	profileRoutes := userRoutes.Group("", middleware.RequireConsent(container.ConsentService, container.Logger))
+
 	// Register profile handler routes
 	userProfileHandler := handler.NewUserProfileHandler(container.UserProfileService, container.Logger)
-	userProfileHandler.RegisterRoutes(userRoutes)
+	userProfileHandler.RegisterRoutes(profileRoutes)
+
+	// Register the own profile route with its completeness checklist
+	completenessHandler := handler.NewProfileCompletenessHandler(container.CompletenessService, container.Logger)
+	completenessHandler.RegisterRoutes(profileRoutes)
+
+	// Register the routes for filling in a profile step by step
+	profileDraftHandler := handler.NewProfileDraftHandler(container.ProfileDraftService, container.Logger)
+	profileDraftHandler.RegisterRoutes(profileRoutes)
+
+	// Register the routes for restoring deleted profiles
+	retentionHandler := handler.NewProfileRetentionHandler(container.RetentionService, container.Logger)
+	retentionHandler.RegisterRoutes(profileRoutes)
+
+	// Register the routes for exporting the account's data; archives are downloaded by signed link
+	dataExportHandler := handler.NewDataExportHandler(container.DataExportService, container.Logger)
+	dataExportHandler.RegisterRoutes(userRoutes)
+	dataExportHandler.RegisterPublicRoutes(api)
+
+	// Register saved search handler routes
+	savedSearchHandler := handler.NewSavedSearchHandler(container.SavedSearchService, container.Logger)
+	savedSearchHandler.RegisterRoutes(profileRoutes)
+
+	// Register partner preference, block and recommendation routes
+	preferenceHandler := handler.NewPartnerPreferenceHandler(container.PreferenceService, container.Logger)
+	preferenceHandler.RegisterRoutes(profileRoutes)
+
+	blockHandler := handler.NewProfileBlockHandler(container.BlockService, container.UserProfileService, container.Logger)
+	blockHandler.RegisterRoutes(profileRoutes)
+
+	recommendationHandler := handler.NewRecommendationHandler(container.RecommendationService, container.Logger)
+	recommendationHandler.RegisterRoutes(profileRoutes)
+
+	// Register similar profile routes
+	similarProfileHandler := handler.NewSimilarProfileHandler(container.SimilarProfileService, container.UserProfileService, container.Logger)
+	similarProfileHandler.RegisterRoutes(profileRoutes)
+
+	// Register interest and contact details routes
+	interestHandler := handler.NewInterestHandler(container.InterestService, container.UserProfileService, container.Logger)
+	interestHandler.RegisterRoutes(profileRoutes)
+
+	contactHandler := handler.NewContactHandler(container.ContactService, container.UserProfileService, container.Logger)
+	contactHandler.RegisterRoutes(profileRoutes)
+
+	// Register membership, profile view and payment webhook routes
+	membershipHandler := handler.NewMembershipHandler(container.MembershipService, container.Logger)
+	membershipHandler.RegisterRoutes(userRoutes)
+	membershipHandler.RegisterWebhookRoutes(api)
+
+	profileViewHandler := handler.NewProfileViewHandler(container.ProfileViewService, container.MembershipService, container.Logger)
+	profileViewHandler.RegisterRoutes(profileRoutes)
+
+	// Register profile boost routes
+	profileBoostHandler := handler.NewProfileBoostHandler(container.ProfileBoostService, container.Logger)
+	profileBoostHandler.RegisterRoutes(profileRoutes)
+
+	// Register profile manager routes
+	profileManagerHandler := handler.NewProfileManagerHandler(container.ProfileManagerService, container.Logger)
+	profileManagerHandler.RegisterRoutes(profileRoutes)
+
+	// Register profile transfer routes
+	profileTransferHandler := handler.NewProfileTransferHandler(container.ProfileTransferService, container.Logger)
+	profileTransferHandler.RegisterRoutes(profileRoutes)
+
+	// Register privacy, biodata and share link routes; shared profiles are public
+	profileShareHandler := handler.NewProfileShareHandler(container.ProfileShareService, container.UserProfileService, container.Logger)
+	profileShareHandler.RegisterRoutes(profileRoutes)
+	profileShareHandler.RegisterPublicRoutes(api)
+
+	// Admin routes (protected by authentication and the admin role)
+	adminRoutes := api.Group("/admin")
+	adminRoutes.Use(
+		middleware.Authentication(container.Logger),
+		middleware.JWTAuthMiddleware(cfg, container.Logger.Logger),
+		middleware.RoleAuthMiddleware([]string{constants.RoleAdmin}, container.Logger.Logger),
+	)
+
+	// Register profile history routes; admins can also revert profiles
+	profileRevisionHandler := handler.NewProfileRevisionHandler(
+		container.ProfileRevisionService, container.UserProfileService, container.Logger)
+	profileRevisionHandler.RegisterRoutes(profileRoutes)
+	profileRevisionHandler.RegisterAdminRoutes(adminRoutes)
+
+	// Register account erasure routes; admins can verify the erasure receipts
+	erasureHandler := handler.NewAccountErasureHandler(container.ErasureService, container.Logger)
+	erasureHandler.RegisterRoutes(userRoutes)
+	erasureHandler.RegisterAdminRoutes(adminRoutes)
 
 	// Run database migrations
 	if cfg.Database.RunMigrations {
@@ -65,6 +162,66 @@ func main() {
 			container.Logger.Fatal("Failed to run database migrations", zap.Error(err))
 		}
 		container.Logger.Info("Database migrations completed")
+
+		// Compute phonetic name keys for profiles created before name search existed. Like
+		// the migrations, this runs only on the instance that migrates the database.
+		backfilled, err := container.UserProfileRepo.BackfillNamePhonetics(context.Background())
+		if err != nil {
+			container.Logger.Error("Failed to backfill phonetic name keys", zap.Error(err))
+		} else if backfilled > 0 {
+			container.Logger.Info("Backfilled phonetic name keys", zap.Int64("profiles", backfilled))
+		}
+
+		// Likewise locate profiles created before distance search existed
+		located, err := container.UserProfileRepo.BackfillResidenceLocations(context.Background())
+		if err != nil {
+			container.Logger.Error("Failed to backfill residence locations", zap.Error(err))
+		} else if located > 0 {
+			container.Logger.Info("Backfilled residence locations", zap.Int64("profiles", located))
+		}
+
+		// And score the completeness of profiles created before scores were stored. Until
+		// they are scored they rank at the completeness floor, so this is as fatal as the
+		// migrations themselves.
+		scored, err := container.CompletenessService.BackfillScores(context.Background())
+		if err != nil {
+			container.Logger.Fatal("Failed to backfill completeness scores", zap.Error(err))
+		}
+		if scored > 0 {
+			container.Logger.Info("Backfilled completeness scores", zap.Int64("profiles", scored))
+		}
+	}
+
+	// Start background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobRunner := jobs.NewRunner(container.Logger)
+	if cfg.Jobs.Enabled {
+		jobRunner.Register(jobs.Job{
+			Name:     "saved_search_alerts",
+			Interval: cfg.Jobs.SavedSearchAlertInterval,
+			Run:      container.SavedSearchService.ProcessAlerts,
+		})
+		jobRunner.Register(jobs.Job{
+			Name:     "daily_recommendations",
+			Interval: cfg.Jobs.RecommendationInterval,
+			Run:      container.RecommendationService.GenerateRecommendations,
+		})
+		jobRunner.Register(jobs.Job{
+			Name:     "deleted_profile_purge",
+			Interval: cfg.Jobs.ProfilePurgeInterval,
+			Run:      container.RetentionService.PurgeDeletedProfiles,
+		})
+		jobRunner.Register(jobs.Job{
+			Name:     "data_exports",
+			Interval: cfg.Jobs.DataExportInterval,
+			Run:      container.DataExportService.ProcessExports,
+		})
+		jobRunner.Register(jobs.Job{
+			Name:     "account_erasures",
+			Interval: cfg.Jobs.AccountErasureInterval,
+			Run:      container.ErasureService.ProcessErasures,
+		})
+		jobRunner.Start(jobsCtx)
 	}
 
 	// Start the server
@@ -99,5 +256,9 @@ func main() {
 		container.Logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Stop background jobs and wait for running ones to finish
+	stopJobs()
+	jobRunner.Wait()
+
 	container.Logger.Info("Server exited properly")
 }
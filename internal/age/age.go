@@ -0,0 +1,117 @@
+package age
+
+import (
+	"time"
+)
+
+// IST is Indian Standard Time, the zone in which birthdays roll over by default.
+// It has had no daylight saving time since 1945, so a fixed zone needs no tz database.
+var IST = time.FixedZone("IST", 5*60*60+30*60)
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to a Clock
+type ClockFunc func() time.Time
+
+// Now returns the time given by f
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock of the running system
+var SystemClock Clock = ClockFunc(time.Now)
+
+// Calculator computes ages in whole years as of the current day in a time zone.
+// Someone born on 29 February turns a year older on 1 March in common years.
+type Calculator struct {
+	clock    Clock
+	location *time.Location
+}
+
+// New creates a Calculator reading the time from clock and counting days in location
+func New(clock Clock, location *time.Location) *Calculator {
+	return &Calculator{
+		clock:    clock,
+		location: location,
+	}
+}
+
+// Default is the Calculator used by the service: the system clock in IST
+var Default = New(SystemClock, IST)
+
+// Of returns the age of someone born on dob as of today, using the Default calculator
+func Of(dob time.Time) int {
+	return Default.Of(dob)
+}
+
+// Today returns the current calendar day in the calculator's zone, at midnight UTC
+func (c *Calculator) Today() time.Time {
+	return civilDate(c.clock.Now().In(c.location))
+}
+
+// Of returns the age of someone born on dob as of today
+func (c *Calculator) Of(dob time.Time) int {
+	return At(dob, c.Today())
+}
+
+// BirthDateRange returns the birth dates, inclusive, of everyone aged between minAge
+// and maxAge today. Either bound may be nil, which leaves that end of the range open.
+func (c *Calculator) BirthDateRange(minAge, maxAge *int) (earliest, latest *time.Time) {
+	today := c.Today()
+
+	if minAge != nil {
+		born := LatestBirthDate(today, *minAge)
+		latest = &born
+	}
+	if maxAge != nil {
+		// Still maxAge until the day before turning maxAge+1
+		born := LatestBirthDate(today, *maxAge+1).AddDate(0, 0, 1)
+		earliest = &born
+	}
+
+	return earliest, latest
+}
+
+// At returns the age on the calendar day today of someone born on the calendar day dob.
+// Only the dates matter; times of day and zones are ignored.
+func At(dob, today time.Time) int {
+	dob, today = civilDate(dob), civilDate(today)
+
+	years := today.Year() - dob.Year()
+	if today.Month() < dob.Month() || (today.Month() == dob.Month() && today.Day() < dob.Day()) {
+		years--
+	}
+
+	return years
+}
+
+// LatestBirthDate returns the last birth date of anyone at least years old on the calendar
+// day today. A birthday on 29 February is reached on 1 March in common years, so on 28
+// February of a common year the 29th of a leap year is not yet included.
+func LatestBirthDate(today time.Time, years int) time.Time {
+	return AddYears(civilDate(today), -years)
+}
+
+// AddYears moves a calendar day by whole years, turning 29 February into 28 February in
+// common years rather than overflowing into March as time.AddDate does
+func AddYears(day time.Time, years int) time.Time {
+	year := day.Year() + years
+	if day.Month() == time.February && day.Day() == 29 && !isLeap(year) {
+		return time.Date(year, time.February, 28, 0, 0, 0, 0, day.Location())
+	}
+	return time.Date(year, day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+}
+
+// civilDate returns the calendar day of t at midnight UTC
+func civilDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// isLeap reports whether year is a leap year of the Gregorian calendar
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,224 @@
+package age
+
+import (
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		dob   time.Time
+		today time.Time
+		want  int
+	}{
+		{"day before birthday", date(1995, time.June, 15), date(2025, time.June, 14), 29},
+		{"on birthday", date(1995, time.June, 15), date(2025, time.June, 15), 30},
+		{"day after birthday", date(1995, time.June, 15), date(2025, time.June, 16), 30},
+		{"born on new year's day, new year's eve", date(2000, time.January, 1), date(2017, time.December, 31), 17},
+		{"born on new year's day, new year's day", date(2000, time.January, 1), date(2018, time.January, 1), 18},
+		{"born on new year's eve, new year's eve", date(1999, time.December, 31), date(2017, time.December, 31), 18},
+		{"leap day birth, 28 February of a common year", date(2000, time.February, 29), date(2019, time.February, 28), 18},
+		{"leap day birth, 1 March of a common year", date(2000, time.February, 29), date(2019, time.March, 1), 19},
+		{"leap day birth, 28 February of a leap year", date(2000, time.February, 29), date(2024, time.February, 28), 23},
+		{"leap day birth, 29 February of a leap year", date(2000, time.February, 29), date(2024, time.February, 29), 24},
+		{"born 1 March, 29 February of a leap year", date(2000, time.March, 1), date(2024, time.February, 29), 23},
+		{"born 28 February, 28 February of a common year", date(2001, time.February, 28), date(2019, time.February, 28), 18},
+		{"leap day birth, no common year birthday across 1900", date(1896, time.February, 29), date(1904, time.February, 28), 7},
+		{"leap day birth, 2000 is a leap year", date(1996, time.February, 29), date(2000, time.February, 29), 4},
+		{"born today", date(2025, time.June, 15), date(2025, time.June, 15), 0},
+		{"times of day ignored", time.Date(1995, time.June, 15, 23, 59, 0, 0, time.UTC), time.Date(2025, time.June, 15, 0, 1, 0, 0, time.UTC), 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := At(tt.dob, tt.today); got != tt.want {
+				t.Errorf("At(%s, %s) = %d, want %d", tt.dob.Format("2006-01-02"), tt.today.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddYears(t *testing.T) {
+	tests := []struct {
+		name  string
+		day   time.Time
+		years int
+		want  time.Time
+	}{
+		{"ordinary day", date(2025, time.June, 15), -18, date(2007, time.June, 15)},
+		{"leap day to leap year", date(2024, time.February, 29), -4, date(2020, time.February, 29)},
+		{"leap day to common year", date(2024, time.February, 29), -18, date(2006, time.February, 28)},
+		{"leap day to century common year", date(2000, time.February, 29), -100, date(1900, time.February, 28)},
+		{"leap day to century leap year", date(2000, time.February, 29), 400, date(2400, time.February, 29)},
+		{"1 March stays put", date(2025, time.March, 1), -25, date(2000, time.March, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddYears(tt.day, tt.years); !got.Equal(tt.want) {
+				t.Errorf("AddYears(%s, %d) = %s, want %s", tt.day.Format("2006-01-02"), tt.years, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestLatestBirthDateAgreesWithAt(t *testing.T) {
+	// Everyone born on the latest birth date is the age, and everyone born the day after is younger
+	todays := []time.Time{
+		date(2024, time.February, 28),
+		date(2024, time.February, 29),
+		date(2024, time.March, 1),
+		date(2025, time.February, 28),
+		date(2025, time.March, 1),
+		date(2025, time.December, 31),
+		date(2026, time.January, 1),
+	}
+
+	for _, today := range todays {
+		for years := 0; years <= 100; years++ {
+			latest := LatestBirthDate(today, years)
+			if got := At(latest, today); got != years {
+				t.Errorf("today %s: born on latest birth date %s for %d is %d", today.Format("2006-01-02"), latest.Format("2006-01-02"), years, got)
+			}
+			if got := At(latest.AddDate(0, 0, 1), today); got != years-1 {
+				t.Errorf("today %s: born the day after latest birth date %s for %d is %d", today.Format("2006-01-02"), latest.Format("2006-01-02"), years, got)
+			}
+		}
+	}
+}
+
+func TestCalculatorTimeZone(t *testing.T) {
+	dob := date(2000, time.June, 15)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		location *time.Location
+		wantDay  time.Time
+		wantAge  int
+	}{
+		{"before midnight UTC is birthday in IST", time.Date(2025, time.June, 14, 19, 0, 0, 0, time.UTC), IST, date(2025, time.June, 15), 25},
+		{"just before midnight IST", time.Date(2025, time.June, 14, 18, 29, 59, 0, time.UTC), IST, date(2025, time.June, 14), 24},
+		{"exactly midnight IST", time.Date(2025, time.June, 14, 18, 30, 0, 0, time.UTC), IST, date(2025, time.June, 15), 25},
+		{"same instant in UTC", time.Date(2025, time.June, 14, 19, 0, 0, 0, time.UTC), time.UTC, date(2025, time.June, 14), 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := tt.now
+			calc := New(ClockFunc(func() time.Time { return now }), tt.location)
+
+			if got := calc.Today(); !got.Equal(tt.wantDay) {
+				t.Errorf("Today() = %s, want %s", got.Format("2006-01-02"), tt.wantDay.Format("2006-01-02"))
+			}
+			if got := calc.Of(dob); got != tt.wantAge {
+				t.Errorf("Of() = %d, want %d", got, tt.wantAge)
+			}
+		})
+	}
+}
+
+func TestBirthDateRange(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name         string
+		today        time.Time
+		minAge       *int
+		maxAge       *int
+		wantEarliest *time.Time
+		wantLatest   *time.Time
+	}{
+		{
+			name:         "ordinary day",
+			today:        date(2025, time.June, 15),
+			minAge:       intPtr(25),
+			maxAge:       intPtr(30),
+			wantEarliest: ptr(date(1994, time.June, 16)),
+			wantLatest:   ptr(date(2000, time.June, 15)),
+		},
+		{
+			name:         "leap day today",
+			today:        date(2024, time.February, 29),
+			minAge:       intPtr(18),
+			maxAge:       intPtr(18),
+			wantEarliest: ptr(date(2005, time.March, 1)),
+			wantLatest:   ptr(date(2006, time.February, 28)),
+		},
+		{
+			name:         "28 February of a common year",
+			today:        date(2025, time.February, 28),
+			minAge:       intPtr(21),
+			maxAge:       intPtr(23),
+			wantEarliest: ptr(date(2001, time.March, 1)),
+			wantLatest:   ptr(date(2004, time.February, 28)),
+		},
+		{
+			name:         "1 March of a common year",
+			today:        date(2025, time.March, 1),
+			minAge:       intPtr(21),
+			wantEarliest: nil,
+			wantLatest:   ptr(date(2004, time.March, 1)),
+		},
+		{
+			name:         "only maximum",
+			today:        date(2025, time.June, 15),
+			maxAge:       intPtr(40),
+			wantEarliest: ptr(date(1984, time.June, 16)),
+		},
+		{
+			name:  "no bounds",
+			today: date(2025, time.June, 15),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := tt.today.Add(12 * time.Hour)
+			calc := New(ClockFunc(func() time.Time { return now }), time.UTC)
+
+			earliest, latest := calc.BirthDateRange(tt.minAge, tt.maxAge)
+			assertDate(t, "earliest", earliest, tt.wantEarliest)
+			assertDate(t, "latest", latest, tt.wantLatest)
+
+			// Every birth date in the range has an age within the bounds, and the
+			// days just outside it don't
+			if earliest != nil {
+				if got := At(*earliest, tt.today); got != *tt.maxAge {
+					t.Errorf("born on earliest date is %d, want %d", got, *tt.maxAge)
+				}
+				if got := At(earliest.AddDate(0, 0, -1), tt.today); got != *tt.maxAge+1 {
+					t.Errorf("born the day before earliest date is %d, want %d", got, *tt.maxAge+1)
+				}
+			}
+			if latest != nil {
+				if got := At(*latest, tt.today); got != *tt.minAge {
+					t.Errorf("born on latest date is %d, want %d", got, *tt.minAge)
+				}
+				if got := At(latest.AddDate(0, 0, 1), tt.today); got != *tt.minAge-1 {
+					t.Errorf("born the day after latest date is %d, want %d", got, *tt.minAge-1)
+				}
+			}
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func assertDate(t *testing.T, name string, got, want *time.Time) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", name, got, want)
+	case !got.Equal(*want):
+		t.Errorf("%s = %s, want %s", name, got.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,224 @@
+package biodata
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"strings"
+)
+
+// Field is one labelled line of a biodata section
+type Field struct {
+	Label string
+	Value string
+}
+
+// Section groups related details under a heading. A section has either fields
+// or free text, such as the "About" paragraph.
+type Section struct {
+	Title  string
+	Fields []Field
+	Text   string
+}
+
+// Sheet is the content of a printable biodata
+type Sheet struct {
+	Title    string // usually the name of the bride or groom
+	Subtitle string // e.g. the profile code
+	Photo    []byte // optional JPEG
+	Sections []Section
+	Footer   string // printed at the bottom of every page
+}
+
+// ErrUnsupportedPhoto is returned when the photo is not a JPEG
+var ErrUnsupportedPhoto = errors.New("biodata photo must be a JPEG")
+
+// A4 page layout, in points
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 50
+	bottomMargin = 60
+	footerY      = 30
+
+	labelWidth  = 150
+	fieldSize   = 10
+	fieldLead   = 15
+	headingSize = 13
+	titleSize   = 22
+
+	photoWidth  = 120
+	photoHeight = 150
+)
+
+// Render writes the sheet as an A4 PDF
+func Render(w io.Writer, sheet *Sheet) error {
+	doc := &document{width: pageWidth, height: pageHeight}
+
+	if len(sheet.Photo) > 0 {
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(sheet.Photo))
+		if err != nil {
+			return ErrUnsupportedPhoto
+		}
+
+		colorSpace := "DeviceRGB"
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			colorSpace = "DeviceGray"
+		case color.CMYKModel:
+			colorSpace = "DeviceCMYK"
+		}
+		doc.image = &jpegImage{data: sheet.Photo, width: cfg.Width, height: cfg.Height, colorSpace: colorSpace}
+	}
+
+	l := &layout{doc: doc, footer: sheet.Footer}
+	l.startPage()
+
+	// Header: title and subtitle on the left, photo on the right
+	top := float64(pageHeight - margin)
+	l.page.text(bold, titleSize, margin, top-titleSize, sheet.Title)
+	if sheet.Subtitle != "" {
+		l.page.text(regular, 11, margin, top-titleSize-20, sheet.Subtitle)
+	}
+	l.y = top - titleSize - 40
+
+	if doc.image != nil {
+		w, h := fitInside(float64(doc.image.width), float64(doc.image.height), photoWidth, photoHeight)
+		x := pageWidth - margin - photoWidth + (photoWidth-w)/2
+		y := top - photoHeight + (photoHeight-h)/2
+		l.page.drawImage(x, y, w, h)
+		l.page.rect(pageWidth-margin-photoWidth, top-photoHeight, photoWidth, photoHeight, 0.5, 0.6)
+		// Fields on the first page run beside the photo, so keep them clear of it
+		l.clearBelow = top - photoHeight - 10
+	}
+
+	for _, section := range sheet.Sections {
+		l.heading(section.Title)
+		for _, field := range section.Fields {
+			l.field(field.Label, field.Value)
+		}
+		if section.Text != "" {
+			l.paragraph(section.Text)
+		}
+		l.y -= 8
+	}
+
+	return doc.writeTo(w)
+}
+
+// layout flows text down the pages of a document
+type layout struct {
+	doc        *document
+	page       *page
+	y          float64
+	footer     string
+	clearBelow float64 // content above this height must not reach the photo column
+}
+
+// startPage begins a new page with the footer already drawn
+func (l *layout) startPage() {
+	l.page = l.doc.newPage()
+	l.y = pageHeight - margin
+	l.clearBelow = 0
+	if l.footer != "" {
+		l.page.line(margin, footerY+12, pageWidth-margin, footerY+12, 0.5, 0.7)
+		l.page.text(regular, 8, margin, footerY, l.footer)
+	}
+}
+
+// ensure starts a new page unless height points fit above the bottom margin
+func (l *layout) ensure(height float64) {
+	if l.y-height < bottomMargin {
+		l.startPage()
+	}
+}
+
+// width returns the text width available at the current height
+func (l *layout) width() float64 {
+	if l.y > l.clearBelow && l.clearBelow > 0 {
+		return pageWidth - 2*margin - photoWidth - 15
+	}
+	return pageWidth - 2*margin
+}
+
+// heading draws a section heading with a rule under it
+func (l *layout) heading(title string) {
+	l.ensure(headingSize + 2*fieldLead)
+	l.y -= headingSize
+	l.page.text(bold, headingSize, margin, l.y, title)
+	l.y -= 5
+	l.page.line(margin, l.y, margin+l.width(), l.y, 0.8, 0.5)
+	l.y -= fieldLead
+}
+
+// field draws a label and its value, wrapping the value under itself
+func (l *layout) field(label, value string) {
+	lines := wrap(value, regular, fieldSize, l.width()-labelWidth)
+	l.ensure(float64(len(lines)) * fieldLead)
+	l.page.text(bold, fieldSize, margin, l.y, label)
+	for _, line := range lines {
+		l.ensure(fieldLead)
+		l.page.text(regular, fieldSize, margin+labelWidth, l.y, line)
+		l.y -= fieldLead
+	}
+}
+
+// paragraph draws free text across the full width
+func (l *layout) paragraph(text string) {
+	for _, para := range strings.Split(text, "\n") {
+		for _, line := range wrap(para, regular, fieldSize, l.width()) {
+			l.ensure(fieldLead)
+			l.page.text(regular, fieldSize, margin, l.y, line)
+			l.y -= fieldLead
+		}
+	}
+}
+
+// wrap breaks text into lines no wider than width, splitting words only when
+// a single word is too long for a line
+func wrap(text string, f *font, size, width float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{"-"}
+	}
+
+	var lines []string
+	current := ""
+	for _, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if f.textWidth(candidate, size) <= width {
+			current = candidate
+			continue
+		}
+
+		if current != "" {
+			lines = append(lines, current)
+		}
+		for f.textWidth(word, size) > width {
+			runes := []rune(word)
+			cut := len(runes)
+			for cut > 1 && f.textWidth(string(runes[:cut]), size) > width {
+				cut--
+			}
+			lines = append(lines, string(runes[:cut]))
+			word = string(runes[cut:])
+		}
+		current = word
+	}
+
+	return append(lines, current)
+}
+
+// fitInside scales w by h to fit in maxW by maxH, keeping its aspect ratio
+func fitInside(w, h, maxW, maxH float64) (float64, float64) {
+	scale := maxW / w
+	if maxH/h < scale {
+		scale = maxH / h
+	}
+	return w * scale, h * scale
+}
@@ -0,0 +1,213 @@
+package biodata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"strings"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	const width = 100
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "empty", text: "", want: []string{"-"}},
+		{name: "blank", text: "  \t ", want: []string{"-"}},
+		{name: "fits", text: "Kozhikode", want: []string{"Kozhikode"}},
+		{name: "collapses spaces", text: "  B.Tech   Engineer ", want: []string{"B.Tech Engineer"}},
+		{
+			name: "breaks between words",
+			text: "Software engineer at a company in Kochi",
+			want: []string{"Software engineer at", "a company in Kochi"},
+		},
+		{
+			name: "splits a word too long for a line",
+			text: "Pneumonoultramicroscopicsilicovolcanoconiosis",
+			want: []string{"Pneumonoultramicros", "copicsilicovolcanocon", "iosis"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrap(tt.text, regular, fieldSize, width)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("wrap(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			for _, line := range got {
+				if w := regular.textWidth(line, fieldSize); w > width {
+					t.Errorf("line %q is %.1f points wide, more than %d", line, w, width)
+				}
+			}
+		})
+	}
+}
+
+func TestFitInside(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         float64
+		wantW, wantH float64
+	}{
+		{name: "portrait", w: 400, h: 600, wantW: 100, wantH: 150},
+		{name: "landscape", w: 600, h: 300, wantW: 120, wantH: 60},
+		{name: "same ratio", w: 60, h: 75, wantW: 120, wantH: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := fitInside(tt.w, tt.h, photoWidth, photoHeight)
+			if w != tt.wantW || h != tt.wantH {
+				t.Errorf("fitInside(%v, %v) = %v, %v, want %v, %v", tt.w, tt.h, w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+// testJPEG encodes a plain JPEG of the given size
+func testJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 120, B: 80, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("encoding JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testSheet(fields int) *Sheet {
+	personal := make([]Field, fields)
+	for i := range personal {
+		personal[i] = Field{Label: fmt.Sprintf("Label %d", i), Value: fmt.Sprintf("Value %d", i)}
+	}
+	return &Sheet{
+		Title:    "Fathima Zahra",
+		Subtitle: "Profile QK-12345",
+		Sections: []Section{
+			{Title: "Personal Details", Fields: personal},
+			{Title: "About", Text: "Soft spoken and family oriented.\nLoves reading."},
+		},
+		Footer: "Shared through Qubool Kallyaanam",
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Run("single page", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Render(&buf, testSheet(5)); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		pdf := parsePDF(t, buf.Bytes())
+
+		if got := len(pdf.find("/Type /Page ")); got != 1 {
+			t.Errorf("document has %d pages, want 1", got)
+		}
+		if got := pdf.find("/Subtype /Image"); len(got) != 0 {
+			t.Errorf("document without photo has %d images", len(got))
+		}
+	})
+
+	t.Run("flows onto more pages with the footer on each", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Render(&buf, testSheet(120)); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		pdf := parsePDF(t, buf.Bytes())
+
+		pages := len(pdf.find("/Type /Page "))
+		if pages < 3 {
+			t.Fatalf("document has %d pages, want at least 3", pages)
+		}
+
+		var footer strings.Builder
+		for _, g := range regular.glyphs("Shared through Qubool Kallyaanam") {
+			fmt.Fprintf(&footer, "%04X", uint16(g.id))
+		}
+		want := fmt.Sprintf("BT /F1 8.0 Tf %.2f %.2f Td <%s> Tj ET", float64(margin), float64(footerY), footer.String())
+		if got := len(pdf.find(want)); got != pages {
+			t.Errorf("footer drawn on %d pages, want %d", got, pages)
+		}
+	})
+
+	t.Run("with photo", func(t *testing.T) {
+		sheet := testSheet(5)
+		sheet.Photo = testJPEG(t, 40, 60)
+
+		var buf bytes.Buffer
+		if err := Render(&buf, sheet); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		pdf := parsePDF(t, buf.Bytes())
+
+		images := pdf.find("/Subtype /Image")
+		if len(images) != 1 {
+			t.Fatalf("document has %d images, want 1", len(images))
+		}
+		for _, want := range []string{"/Width 40", "/Height 60", "/ColorSpace /DeviceRGB", "/Filter /DCTDecode"} {
+			if !strings.Contains(images[0], want) {
+				t.Errorf("image object lacks %s", want)
+			}
+		}
+		if data := stream(t, images[0]); !bytes.Equal(data, sheet.Photo) {
+			t.Error("image stream is not the photo")
+		}
+
+		// A 40x60 photo is scaled to 100x150 and centered in the 120 point wide box
+		x := pageWidth - margin - photoWidth + (photoWidth-100)/2
+		y := pageHeight - margin - photoHeight
+		want := fmt.Sprintf("q 100.00 0 0 150.00 %.2f %.2f cm /Im1 Do Q", float64(x), float64(y))
+		if got := len(pdf.find(want)); got != 1 {
+			t.Errorf("found %d pages drawing %q, want 1", got, want)
+		}
+	})
+
+	t.Run("photo that isn't a JPEG", func(t *testing.T) {
+		sheet := testSheet(5)
+		sheet.Photo = []byte("\x89PNG\r\n\x1a\n")
+
+		err := Render(&bytes.Buffer{}, sheet)
+		if !errors.Is(err, ErrUnsupportedPhoto) {
+			t.Errorf("Render() error = %v, want ErrUnsupportedPhoto", err)
+		}
+	})
+}
+
+func TestLayoutWidth(t *testing.T) {
+	full := float64(pageWidth - 2*margin)
+	besidePhoto := full - photoWidth - 15
+
+	l := &layout{doc: &document{width: pageWidth, height: pageHeight}}
+	l.startPage()
+	if got := l.width(); got != full {
+		t.Errorf("width() without photo = %v, want %v", got, full)
+	}
+
+	l.clearBelow = 600
+	l.y = 650
+	if got := l.width(); got != besidePhoto {
+		t.Errorf("width() beside the photo = %v, want %v", got, besidePhoto)
+	}
+	l.y = 590
+	if got := l.width(); got != full {
+		t.Errorf("width() below the photo = %v, want %v", got, full)
+	}
+
+	l.startPage()
+	l.y = 650
+	if got := l.width(); got != full {
+		t.Errorf("width() on the next page = %v, want %v", got, full)
+	}
+}
@@ -0,0 +1,304 @@
+package biodata
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	xfont "golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// font is a TrueType font embedded in the document, so that text in any script the font
+// covers can be shown. Text is written as glyph IDs through the Identity-H encoding.
+type font struct {
+	resource   string // name in the page resources, e.g. F1
+	name       string // PostScript name
+	length     int    // size of the TrueType file
+	compressed []byte // the TrueType file, zlib compressed for embedding
+	sfnt       *sfnt.Font
+}
+
+// glyph is a glyph of a font and the character it shows
+type glyph struct {
+	id   sfnt.GlyphIndex
+	text rune
+}
+
+var (
+	regular = mustParseFont("F1", goregular.TTF)
+	bold    = mustParseFont("F2", gobold.TTF)
+)
+
+// mustParseFont parses a TrueType font bundled with the service
+func mustParseFont(resource string, data []byte) *font {
+	f, err := sfnt.Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("biodata: parsing font %s: %v", resource, err))
+	}
+
+	name, err := f.Name(nil, sfnt.NameIDPostScript)
+	if err != nil || name == "" {
+		name = "Font" + resource
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		panic(fmt.Sprintf("biodata: compressing font %s: %v", resource, err))
+	}
+	if err := zw.Close(); err != nil {
+		panic(fmt.Sprintf("biodata: compressing font %s: %v", resource, err))
+	}
+
+	return &font{resource: resource, name: name, length: len(data), compressed: compressed.Bytes(), sfnt: f}
+}
+
+// glyphs maps s to the glyphs of the font, showing characters the font lacks as '?'
+// and control characters as spaces
+func (f *font) glyphs(s string) []glyph {
+	var buf sfnt.Buffer
+	glyphs := make([]glyph, 0, len(s))
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			r = ' '
+		}
+		id, err := f.sfnt.GlyphIndex(&buf, r)
+		if err != nil || id == 0 {
+			r = '?'
+			id, _ = f.sfnt.GlyphIndex(&buf, r)
+		}
+		glyphs = append(glyphs, glyph{id: id, text: r})
+	}
+	return glyphs
+}
+
+// glyphWidth returns the advance width of a glyph in thousandths of the font size
+func (f *font) glyphWidth(buf *sfnt.Buffer, g sfnt.GlyphIndex) int {
+	advance, err := f.sfnt.GlyphAdvance(buf, g, fixed.I(1000), xfont.HintingNone)
+	if err != nil {
+		return 0
+	}
+	return advance.Round()
+}
+
+// textWidth returns the width of s in points when set in f at size
+func (f *font) textWidth(s string, size float64) float64 {
+	var buf sfnt.Buffer
+	total := 0
+	for _, g := range f.glyphs(s) {
+		total += f.glyphWidth(&buf, g.id)
+	}
+	return float64(total) * size / 1000
+}
+
+// jpegImage is a JPEG placed on a page as-is, since PDF decodes JPEG natively
+type jpegImage struct {
+	data       []byte
+	width      int
+	height     int
+	colorSpace string
+}
+
+// page accumulates the content stream of one page
+type page struct {
+	doc     *document
+	content bytes.Buffer
+}
+
+// text draws s with its baseline starting at x, y
+func (p *page) text(f *font, size, x, y float64, s string) {
+	glyphs := f.glyphs(s)
+	p.doc.use(f, glyphs)
+
+	hex := make([]byte, 0, 4*len(glyphs))
+	for _, g := range glyphs {
+		hex = fmt.Appendf(hex, "%04X", uint16(g.id))
+	}
+	fmt.Fprintf(&p.content, "BT /%s %.1f Tf %.2f %.2f Td <%s> Tj ET\n", f.resource, size, x, y, hex)
+}
+
+// line draws a straight line of the given width and grey level
+func (p *page) line(x1, y1, x2, y2, width, grey float64) {
+	fmt.Fprintf(&p.content, "%.2f G %.2f w %.2f %.2f m %.2f %.2f l S\n", grey, width, x1, y1, x2, y2)
+}
+
+// rect strokes a rectangle with its lower left corner at x, y
+func (p *page) rect(x, y, w, h, width, grey float64) {
+	fmt.Fprintf(&p.content, "%.2f G %.2f w %.2f %.2f %.2f %.2f re S\n", grey, width, x, y, w, h)
+}
+
+// drawImage places the document's image with its lower left corner at x, y
+func (p *page) drawImage(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "q %.2f 0 0 %.2f %.2f %.2f cm /Im1 Do Q\n", w, h, x, y)
+}
+
+// document is a minimal PDF writer for text, lines and a single JPEG image
+type document struct {
+	width, height float64
+	pages         []*page
+	image         *jpegImage
+	// used records the characters shown with each glyph of each font, for the glyph
+	// widths and the mapping back to text that copying and searching rely on
+	used map[*font]map[sfnt.GlyphIndex]rune
+}
+
+// newPage starts a new page and returns it
+func (d *document) newPage() *page {
+	p := &page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// use records the glyphs of f shown in the document
+func (d *document) use(f *font, glyphs []glyph) {
+	if d.used == nil {
+		d.used = make(map[*font]map[sfnt.GlyphIndex]rune)
+	}
+	if d.used[f] == nil {
+		d.used[f] = make(map[sfnt.GlyphIndex]rune)
+	}
+	for _, g := range glyphs {
+		d.used[f][g.id] = g.text
+	}
+}
+
+// objectList collects the numbered objects of a document, numbered from 1
+type objectList struct {
+	objects [][]byte
+}
+
+// add adds an object and returns its number
+func (o *objectList) add(format string, args ...interface{}) int {
+	o.objects = append(o.objects, []byte(fmt.Sprintf(format, args...)))
+	return len(o.objects)
+}
+
+// addStream adds a stream object and returns its number. dict is the start of the
+// stream dictionary, which is completed with the stream length.
+func (o *objectList) addStream(dict string, data []byte) int {
+	obj := fmt.Appendf(nil, "%s /Length %d >>\nstream\n", dict, len(data))
+	obj = append(obj, data...)
+	o.objects = append(o.objects, append(obj, "\nendstream"...))
+	return len(o.objects)
+}
+
+// addFont adds the objects of an embedded font and returns the number of its Type0 font
+// object. The font file is embedded whole, compressed, rather than subset.
+func (d *document) addFont(objects *objectList, f *font) int {
+	var buf sfnt.Buffer
+	metrics, err := f.sfnt.Metrics(&buf, fixed.I(1000), xfont.HintingNone)
+	if err != nil {
+		metrics = xfont.Metrics{Ascent: fixed.I(800), Descent: fixed.I(200), CapHeight: fixed.I(700)}
+	}
+	bounds, err := f.sfnt.Bounds(&buf, fixed.I(1000), xfont.HintingNone)
+	if err != nil {
+		bounds = fixed.R(0, -200, 1000, 800)
+	}
+
+	fileID := objects.addStream(fmt.Sprintf("<< /Filter /FlateDecode /Length1 %d", f.length), f.compressed)
+
+	// Font bounds are y-down, PDF font boxes y-up
+	descriptorID := objects.add("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] "+
+		"/ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, bounds.Min.X.Round(), -bounds.Max.Y.Round(), bounds.Max.X.Round(), -bounds.Min.Y.Round(),
+		metrics.Ascent.Round(), -metrics.Descent.Round(), metrics.CapHeight.Round(), fileID)
+
+	used := d.used[f]
+	ids := make([]int, 0, len(used))
+	for g := range used {
+		ids = append(ids, int(g))
+	}
+	sort.Ints(ids)
+
+	var widths, toUnicode strings.Builder
+	for _, id := range ids {
+		fmt.Fprintf(&widths, "%d [%d] ", id, f.glyphWidth(&buf, sfnt.GlyphIndex(id)))
+	}
+	toUnicode.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(ids); start += 100 {
+		chunk := ids[start:min(start+100, len(ids))]
+		fmt.Fprintf(&toUnicode, "%d beginbfchar\n", len(chunk))
+		for _, id := range chunk {
+			fmt.Fprintf(&toUnicode, "<%04X> <", id)
+			for _, u := range utf16.Encode([]rune{used[sfnt.GlyphIndex(id)]}) {
+				fmt.Fprintf(&toUnicode, "%04X", u)
+			}
+			toUnicode.WriteString(">\n")
+		}
+		toUnicode.WriteString("endbfchar\n")
+	}
+	toUnicode.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	toUnicodeID := objects.addStream("<<", []byte(toUnicode.String()))
+
+	cidFontID := objects.add("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+		"/FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW 1000 /W [%s] >>",
+		f.name, descriptorID, strings.TrimSpace(widths.String()))
+
+	return objects.add("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H "+
+		"/DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>", f.name, cidFontID, toUnicodeID)
+}
+
+// writeTo serializes the document. Objects are numbered in a fixed order:
+// catalog, page tree, fonts, the image if any, then each page and its content.
+// Fonts are written after the pages are laid out, once the glyphs used are known.
+func (d *document) writeTo(w io.Writer) error {
+	objects := &objectList{}
+	add := objects.add
+
+	add("<< /Type /Catalog /Pages 2 0 R >>")
+	pagesIndex := add("") // filled in once the page objects are numbered
+	regularID := d.addFont(objects, regular)
+	boldID := d.addFont(objects, bold)
+
+	xObjects := ""
+	if d.image != nil {
+		imageID := objects.addStream(fmt.Sprintf(
+			"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode",
+			d.image.width, d.image.height, d.image.colorSpace), d.image.data)
+		xObjects = fmt.Sprintf(" /XObject << /Im1 %d 0 R >>", imageID)
+	}
+
+	kids := make([]string, len(d.pages))
+	for i, p := range d.pages {
+		contentID := add("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String())
+		pageID := add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /%s %d 0 R /%s %d 0 R >>%s >> /Contents %d 0 R >>",
+			d.width, d.height, regular.resource, regularID, bold.resource, boldID, xObjects, contentID)
+		kids[i] = fmt.Sprintf("%d 0 R", pageID)
+	}
+	objects.objects[pagesIndex-1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, len(objects.objects))
+	for i, obj := range objects.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(obj)
+		buf.WriteString("\nendobj\n")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects.objects)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,223 @@
+package biodata
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestGlyphs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string // the characters the glyphs show
+	}{
+		{name: "ascii", text: "Ameen K", want: "Ameen K"},
+		{name: "latin-1", text: "Zoë Müller", want: "Zoë Müller"},
+		{name: "beyond latin-1", text: "Łukasz – “Nikolaj”", want: "Łukasz – “Nikolaj”"},
+		{name: "cyrillic and greek", text: "Жанна Σοφία", want: "Жанна Σοφία"},
+		{name: "control characters", text: "a\tb\nc", want: "a b c"},
+		{name: "missing from the font", text: "Ameen മ", want: "Ameen ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var shown strings.Builder
+			for _, g := range regular.glyphs(tt.text) {
+				if g.id == 0 {
+					t.Errorf("glyphs(%q) uses the missing glyph for %q", tt.text, g.text)
+				}
+				shown.WriteRune(g.text)
+			}
+			if shown.String() != tt.want {
+				t.Errorf("glyphs(%q) show %q, want %q", tt.text, shown.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	if got := regular.textWidth("", 10); got != 0 {
+		t.Errorf("textWidth(\"\") = %v, want 0", got)
+	}
+
+	narrow := regular.textWidth("iiii", 10)
+	wide := regular.textWidth("WWWW", 10)
+	if narrow <= 0 || wide <= narrow {
+		t.Errorf("textWidth(iiii) = %v, textWidth(WWWW) = %v, want 0 < iiii < WWWW", narrow, wide)
+	}
+
+	if got, want := regular.textWidth("WWWW", 20), 2*wide; got != want {
+		t.Errorf("textWidth at double size = %v, want %v", got, want)
+	}
+	if got := bold.textWidth("Жанна", 10); got <= 0 {
+		t.Errorf("textWidth(Жанна) = %v, want > 0", got)
+	}
+}
+
+// parsedPDF is a document read back from the output of writeTo
+type parsedPDF struct {
+	data    []byte
+	objects map[int]string
+}
+
+// parsePDF reads back the objects of a document through its cross-reference table
+func parsePDF(t *testing.T, data []byte) *parsedPDF {
+	t.Helper()
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) {
+		t.Fatalf("document starts with %q, want a PDF header", data[:min(len(data), 10)])
+	}
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("document has no startxref trailer")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d doesn't point at the xref table", xref)
+	}
+
+	var count int
+	lines := strings.Split(string(data[xref:]), "\n")
+	if _, err := fmt.Sscanf(lines[1], "0 %d", &count); err != nil {
+		t.Fatalf("xref subsection header %q: %v", lines[1], err)
+	}
+
+	doc := &parsedPDF{data: data, objects: make(map[int]string)}
+	for n := 1; n < count; n++ {
+		offset, err := strconv.Atoi(strings.Fields(lines[2+n])[0])
+		if err != nil {
+			t.Fatalf("xref entry %q: %v", lines[2+n], err)
+		}
+		header := fmt.Sprintf("%d 0 obj\n", n)
+		if !bytes.HasPrefix(data[offset:], []byte(header)) {
+			t.Fatalf("xref entry of object %d points at %q", n, data[offset:offset+min(20, len(data)-offset)])
+		}
+		body := data[offset+len(header):]
+		end := bytes.Index(body, []byte("\nendobj\n"))
+		if end < 0 {
+			t.Fatalf("object %d has no endobj", n)
+		}
+		doc.objects[n] = string(body[:end])
+	}
+
+	return doc
+}
+
+// find returns the objects containing s
+func (d *parsedPDF) find(s string) []string {
+	var found []string
+	for n := 1; n <= len(d.objects); n++ {
+		if strings.Contains(d.objects[n], s) {
+			found = append(found, d.objects[n])
+		}
+	}
+	return found
+}
+
+// stream returns the data of a stream object, inflated if it is compressed
+func stream(t *testing.T, obj string) []byte {
+	t.Helper()
+
+	start := strings.Index(obj, "\nstream\n")
+	if start < 0 || !strings.HasSuffix(obj, "\nendstream") {
+		t.Fatalf("object is not a stream: %.60q", obj)
+	}
+	data := []byte(obj[start+len("\nstream\n") : len(obj)-len("\nendstream")])
+
+	var length int
+	if m := regexp.MustCompile(`/Length (\d+)`).FindStringSubmatch(obj[:start]); m != nil {
+		length, _ = strconv.Atoi(m[1])
+	}
+	if length != len(data) {
+		t.Fatalf("stream /Length %d, want %d", length, len(data))
+	}
+
+	if strings.Contains(obj[:start], "/FlateDecode") {
+		r, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("inflating stream: %v", err)
+		}
+		if data, err = io.ReadAll(r); err != nil {
+			t.Fatalf("inflating stream: %v", err)
+		}
+	}
+	return data
+}
+
+func TestWriteTo(t *testing.T) {
+	doc := &document{width: pageWidth, height: pageHeight}
+	first := doc.newPage()
+	first.text(bold, 20, 50, 800, "Жанна")
+	first.line(50, 790, 545, 790, 1, 0.5)
+	second := doc.newPage()
+	second.text(regular, 10, 50, 800, "Zoë (A)")
+
+	var buf bytes.Buffer
+	if err := doc.writeTo(&buf); err != nil {
+		t.Fatalf("writeTo() error = %v", err)
+	}
+	pdf := parsePDF(t, buf.Bytes())
+
+	if got := len(pdf.find("/Type /Page ")); got != 2 {
+		t.Errorf("document has %d pages, want 2", got)
+	}
+	if got := pdf.find("/Type /Pages"); len(got) != 1 || !strings.Contains(got[0], "/Count 2") {
+		t.Errorf("page tree = %q, want one with /Count 2", got)
+	}
+
+	fonts := pdf.find("/Subtype /Type0")
+	if len(fonts) != 2 {
+		t.Fatalf("document has %d Type0 fonts, want 2", len(fonts))
+	}
+	for _, f := range fonts {
+		if !strings.Contains(f, "/Encoding /Identity-H") {
+			t.Errorf("font %q is not Identity-H encoded", f)
+		}
+	}
+
+	files := pdf.find("/Length1 ")
+	if len(files) != 2 {
+		t.Fatalf("document embeds %d font files, want 2", len(files))
+	}
+	for _, f := range files {
+		data := stream(t, f)
+		if !bytes.Equal(data, goregular.TTF) && !bytes.Equal(data, gobold.TTF) {
+			t.Errorf("embedded font file of %d bytes is neither of the bundled fonts", len(data))
+		}
+	}
+
+	// Text is drawn as glyph IDs, and the ToUnicode maps give the characters back
+	var mapped strings.Builder
+	for _, obj := range pdf.find("/CMapName") {
+		mapped.Write(stream(t, obj))
+	}
+	for _, r := range "ЖанZoë(A) " {
+		g := regular.glyphs(string(r))[0]
+		if strings.ContainsRune("Жан", r) {
+			g = bold.glyphs(string(r))[0]
+		}
+		entry := fmt.Sprintf("<%04X> <%04X>", uint16(g.id), r)
+		if !strings.Contains(mapped.String(), entry) {
+			t.Errorf("ToUnicode maps have no entry %s for %q", entry, r)
+		}
+	}
+
+	var title strings.Builder
+	for _, g := range bold.glyphs("Жанна") {
+		fmt.Fprintf(&title, "%04X", uint16(g.id))
+	}
+	want := fmt.Sprintf("BT /F2 20.0 Tf 50.00 800.00 Td <%s> Tj ET", title.String())
+	if contents := pdf.find(want); len(contents) != 1 {
+		t.Errorf("found %d content streams drawing %q, want 1", len(contents), want)
+	}
+}
@@ -0,0 +1,155 @@
+package completeness
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// Section names, in the order they are shown on the onboarding checklist
+const (
+	SectionBasic       = "basic"
+	SectionPhotos      = "photos"
+	SectionEducation   = "education"
+	SectionFamily      = "family"
+	SectionPreferences = "preferences"
+	SectionAboutMe     = "about_me"
+)
+
+// Thresholds for items that need more than a value to count as done
+const (
+	// RecommendedPhotos is the number of photos a profile should have
+	RecommendedPhotos = 3
+
+	// MinAboutMeLength is the number of characters an about me must have
+	MinAboutMeLength = 50
+)
+
+// Facts is everything the score depends on. Photos and preferences are kept apart
+// from the profile row, so callers look them up.
+type Facts struct {
+	Profile        *model.UserProfile
+	PhotoCount     int
+	HasPreferences bool
+	// PhotosUnknown is set when the photos can't be counted. The photos section is
+	// then left out, and the other sections make up the whole score.
+	PhotosUnknown bool
+}
+
+// Item is one thing to fill in on the onboarding checklist
+type Item struct {
+	Section string
+	Field   string
+	Label   string
+}
+
+// SectionResult is how complete one section is
+type SectionResult struct {
+	Name    string
+	Weight  int
+	Score   int // percentage of the section's items that are done
+	Missing []Item
+}
+
+// Result is how complete a profile is
+type Result struct {
+	Score    int // weighted percentage over all sections
+	Sections []SectionResult
+	Missing  []Item // the missing items of every section, in checklist order
+}
+
+// check is an item along with how to tell whether it is done
+type check struct {
+	Item
+	done func(f *Facts) bool
+}
+
+// section is a weighted group of checks
+type section struct {
+	name   string
+	weight int
+	checks []check
+	// measurable reports whether the facts tell if the checks are done, nil if always
+	measurable func(f *Facts) bool
+}
+
+// sections weight each part of a profile by how much it helps matches decide.
+// The weights add up to 100.
+var sections = []section{
+	{name: SectionBasic, weight: 30, checks: []check{
+		{Item{Field: "name", Label: "Add your name"}, func(f *Facts) bool { return filled(f.Profile.Name) }},
+		{Item{Field: "date_of_birth", Label: "Add your date of birth"}, func(f *Facts) bool { return !f.Profile.DateOfBirth.IsZero() }},
+		{Item{Field: "community", Label: "Choose your community"}, func(f *Facts) bool { return f.Profile.Community != "" }},
+		{Item{Field: "marital_status", Label: "Choose your marital status"}, func(f *Facts) bool { return f.Profile.MaritalStatus != "" }},
+		{Item{Field: "height", Label: "Add your height"}, func(f *Facts) bool { return f.Profile.Height > 0 }},
+		{Item{Field: "weight", Label: "Add your weight"}, func(f *Facts) bool { return f.Profile.Weight > 0 }},
+		{Item{Field: "home_district", Label: "Choose your home district"}, func(f *Facts) bool { return f.Profile.HomeDistrict != "" }},
+		{Item{Field: "residence_city", Label: "Add the city you live in"}, func(f *Facts) bool { return filled(f.Profile.ResidenceCity) }},
+	}},
+	{name: SectionPhotos, weight: 20, checks: []check{
+		{Item{Field: "photo", Label: "Upload a profile photo"}, func(f *Facts) bool { return f.PhotoCount > 0 }},
+		{Item{Field: "photos", Label: "Upload at least 3 photos"}, func(f *Facts) bool { return f.PhotoCount >= RecommendedPhotos }},
+	}, measurable: func(f *Facts) bool { return !f.PhotosUnknown }},
+	{name: SectionEducation, weight: 15, checks: []check{
+		{Item{Field: "education", Label: "Add your education"}, func(f *Facts) bool { return filled(f.Profile.Education) }},
+		{Item{Field: "occupation", Label: "Add your occupation"}, func(f *Facts) bool { return filled(f.Profile.Occupation) }},
+	}},
+	{name: SectionFamily, weight: 15, checks: []check{
+		{Item{Field: "father_occupation", Label: "Add your father's occupation"}, func(f *Facts) bool { return filled(f.Profile.FatherOccupation) }},
+		{Item{Field: "mother_occupation", Label: "Add your mother's occupation"}, func(f *Facts) bool { return filled(f.Profile.MotherOccupation) }},
+		{Item{Field: "siblings", Label: "Tell us about your siblings"}, func(f *Facts) bool { return filled(f.Profile.Siblings) }},
+	}},
+	{name: SectionPreferences, weight: 10, checks: []check{
+		{Item{Field: "partner_preferences", Label: "Set your partner preferences"}, func(f *Facts) bool { return f.HasPreferences }},
+	}},
+	{name: SectionAboutMe, weight: 10, checks: []check{
+		{Item{Field: "about_me", Label: "Write at least 50 characters about yourself"}, func(f *Facts) bool {
+			return utf8.RuneCountInString(strings.TrimSpace(f.Profile.AboutMe)) >= MinAboutMeLength
+		}},
+	}},
+}
+
+// Evaluate scores how complete a profile is and lists what is missing from it.
+// Each section contributes its weight in proportion to the items done in it.
+// Sections that can't be measured from the facts are left out of the score and
+// the checklist.
+func Evaluate(f *Facts) *Result {
+	result := &Result{Sections: make([]SectionResult, 0, len(sections))}
+
+	weighted, total := 0, 0
+	for _, s := range sections {
+		if s.measurable != nil && !s.measurable(f) {
+			continue
+		}
+		total += s.weight
+
+		sr := SectionResult{Name: s.name, Weight: s.weight}
+
+		done := 0
+		for _, c := range s.checks {
+			if c.done(f) {
+				done++
+				continue
+			}
+			item := c.Item
+			item.Section = s.name
+			sr.Missing = append(sr.Missing, item)
+		}
+
+		sr.Score = done * 100 / len(s.checks)
+		weighted += s.weight * done * 100 / len(s.checks)
+
+		result.Sections = append(result.Sections, sr)
+		result.Missing = append(result.Missing, sr.Missing...)
+	}
+
+	result.Score = weighted / total
+
+	return result
+}
+
+// filled reports whether a free-text value has more than whitespace
+func filled(s string) bool {
+	return strings.TrimSpace(s) != ""
+}
@@ -0,0 +1,107 @@
+package completeness
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// completeProfile has every profile field of the checklist filled in
+func completeProfile() *model.UserProfile {
+	return &model.UserProfile{
+		Name:             "Fathima Zahra",
+		DateOfBirth:      time.Date(1998, time.March, 4, 0, 0, 0, 0, time.UTC),
+		Community:        "sunni",
+		MaritalStatus:    "never_married",
+		Height:           160,
+		Weight:           55,
+		HomeDistrict:     "kozhikode",
+		ResidenceCity:    "Kochi",
+		Education:        "B.Tech",
+		Occupation:       "Software engineer",
+		FatherOccupation: "Teacher",
+		MotherOccupation: "Homemaker",
+		Siblings:         "One elder brother",
+		AboutMe:          strings.Repeat("a", MinAboutMeLength),
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		facts       *Facts
+		wantScore   int
+		wantMissing []string
+		wantPhotos  bool // whether the photos section is in the result
+	}{
+		{
+			name:       "complete",
+			facts:      &Facts{Profile: completeProfile(), PhotoCount: RecommendedPhotos, HasPreferences: true},
+			wantScore:  100,
+			wantPhotos: true,
+		},
+		{
+			name:        "one photo",
+			facts:       &Facts{Profile: completeProfile(), PhotoCount: 1, HasPreferences: true},
+			wantScore:   90,
+			wantMissing: []string{"photos"},
+			wantPhotos:  true,
+		},
+		{
+			name:        "no photos",
+			facts:       &Facts{Profile: completeProfile(), HasPreferences: true},
+			wantScore:   80,
+			wantMissing: []string{"photo", "photos"},
+			wantPhotos:  true,
+		},
+		{
+			name:      "photos unknown",
+			facts:     &Facts{Profile: completeProfile(), PhotosUnknown: true, HasPreferences: true},
+			wantScore: 100,
+		},
+		{
+			// 70 of the 80 measurable points
+			name:        "photos unknown without preferences",
+			facts:       &Facts{Profile: completeProfile(), PhotosUnknown: true},
+			wantScore:   87,
+			wantMissing: []string{"partner_preferences"},
+		},
+		{
+			name:  "empty",
+			facts: &Facts{Profile: &model.UserProfile{}, PhotosUnknown: true},
+			wantMissing: []string{
+				"name", "date_of_birth", "community", "marital_status", "height", "weight", "home_district",
+				"residence_city", "education", "occupation", "father_occupation", "mother_occupation", "siblings",
+				"partner_preferences", "about_me",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(tt.facts)
+
+			if result.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d", result.Score, tt.wantScore)
+			}
+
+			var missing []string
+			for _, item := range result.Missing {
+				missing = append(missing, item.Field)
+			}
+			if strings.Join(missing, ",") != strings.Join(tt.wantMissing, ",") {
+				t.Errorf("Missing = %v, want %v", missing, tt.wantMissing)
+			}
+
+			hasPhotos := false
+			for _, s := range result.Sections {
+				hasPhotos = hasPhotos || s.Name == SectionPhotos
+			}
+			if hasPhotos != tt.wantPhotos {
+				t.Errorf("photos section in result = %v, want %v", hasPhotos, tt.wantPhotos)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"time"
 
@@ -13,6 +14,17 @@ type Config struct {
 	Database DatabaseConfig
 	Logging  LoggingConfig
 	JWT      JWTConfig
+	Search   SearchConfig
+	Jobs     JobsConfig
+	Matching MatchingConfig
+	Similar  SimilarConfig
+	Contact  ContactConfig
+	Payment  PaymentConfig
+	Boost    BoostConfig
+	Profile  ProfileConfig
+	Share    ShareConfig
+	Export   ExportConfig
+	Consent  ConsentConfig
 }
 
 // ServerConfig contains server related settings
@@ -54,12 +66,151 @@ type JWTConfig struct {
 	Issuer        string
 }
 
+// SearchConfig contains profile search settings
+type SearchConfig struct {
+	DefaultLimit int
+	MaxLimit     int
+	CursorSecret string // HMAC secret signing pagination cursors
+}
+
+// JobsConfig contains background job settings
+type JobsConfig struct {
+	Enabled                  bool
+	SavedSearchAlertInterval time.Duration
+	RecommendationInterval   time.Duration
+	ProfilePurgeInterval     time.Duration
+	DataExportInterval       time.Duration
+	AccountErasureInterval   time.Duration
+}
+
+// MatchingConfig contains match recommendation settings
+type MatchingConfig struct {
+	DailyRecommendations int           // recommendations generated per profile per day
+	CandidatePoolSize    int           // candidates scored per profile per run
+	RepeatAfter          time.Duration // before a recommended profile may be recommended again
+	SkipCooldown         time.Duration // before a skipped profile may be recommended again
+}
+
+// SimilarConfig contains "similar profiles" settings
+type SimilarConfig struct {
+	PoolSize       int           // candidates closest in age that are scored
+	AgeWindowYears int           // candidates outside this age difference are not considered
+	MaxResults     int           // similar profiles returned at most
+	CacheTTL       time.Duration // how long ranked results of a profile are reused
+	CacheSize      int           // profiles whose results are cached at most
+	Weights        SimilarityWeights
+}
+
+// SimilarityWeights sets how much each attribute contributes to profile similarity
+type SimilarityWeights struct {
+	Age        float64
+	Height     float64
+	Community  float64
+	District   float64
+	Education  float64
+	Occupation float64
+}
+
+// ContactConfig contains contact details settings
+type ContactConfig struct {
+	EncryptionKey string // base64-encoded 32-byte AES key for contact details at rest
+}
+
+// PaymentConfig contains payment provider settings
+type PaymentConfig struct {
+	Provider      string // payment provider used for new subscriptions; only "fake" is built in
+	WebhookSecret string // shared secret authenticating the provider's webhooks
+}
+
+// BoostConfig contains profile boost settings
+type BoostConfig struct {
+	Duration     time.Duration // how long a boost features the profile
+	MaxPerPage   int           // boosted profiles placed on the first page of search results
+	SlotInterval int           // results between boosted placements, starting at the top
+}
+
+// ProfileConfig contains profile ownership and change history settings
+type ProfileConfig struct {
+	MaxPerAccount         int           // profiles one account may own, e.g. a parent managing several children
+	TransferTTL           time.Duration // how long a profile transfer token can be accepted
+	SensitiveChangeLimit  int           // changes of date of birth or marital status within the window that flag a revision
+	SensitiveChangeWindow time.Duration // how far back sensitive changes are counted
+	RestoreWindow         time.Duration // how long a deleted profile can be restored
+	Retention             time.Duration // how long a deleted profile is kept before it is anonymized
+}
+
+// ShareConfig contains public profile share link settings
+type ShareConfig struct {
+	Secret     string        // HMAC secret signing share link tokens
+	LinkTTL    time.Duration // how long a share link works when no expiry is requested
+	MaxLinkTTL time.Duration // longest expiry a share link can be given
+}
+
+// ExportConfig contains personal data export settings
+type ExportConfig struct {
+	Secret      string        // HMAC secret signing download tokens
+	DownloadTTL time.Duration // how long a download link works once handed out
+	ArchiveTTL  time.Duration // how long an assembled archive is kept
+}
+
+// ConsentConfig contains the current versions of the policies users agree to. Raising
+// the version of a mandatory policy requires every user to accept it again.
+type ConsentConfig struct {
+	TermsVersion         string
+	PrivacyPolicyVersion string
+	PhotoDisplayVersion  string
+	DataSharingVersion   string
+}
+
 func validateConfig(config *Config) error {
 	// Validate JWT configuration
 	if config.JWT.Secret == "" {
 		return fmt.Errorf("JWT_SECRET environment variable is required")
 	}
 
+	// Validate contact details encryption key
+	if config.Contact.EncryptionKey == "" {
+		return fmt.Errorf("CONTACT_ENCRYPTION_KEY environment variable is required")
+	}
+	if key, err := base64.StdEncoding.DecodeString(config.Contact.EncryptionKey); err != nil || len(key) != 32 {
+		return fmt.Errorf("CONTACT_ENCRYPTION_KEY must be a base64-encoded 32-byte key")
+	}
+
+	// Validate search cursor configuration
+	if config.Search.CursorSecret == "" {
+		return fmt.Errorf("SEARCH_CURSOR_SECRET environment variable is required")
+	}
+
+	// Validate payment configuration. There is no default provider, so that a deployment
+	// can't end up on the fake one, which collects no money, without choosing it.
+	if config.Payment.Provider == "" {
+		return fmt.Errorf("PAYMENT_PROVIDER environment variable is required")
+	}
+	if config.Payment.WebhookSecret == "" {
+		return fmt.Errorf("PAYMENT_WEBHOOK_SECRET environment variable is required")
+	}
+
+	// Validate share link configuration
+	if config.Share.Secret == "" {
+		return fmt.Errorf("SHARE_LINK_SECRET environment variable is required")
+	}
+
+	// Validate data export configuration
+	if config.Export.Secret == "" {
+		return fmt.Errorf("EXPORT_TOKEN_SECRET environment variable is required")
+	}
+
+	// Validate policy versions
+	if config.Consent.TermsVersion == "" || config.Consent.PrivacyPolicyVersion == "" ||
+		config.Consent.PhotoDisplayVersion == "" || config.Consent.DataSharingVersion == "" {
+		return fmt.Errorf("policy versions (CONSENT_*_VERSION) must not be empty")
+	}
+
+	// Deleted profiles must be kept at least as long as they can be restored
+	if config.Profile.Retention < config.Profile.RestoreWindow {
+		return fmt.Errorf("PROFILE_RETENTION must not be shorter than PROFILE_RESTORE_WINDOW")
+	}
+
 	// Validate database configuration
 	if config.Database.User == "" || config.Database.Password == "" {
 		return fmt.Errorf("database credentials (DB_USER, DB_PASSWORD) are required")
@@ -104,6 +255,76 @@ func LoadConfig() (*Config, error) {
 			RefreshExpiry: v.GetDuration("JWT_REFRESH_EXPIRY"),
 			Issuer:        v.GetString("JWT_ISSUER"),
 		},
+		Search: SearchConfig{
+			DefaultLimit: v.GetInt("SEARCH_DEFAULT_LIMIT"),
+			MaxLimit:     v.GetInt("SEARCH_MAX_LIMIT"),
+			CursorSecret: v.GetString("SEARCH_CURSOR_SECRET"),
+		},
+		Jobs: JobsConfig{
+			Enabled:                  v.GetBool("JOBS_ENABLED"),
+			SavedSearchAlertInterval: v.GetDuration("JOBS_SAVED_SEARCH_ALERT_INTERVAL"),
+			RecommendationInterval:   v.GetDuration("JOBS_RECOMMENDATION_INTERVAL"),
+			ProfilePurgeInterval:     v.GetDuration("JOBS_PROFILE_PURGE_INTERVAL"),
+			DataExportInterval:       v.GetDuration("JOBS_DATA_EXPORT_INTERVAL"),
+			AccountErasureInterval:   v.GetDuration("JOBS_ACCOUNT_ERASURE_INTERVAL"),
+		},
+		Matching: MatchingConfig{
+			DailyRecommendations: v.GetInt("MATCHING_DAILY_RECOMMENDATIONS"),
+			CandidatePoolSize:    v.GetInt("MATCHING_CANDIDATE_POOL_SIZE"),
+			RepeatAfter:          v.GetDuration("MATCHING_REPEAT_AFTER"),
+			SkipCooldown:         v.GetDuration("MATCHING_SKIP_COOLDOWN"),
+		},
+		Similar: SimilarConfig{
+			PoolSize:       v.GetInt("SIMILAR_POOL_SIZE"),
+			AgeWindowYears: v.GetInt("SIMILAR_AGE_WINDOW_YEARS"),
+			MaxResults:     v.GetInt("SIMILAR_MAX_RESULTS"),
+			CacheTTL:       v.GetDuration("SIMILAR_CACHE_TTL"),
+			CacheSize:      v.GetInt("SIMILAR_CACHE_SIZE"),
+			Weights: SimilarityWeights{
+				Age:        v.GetFloat64("SIMILAR_WEIGHT_AGE"),
+				Height:     v.GetFloat64("SIMILAR_WEIGHT_HEIGHT"),
+				Community:  v.GetFloat64("SIMILAR_WEIGHT_COMMUNITY"),
+				District:   v.GetFloat64("SIMILAR_WEIGHT_DISTRICT"),
+				Education:  v.GetFloat64("SIMILAR_WEIGHT_EDUCATION"),
+				Occupation: v.GetFloat64("SIMILAR_WEIGHT_OCCUPATION"),
+			},
+		},
+		Contact: ContactConfig{
+			EncryptionKey: v.GetString("CONTACT_ENCRYPTION_KEY"),
+		},
+		Payment: PaymentConfig{
+			Provider:      v.GetString("PAYMENT_PROVIDER"),
+			WebhookSecret: v.GetString("PAYMENT_WEBHOOK_SECRET"),
+		},
+		Boost: BoostConfig{
+			Duration:     v.GetDuration("BOOST_DURATION"),
+			MaxPerPage:   v.GetInt("BOOST_MAX_PER_PAGE"),
+			SlotInterval: v.GetInt("BOOST_SLOT_INTERVAL"),
+		},
+		Profile: ProfileConfig{
+			MaxPerAccount:         v.GetInt("PROFILE_MAX_PER_ACCOUNT"),
+			TransferTTL:           v.GetDuration("PROFILE_TRANSFER_TTL"),
+			SensitiveChangeLimit:  v.GetInt("PROFILE_SENSITIVE_CHANGE_LIMIT"),
+			SensitiveChangeWindow: v.GetDuration("PROFILE_SENSITIVE_CHANGE_WINDOW"),
+			RestoreWindow:         v.GetDuration("PROFILE_RESTORE_WINDOW"),
+			Retention:             v.GetDuration("PROFILE_RETENTION"),
+		},
+		Share: ShareConfig{
+			Secret:     v.GetString("SHARE_LINK_SECRET"),
+			LinkTTL:    v.GetDuration("SHARE_LINK_TTL"),
+			MaxLinkTTL: v.GetDuration("SHARE_LINK_MAX_TTL"),
+		},
+		Export: ExportConfig{
+			Secret:      v.GetString("EXPORT_TOKEN_SECRET"),
+			DownloadTTL: v.GetDuration("EXPORT_DOWNLOAD_TTL"),
+			ArchiveTTL:  v.GetDuration("EXPORT_ARCHIVE_TTL"),
+		},
+		Consent: ConsentConfig{
+			TermsVersion:         v.GetString("CONSENT_TERMS_VERSION"),
+			PrivacyPolicyVersion: v.GetString("CONSENT_PRIVACY_POLICY_VERSION"),
+			PhotoDisplayVersion:  v.GetString("CONSENT_PHOTO_DISPLAY_VERSION"),
+			DataSharingVersion:   v.GetString("CONSENT_DATA_SHARING_VERSION"),
+		},
 	}
 
 	// Add this before returning:
@@ -137,6 +358,68 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("JWT_TOKEN_EXPIRY", "15m")
 	v.SetDefault("JWT_REFRESH_EXPIRY", "24h")
 	v.SetDefault("JWT_ISSUER", "qubool-kallyaanam-api")
+
+	// Search defaults
+	v.SetDefault("SEARCH_DEFAULT_LIMIT", 10)
+	v.SetDefault("SEARCH_MAX_LIMIT", 50)
+
+	// Background job defaults
+	v.SetDefault("JOBS_ENABLED", true)
+	v.SetDefault("JOBS_SAVED_SEARCH_ALERT_INTERVAL", "5m")
+	v.SetDefault("JOBS_RECOMMENDATION_INTERVAL", "1h")
+	v.SetDefault("JOBS_PROFILE_PURGE_INTERVAL", "24h")
+	v.SetDefault("JOBS_DATA_EXPORT_INTERVAL", "1m")
+	v.SetDefault("JOBS_ACCOUNT_ERASURE_INTERVAL", "1m")
+
+	// Matching defaults
+	v.SetDefault("MATCHING_DAILY_RECOMMENDATIONS", 20)
+	v.SetDefault("MATCHING_CANDIDATE_POOL_SIZE", 200)
+	v.SetDefault("MATCHING_REPEAT_AFTER", "720h")
+	v.SetDefault("MATCHING_SKIP_COOLDOWN", "2160h")
+
+	// Similar profiles defaults
+	v.SetDefault("SIMILAR_POOL_SIZE", 200)
+	v.SetDefault("SIMILAR_AGE_WINDOW_YEARS", 8)
+	v.SetDefault("SIMILAR_MAX_RESULTS", 20)
+	v.SetDefault("SIMILAR_CACHE_TTL", "15m")
+	v.SetDefault("SIMILAR_CACHE_SIZE", 1000)
+	v.SetDefault("SIMILAR_WEIGHT_AGE", 3)
+	v.SetDefault("SIMILAR_WEIGHT_HEIGHT", 1)
+	v.SetDefault("SIMILAR_WEIGHT_COMMUNITY", 2)
+	v.SetDefault("SIMILAR_WEIGHT_DISTRICT", 1.5)
+	v.SetDefault("SIMILAR_WEIGHT_EDUCATION", 1)
+	v.SetDefault("SIMILAR_WEIGHT_OCCUPATION", 1)
+
+	// Profile boost defaults
+	v.SetDefault("BOOST_DURATION", "24h")
+	v.SetDefault("BOOST_MAX_PER_PAGE", 2)
+	v.SetDefault("BOOST_SLOT_INTERVAL", 5)
+
+	// Profile ownership defaults
+	v.SetDefault("PROFILE_MAX_PER_ACCOUNT", 3)
+	v.SetDefault("PROFILE_TRANSFER_TTL", "72h")
+
+	// Profile change history defaults
+	v.SetDefault("PROFILE_SENSITIVE_CHANGE_LIMIT", 2)
+	v.SetDefault("PROFILE_SENSITIVE_CHANGE_WINDOW", "720h")
+
+	// Deleted profile defaults
+	v.SetDefault("PROFILE_RESTORE_WINDOW", "720h")
+	v.SetDefault("PROFILE_RETENTION", "2160h")
+
+	// Share link defaults
+	v.SetDefault("SHARE_LINK_TTL", "168h")
+	v.SetDefault("SHARE_LINK_MAX_TTL", "720h")
+
+	// Data export defaults
+	v.SetDefault("EXPORT_DOWNLOAD_TTL", "24h")
+	v.SetDefault("EXPORT_ARCHIVE_TTL", "168h")
+
+	// Policy version defaults
+	v.SetDefault("CONSENT_TERMS_VERSION", "1")
+	v.SetDefault("CONSENT_PRIVACY_POLICY_VERSION", "1")
+	v.SetDefault("CONSENT_PHOTO_DISPLAY_VERSION", "1")
+	v.SetDefault("CONSENT_DATA_SHARING_VERSION", "1")
 }
 
 // NewConfig creates a new configuration with default values - kept for backward compatibility
@@ -0,0 +1,112 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Section is one JSON document of an archive, such as the account's profiles
+type Section struct {
+	Name string // file name without the .json extension
+	Data any
+}
+
+// File is a binary file of an archive, such as a photo
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Archive is the content of a personal data export
+type Archive struct {
+	UserID      uuid.UUID
+	GeneratedAt time.Time
+	Sections    []Section
+	Files       []File
+}
+
+// manifestName is the archive entry describing the others
+const manifestName = "manifest.json"
+
+// manifest lists the entries of an archive with their checksums, so that the
+// recipient can tell that nothing was altered or lost
+type manifest struct {
+	UserID      uuid.UUID       `json:"user_id"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Entries     []manifestEntry `json:"entries"`
+}
+
+// manifestEntry describes one entry of an archive
+type manifestEntry struct {
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Write writes the archive as a ZIP holding a JSON document per section, the files,
+// and a manifest listing them
+func Write(w io.Writer, archive *Archive) error {
+	zw := zip.NewWriter(w)
+	m := manifest{
+		UserID:      archive.UserID,
+		GeneratedAt: archive.GeneratedAt.UTC(),
+	}
+
+	for _, section := range archive.Sections {
+		data, err := json.MarshalIndent(section.Data, "", "  ")
+		if err != nil {
+			return err
+		}
+		entry, err := writeEntry(zw, section.Name+".json", data, archive.GeneratedAt)
+		if err != nil {
+			return err
+		}
+		m.Entries = append(m.Entries, entry)
+	}
+
+	for _, file := range archive.Files {
+		entry, err := writeEntry(zw, file.Name, file.Data, archive.GeneratedAt)
+		if err != nil {
+			return err
+		}
+		m.Entries = append(m.Entries, entry)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := writeEntry(zw, manifestName, data, archive.GeneratedAt); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// writeEntry adds a file to the ZIP and returns its manifest entry
+func writeEntry(zw *zip.Writer, name string, data []byte, modified time.Time) (manifestEntry, error) {
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return manifestEntry{}, err
+	}
+	if _, err := f.Write(data); err != nil {
+		return manifestEntry{}, err
+	}
+
+	sum := sha256.Sum256(data)
+	return manifestEntry{
+		Name:   name,
+		Size:   len(data),
+		SHA256: hex.EncodeToString(sum[:]),
+	}, nil
+}
@@ -1,10 +1,17 @@
 package di
 
 import (
+	"fmt"
+
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/notification"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/payment"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/photo"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
 	postgresRepo "github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository/postgres"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/similarity"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/encryption"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
@@ -13,11 +20,55 @@ import (
 
 // Container holds application dependencies
 type Container struct {
-	Config             *config.Config
-	DB                 *gorm.DB
-	Logger             *logger.Logger
-	UserProfileRepo    repository.UserProfileRepository
-	UserProfileService service.UserProfileService
+	Config                 *config.Config
+	DB                     *gorm.DB
+	Logger                 *logger.Logger
+	Notifier               notification.Notifier
+	PhotoStore             photo.Store
+	UserProfileRepo        repository.UserProfileRepository
+	SavedSearchRepo        repository.SavedSearchRepository
+	SearchAlertRepo        repository.SearchAlertRepository
+	PreferenceRepo         repository.PartnerPreferenceRepository
+	BlockRepo              repository.ProfileBlockRepository
+	RecommendationRepo     repository.RecommendationRepository
+	InterestRepo           repository.InterestRepository
+	ContactDetailRepo      repository.ContactDetailRepository
+	ContactRevealRepo      repository.ContactRevealRepository
+	PlanRepo               repository.MembershipPlanRepository
+	SubscriptionRepo       repository.SubscriptionRepository
+	ProfileViewRepo        repository.ProfileViewRepository
+	ProfileBoostRepo       repository.ProfileBoostRepository
+	ProfileManagerRepo     repository.ProfileManagerRepository
+	ProfileTransferRepo    repository.ProfileTransferRepository
+	ProfilePrivacyRepo     repository.ProfilePrivacyRepository
+	ProfileShareLinkRepo   repository.ProfileShareLinkRepository
+	ProfileDraftRepo       repository.ProfileDraftRepository
+	ProfileRevisionRepo    repository.ProfileRevisionRepository
+	DataExportRepo         repository.DataExportRepository
+	AccountErasureRepo     repository.AccountErasureRepository
+	ConsentRecordRepo      repository.ConsentRecordRepository
+	PaymentProvider        payment.Provider
+	UserProfileService     service.UserProfileService
+	SavedSearchService     service.SavedSearchService
+	PreferenceService      service.PartnerPreferenceService
+	BlockService           service.ProfileBlockService
+	RecommendationService  service.RecommendationService
+	SimilarProfileService  service.SimilarProfileService
+	InterestService        service.InterestService
+	ContactService         service.ContactService
+	MembershipService      service.MembershipService
+	ProfileViewService     service.ProfileViewService
+	ProfileBoostService    service.ProfileBoostService
+	ProfileManagerService  service.ProfileManagerService
+	ProfileTransferService service.ProfileTransferService
+	ProfileShareService    service.ProfileShareService
+	CompletenessService    service.ProfileCompletenessService
+	ProfileDraftService    service.ProfileDraftService
+	ProfileRevisionService service.ProfileRevisionService
+	RetentionService       service.ProfileRetentionService
+	DataExportService      service.DataExportService
+	ErasureService         service.AccountErasureService
+	ConsentService         service.ConsentService
 }
 
 // NewContainer initializes the dependency container
@@ -35,17 +86,141 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		return nil, err
 	}
 
+	// Initialize notifier
+	notifier := notification.NewLogNotifier(log)
+
+	// Initialize photo store. There is no client for the media service yet, so profiles
+	// have no photos as far as this service can tell.
+	photoStore := photo.NewNoopStore()
+	log.Warn("No photo store configured: biodata sheets and data exports are produced without photos, " +
+		"and the photos section is left out of profile completeness")
+
 	// Initialize repositories
 	userProfileRepo := postgresRepo.NewUserProfileRepository(db)
+	savedSearchRepo := postgresRepo.NewSavedSearchRepository(db)
+	searchAlertRepo := postgresRepo.NewSearchAlertRepository(db)
+	preferenceRepo := postgresRepo.NewPartnerPreferenceRepository(db)
+	blockRepo := postgresRepo.NewProfileBlockRepository(db)
+	recommendationRepo := postgresRepo.NewRecommendationRepository(db)
+	interestRepo := postgresRepo.NewInterestRepository(db)
+	contactDetailRepo := postgresRepo.NewContactDetailRepository(db)
+	contactRevealRepo := postgresRepo.NewContactRevealRepository(db)
+	planRepo := postgresRepo.NewMembershipPlanRepository(db)
+	subscriptionRepo := postgresRepo.NewSubscriptionRepository(db)
+	profileViewRepo := postgresRepo.NewProfileViewRepository(db)
+	profileBoostRepo := postgresRepo.NewProfileBoostRepository(db)
+	profileManagerRepo := postgresRepo.NewProfileManagerRepository(db)
+	profileTransferRepo := postgresRepo.NewProfileTransferRepository(db)
+	profilePrivacyRepo := postgresRepo.NewProfilePrivacyRepository(db)
+	profileShareLinkRepo := postgresRepo.NewProfileShareLinkRepository(db)
+	profileDraftRepo := postgresRepo.NewProfileDraftRepository(db)
+	profileRevisionRepo := postgresRepo.NewProfileRevisionRepository(db)
+	dataExportRepo := postgresRepo.NewDataExportRepository(db)
+	accountErasureRepo := postgresRepo.NewAccountErasureRepository(db)
+	consentRecordRepo := postgresRepo.NewConsentRecordRepository(db)
+
+	// Initialize contact details cipher
+	contactCipher, err := encryption.NewCipherFromBase64(cfg.Contact.EncryptionKey)
+	if err != nil {
+		log.Error("Failed to initialize contact details cipher", zap.Error(err))
+		return nil, err
+	}
+
+	// Initialize payment provider
+	var paymentProvider payment.Provider
+	switch cfg.Payment.Provider {
+	case payment.FakeProviderName:
+		paymentProvider = payment.NewFakeProvider(cfg.Payment.WebhookSecret)
+	default:
+		err := fmt.Errorf("unsupported payment provider %q", cfg.Payment.Provider)
+		log.Error("Failed to initialize payment provider", zap.Error(err))
+		return nil, err
+	}
 
 	// Initialize services
-	userProfileService := service.NewUserProfileService(userProfileRepo, log)
+	membershipService := service.NewMembershipService(planRepo, subscriptionRepo, paymentProvider, log)
+	completenessService := service.NewProfileCompletenessService(userProfileRepo, preferenceRepo, photoStore, log)
+	userProfileService := service.NewUserProfileService(
+		userProfileRepo, profileViewRepo, profileBoostRepo, profileManagerRepo, blockRepo, profileRevisionRepo,
+		membershipService, completenessService, cfg.Search, cfg.Boost, cfg.Profile, log)
+	savedSearchService := service.NewSavedSearchService(
+		savedSearchRepo, searchAlertRepo, userProfileRepo, userProfileService, membershipService, notifier, log)
+	preferenceService := service.NewPartnerPreferenceService(preferenceRepo, userProfileRepo, completenessService, log)
+	blockService := service.NewProfileBlockService(blockRepo, userProfileRepo, log)
+	recommendationService := service.NewRecommendationService(
+		recommendationRepo, preferenceRepo, userProfileRepo, cfg.Matching, log)
+	similarProfileService := service.NewSimilarProfileService(
+		userProfileRepo, blockRepo, similarity.Weighted(similarity.Weights(cfg.Similar.Weights)), cfg.Similar, log)
+	interestService := service.NewInterestService(interestRepo, userProfileRepo, blockRepo, profileManagerRepo, log)
+	contactService := service.NewContactService(
+		contactDetailRepo, contactRevealRepo, interestRepo, userProfileRepo, blockRepo, profileManagerRepo,
+		contactCipher, membershipService, log)
+	profileViewService := service.NewProfileViewService(profileViewRepo, userProfileRepo, log)
+	profileBoostService := service.NewProfileBoostService(
+		profileBoostRepo, userProfileRepo, membershipService, cfg.Boost, log)
+	profileManagerService := service.NewProfileManagerService(profileManagerRepo, userProfileRepo, log)
+	profileTransferService := service.NewProfileTransferService(
+		profileTransferRepo, userProfileRepo, profileManagerRepo, cfg.Profile, log)
+	profileShareService := service.NewProfileShareService(
+		profilePrivacyRepo, profileShareLinkRepo, userProfileRepo, blockRepo, photoStore, cfg.Share, log)
+	profileDraftService := service.NewProfileDraftService(profileDraftRepo, userProfileRepo, userProfileService, log)
+	profileRevisionService := service.NewProfileRevisionService(
+		profileRevisionRepo, userProfileRepo, profileManagerRepo, completenessService, cfg.Profile, log)
+	retentionService := service.NewProfileRetentionService(
+		userProfileRepo, profileManagerRepo, accountErasureRepo, cfg.Profile, log)
+	dataExportService := service.NewDataExportService(dataExportRepo, photoStore, contactCipher, cfg.Export, log)
+	erasureService := service.NewAccountErasureService(accountErasureRepo, userProfileRepo, log)
+	consentService := service.NewConsentService(consentRecordRepo, cfg.Consent, log)
 
 	return &Container{
-		Config:             cfg,
-		DB:                 db,
-		Logger:             log,
-		UserProfileRepo:    userProfileRepo,
-		UserProfileService: userProfileService,
+		Config:                 cfg,
+		DB:                     db,
+		Logger:                 log,
+		Notifier:               notifier,
+		PhotoStore:             photoStore,
+		UserProfileRepo:        userProfileRepo,
+		SavedSearchRepo:        savedSearchRepo,
+		SearchAlertRepo:        searchAlertRepo,
+		PreferenceRepo:         preferenceRepo,
+		BlockRepo:              blockRepo,
+		RecommendationRepo:     recommendationRepo,
+		InterestRepo:           interestRepo,
+		ContactDetailRepo:      contactDetailRepo,
+		ContactRevealRepo:      contactRevealRepo,
+		PlanRepo:               planRepo,
+		SubscriptionRepo:       subscriptionRepo,
+		ProfileViewRepo:        profileViewRepo,
+		ProfileBoostRepo:       profileBoostRepo,
+		ProfileManagerRepo:     profileManagerRepo,
+		ProfileTransferRepo:    profileTransferRepo,
+		ProfilePrivacyRepo:     profilePrivacyRepo,
+		ProfileShareLinkRepo:   profileShareLinkRepo,
+		ProfileDraftRepo:       profileDraftRepo,
+		ProfileRevisionRepo:    profileRevisionRepo,
+		DataExportRepo:         dataExportRepo,
+		AccountErasureRepo:     accountErasureRepo,
+		ConsentRecordRepo:      consentRecordRepo,
+		PaymentProvider:        paymentProvider,
+		UserProfileService:     userProfileService,
+		SavedSearchService:     savedSearchService,
+		PreferenceService:      preferenceService,
+		BlockService:           blockService,
+		RecommendationService:  recommendationService,
+		SimilarProfileService:  similarProfileService,
+		InterestService:        interestService,
+		ContactService:         contactService,
+		MembershipService:      membershipService,
+		ProfileViewService:     profileViewService,
+		ProfileBoostService:    profileBoostService,
+		ProfileManagerService:  profileManagerService,
+		ProfileTransferService: profileTransferService,
+		ProfileShareService:    profileShareService,
+		CompletenessService:    completenessService,
+		ProfileDraftService:    profileDraftService,
+		ProfileRevisionService: profileRevisionService,
+		RetentionService:       retentionService,
+		DataExportService:      dataExportService,
+		ErasureService:         erasureService,
+		ConsentService:         consentService,
 	}, nil
 }
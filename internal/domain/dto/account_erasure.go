@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// EraseAccountRequest represents the request payload for erasing the user's account.
+// Erasure can't be undone, so it must be confirmed explicitly.
+type EraseAccountRequest struct {
+	Confirm bool `json:"confirm" binding:"required"`
+}
+
+// AccountErasureResponse represents an account erasure in API responses. The receipt
+// is returned as it was sealed, so that its hash can be checked against it.
+type AccountErasureResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	Status         string          `json:"status"`
+	CompletedSteps int             `json:"completed_steps"`
+	TotalSteps     int             `json:"total_steps"`
+	CreatedAt      time.Time       `json:"created_at"`
+	StartedAt      *time.Time      `json:"started_at,omitempty"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+	Receipt        json.RawMessage `json:"receipt,omitempty"`
+	ReceiptHash    string          `json:"receipt_hash,omitempty"`
+}
+
+// AccountErasureFromModel creates an AccountErasureResponse from a model.AccountErasure
+func AccountErasureFromModel(erasure *model.AccountErasure, totalSteps int) *AccountErasureResponse {
+	response := &AccountErasureResponse{
+		ID:             erasure.ID,
+		Status:         string(erasure.Status),
+		CompletedSteps: erasure.CompletedSteps,
+		TotalSteps:     totalSteps,
+		CreatedAt:      erasure.CreatedAt,
+		StartedAt:      erasure.StartedAt,
+		CompletedAt:    erasure.CompletedAt,
+		ReceiptHash:    erasure.ReceiptHash,
+	}
+	if erasure.Receipt != "" {
+		response.Receipt = json.RawMessage(erasure.Receipt)
+	}
+	return response
+}
+
+// ErasureReceiptChainResponse represents the outcome of verifying the erasure receipt chain
+type ErasureReceiptChainResponse struct {
+	Valid            bool   `json:"valid"`
+	Receipts         int64  `json:"receipts"`                     // receipts verified before any break
+	BrokenAtSequence *int64 `json:"broken_at_sequence,omitempty"` // first receipt that doesn't match the chain
+	LastHash         string `json:"last_hash,omitempty"`          // hash of the last verified receipt
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// GrantConsentRequest represents the request payload for agreeing to a policy. The
+// version is the one the user was shown, and must be the current one.
+type GrantConsentRequest struct {
+	Policy  string `json:"policy" binding:"required,oneof=terms privacy_policy photo_display data_sharing"`
+	Version string `json:"version" binding:"required,max=50"`
+}
+
+// ConsentResponse represents where the user stands on a policy. Status is granted,
+// withdrawn or none; UpToDate tells whether the current version was granted.
+type ConsentResponse struct {
+	Policy         string     `json:"policy"`
+	Mandatory      bool       `json:"mandatory"`
+	CurrentVersion string     `json:"current_version"`
+	Status         string     `json:"status"`
+	Version        string     `json:"version,omitempty"`
+	RecordedAt     *time.Time `json:"recorded_at,omitempty"`
+	UpToDate       bool       `json:"up_to_date"`
+}
+
+// ConsentStatusNone is the status of a policy the user has neither granted nor withdrawn
+const ConsentStatusNone = "none"
+
+// ConsentFromModel creates a ConsentResponse for a policy from its latest record, or
+// from none if the user never answered it
+func ConsentFromModel(policy model.ConsentPolicy, currentVersion string, latest *model.ConsentRecord) *ConsentResponse {
+	response := &ConsentResponse{
+		Policy:         string(policy),
+		Mandatory:      policy.Mandatory(),
+		CurrentVersion: currentVersion,
+		Status:         ConsentStatusNone,
+	}
+	if latest == nil {
+		return response
+	}
+
+	response.Status = string(latest.Action)
+	response.Version = latest.PolicyVersion
+	response.RecordedAt = &latest.CreatedAt
+	response.UpToDate = latest.Action == model.ConsentActionGranted && latest.PolicyVersion == currentVersion
+	return response
+}
+
+// ConsentRecordResponse represents an entry of the user's consent ledger in API responses
+type ConsentRecordResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Policy    string    `json:"policy"`
+	Version   string    `json:"version"`
+	Action    string    `json:"action"`
+	SourceIP  string    `json:"source_ip,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ConsentRecordFromModel creates a ConsentRecordResponse from a model.ConsentRecord
+func ConsentRecordFromModel(record *model.ConsentRecord) *ConsentRecordResponse {
+	response := &ConsentRecordResponse{
+		ID:        record.ID,
+		Policy:    string(record.Policy),
+		Version:   record.PolicyVersion,
+		Action:    string(record.Action),
+		RequestID: record.RequestID,
+		CreatedAt: record.CreatedAt,
+	}
+	if record.SourceIP != nil {
+		response.SourceIP = *record.SourceIP
+	}
+	return response
+}
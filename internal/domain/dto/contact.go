@@ -0,0 +1,36 @@
+package dto
+
+// ContactDetailsRequest represents the request payload for setting contact details
+type ContactDetailsRequest struct {
+	Phone            string `json:"phone" binding:"required,e164"`
+	WhatsApp         string `json:"whatsapp" binding:"omitempty,e164"`
+	GuardianName     string `json:"guardian_name" binding:"max=100"`
+	GuardianRelation string `json:"guardian_relation" binding:"max=50"`
+	GuardianPhone    string `json:"guardian_phone" binding:"omitempty,e164"`
+}
+
+// ContactDetailsResponse represents contact details in API responses.
+// The same structure is what gets encrypted at rest.
+type ContactDetailsResponse struct {
+	Phone            string `json:"phone"`
+	WhatsApp         string `json:"whatsapp,omitempty"`
+	GuardianName     string `json:"guardian_name,omitempty"`
+	GuardianRelation string `json:"guardian_relation,omitempty"`
+	GuardianPhone    string `json:"guardian_phone,omitempty"`
+}
+
+// ContactRevealResponse represents contact details revealed to another member
+type ContactRevealResponse struct {
+	Contact *ContactDetailsResponse `json:"contact"`
+	// Basis is what allowed the reveal: an accepted interest or the monthly quota
+	Basis string `json:"basis"`
+	// RemainingQuota is the number of quota reveals left this month
+	RemainingQuota int `json:"remaining_quota"`
+}
+
+// ContactQuotaResponse represents the viewer's monthly contact reveal quota
+type ContactQuotaResponse struct {
+	Quota     int `json:"quota"`
+	Used      int `json:"used"`
+	Remaining int `json:"remaining"`
+}
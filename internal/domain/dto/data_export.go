@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// DataExportResponse represents a personal data export in API responses. The download
+// token is only set once the archive is ready, and is minted afresh on every request.
+type DataExportResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	Status            string     `json:"status"`
+	ArchiveSize       int64      `json:"archive_size,omitempty"`
+	Error             string     `json:"error,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	DownloadToken     string     `json:"download_token,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+}
+
+// DataExportFromModel creates a DataExportResponse from a model.DataExport
+func DataExportFromModel(export *model.DataExport) *DataExportResponse {
+	return &DataExportResponse{
+		ID:          export.ID,
+		Status:      string(export.Status),
+		ArchiveSize: export.ArchiveSize,
+		Error:       export.Error,
+		CreatedAt:   export.CreatedAt,
+		StartedAt:   export.StartedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+}
+
+// DataExportArchive is an assembled personal data export
+type DataExportArchive struct {
+	Filename string
+	Content  []byte
+}
+
+// ExportedContactDetails represents the decrypted contact details of a profile in an export
+type ExportedContactDetails struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+	*ContactDetailsResponse
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExportedPartnerPreference represents the partner preferences of a profile in an export
+type ExportedPartnerPreference struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+	*PartnerPreferenceResponse
+}
+
+// ExportedProfileView represents a view of one of the account's profiles in an export.
+// Who viewed it is left out, as seeing viewers is a membership benefit.
+type ExportedProfileView struct {
+	ProfileID    uuid.UUID `json:"profile_id"`
+	ViewCount    int       `json:"view_count"`
+	LastViewedAt time.Time `json:"last_viewed_at"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// SendInterestRequest represents the request payload for sending an interest
+type SendInterestRequest struct {
+	ProfileID string `json:"profile_id" binding:"required"` // profile ID or public code
+	Message   string `json:"message" binding:"max=500"`
+}
+
+// RespondInterestRequest represents the request payload for responding to an interest
+type RespondInterestRequest struct {
+	Action string `json:"action" binding:"required,oneof=accept decline"`
+}
+
+// InterestResponse represents an interest in API responses
+type InterestResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	SenderProfileID   uuid.UUID  `json:"sender_profile_id"`
+	ReceiverProfileID uuid.UUID  `json:"receiver_profile_id"`
+	Status            string     `json:"status"`
+	Message           string     `json:"message,omitempty"`
+	RespondedAt       *time.Time `json:"responded_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// InterestListResponse represents a page of interests
+type InterestListResponse struct {
+	Interests []*InterestResponse `json:"interests"`
+	Total     int64               `json:"total"`
+	Page      int                 `json:"page"`
+	Limit     int                 `json:"limit"`
+}
+
+// InterestFromModel creates an InterestResponse from a model.Interest
+func InterestFromModel(interest *model.Interest) *InterestResponse {
+	return &InterestResponse{
+		ID:                interest.ID,
+		SenderProfileID:   interest.SenderProfileID,
+		ReceiverProfileID: interest.ReceiverProfileID,
+		Status:            string(interest.Status),
+		Message:           interest.Message,
+		RespondedAt:       interest.RespondedAt,
+		CreatedAt:         interest.CreatedAt,
+	}
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// EntitlementsResponse represents what a membership plan allows
+type EntitlementsResponse struct {
+	MonthlyContactReveals int  `json:"monthly_contact_reveals"`
+	MonthlyProfileBoosts  int  `json:"monthly_profile_boosts"`
+	WhoViewedMe           bool `json:"who_viewed_me"`
+	AdvancedFilters       bool `json:"advanced_filters"`
+}
+
+// MembershipPlanResponse represents a membership plan in API responses
+type MembershipPlanResponse struct {
+	Code         string               `json:"code"`
+	Name         string               `json:"name"`
+	PricePaise   int64                `json:"price_paise"`
+	Currency     string               `json:"currency"`
+	DurationDays int                  `json:"duration_days"`
+	Entitlements EntitlementsResponse `json:"entitlements"`
+}
+
+// MembershipResponse represents a user's current plan and entitlements
+type MembershipResponse struct {
+	PlanCode     string               `json:"plan_code"`
+	PlanName     string               `json:"plan_name"`
+	ExpiresAt    *time.Time           `json:"expires_at,omitempty"`
+	Entitlements EntitlementsResponse `json:"entitlements"`
+}
+
+// SubscribeRequest represents the request payload for subscribing to a plan
+type SubscribeRequest struct {
+	PlanCode string `json:"plan_code" binding:"required,max=50"`
+}
+
+// CheckoutResponse represents a started subscription awaiting payment
+type CheckoutResponse struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	PlanCode       string    `json:"plan_code"`
+	AmountPaise    int64     `json:"amount_paise"`
+	Currency       string    `json:"currency"`
+	Provider       string    `json:"provider"`
+	Reference      string    `json:"reference"`
+	CheckoutURL    string    `json:"checkout_url"`
+}
+
+// MembershipPlanFromModel creates a MembershipPlanResponse from a model.MembershipPlan
+func MembershipPlanFromModel(plan *model.MembershipPlan) *MembershipPlanResponse {
+	return &MembershipPlanResponse{
+		Code:         plan.Code,
+		Name:         plan.Name,
+		PricePaise:   plan.PricePaise,
+		Currency:     plan.Currency,
+		DurationDays: plan.DurationDays,
+		Entitlements: EntitlementsResponse{
+			MonthlyContactReveals: plan.MonthlyContactReveals,
+			MonthlyProfileBoosts:  plan.MonthlyProfileBoosts,
+			WhoViewedMe:           plan.WhoViewedMe,
+			AdvancedFilters:       plan.AdvancedFilters,
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// PartnerPreferenceRequest represents the request payload for setting partner preferences
+type PartnerPreferenceRequest struct {
+	MinAge        *int     `json:"min_age,omitempty" binding:"omitempty,min=18,max=80"`
+	MaxAge        *int     `json:"max_age,omitempty" binding:"omitempty,min=18,max=80"`
+	MinHeight     *float64 `json:"min_height,omitempty" binding:"omitempty,min=100,max=250"`
+	MaxHeight     *float64 `json:"max_height,omitempty" binding:"omitempty,min=100,max=250"`
+	Community     []string `json:"community,omitempty" binding:"omitempty,dive,oneof='A muslim' Hanafi Salafi Sunni Thableegh Shia 'Jamat Islami'"`
+	Nationality   []string `json:"nationality,omitempty" binding:"omitempty,dive,oneof=India UAE UK USA"`
+	MaritalStatus []string `json:"marital_status,omitempty" binding:"omitempty,dive,oneof='Never married' Widower Divorced 'Nikah Divorce'"`
+	HomeDistrict  []string `json:"home_district,omitempty" binding:"omitempty,dive,min=2,max=50"`
+}
+
+// PartnerPreferenceResponse represents partner preferences in API responses
+type PartnerPreferenceResponse struct {
+	PartnerPreferenceRequest
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// PartnerPreferenceFromModel creates a PartnerPreferenceResponse from a model.PartnerPreference.
+// The stored criteria use the same field names as the request.
+func PartnerPreferenceFromModel(preference *model.PartnerPreference) (*PartnerPreferenceResponse, error) {
+	response := &PartnerPreferenceResponse{UpdatedAt: &preference.UpdatedAt}
+	if err := json.Unmarshal(preference.Criteria, &response.PartnerPreferenceRequest); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// BlockProfileRequest represents the request payload for blocking a profile
+type BlockProfileRequest struct {
+	ProfileID string `json:"profile_id" binding:"required"` // profile ID or public code
+}
+
+// ProfileBlockResponse represents a blocked profile in API responses
+type ProfileBlockResponse struct {
+	BlockedProfileID uuid.UUID `json:"blocked_profile_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ProfileBlockFromModel creates a ProfileBlockResponse from a model.ProfileBlock
+func ProfileBlockFromModel(block *model.ProfileBlock) *ProfileBlockResponse {
+	return &ProfileBlockResponse{
+		BlockedProfileID: block.BlockedProfileID,
+		CreatedAt:        block.CreatedAt,
+	}
+}
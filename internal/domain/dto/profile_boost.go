@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileBoostResponse represents a profile boost and its results in API responses
+type ProfileBoostResponse struct {
+	ID          uuid.UUID `json:"id"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Active      bool      `json:"active"`
+	Impressions int64     `json:"impressions"`
+	Clicks      int64     `json:"clicks"`
+}
+
+// ProfileBoostFromModel creates a ProfileBoostResponse from a model.ProfileBoost
+func ProfileBoostFromModel(boost *model.ProfileBoost, now time.Time) *ProfileBoostResponse {
+	return &ProfileBoostResponse{
+		ID:          boost.ID,
+		StartsAt:    boost.StartsAt,
+		EndsAt:      boost.EndsAt,
+		Active:      boost.IsActive(now),
+		Impressions: boost.Impressions,
+		Clicks:      boost.Clicks,
+	}
+}
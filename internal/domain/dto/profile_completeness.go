@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/completeness"
+)
+
+// ChecklistItem represents something to fill in to complete a profile
+type ChecklistItem struct {
+	Section string `json:"section"`
+	Field   string `json:"field"`
+	Label   string `json:"label"`
+}
+
+// CompletenessSection represents how complete one section of a profile is
+type CompletenessSection struct {
+	Name    string          `json:"name"`
+	Weight  int             `json:"weight"`
+	Score   int             `json:"score"`
+	Missing []ChecklistItem `json:"missing"`
+}
+
+// CompletenessResponse represents how complete a profile is, as a percentage,
+// along with the onboarding checklist of what is still missing
+type CompletenessResponse struct {
+	Score    int                   `json:"score"`
+	Sections []CompletenessSection `json:"sections"`
+	Missing  []ChecklistItem       `json:"missing"`
+}
+
+// MyProfileResponse represents the user's own profile with its completeness
+type MyProfileResponse struct {
+	*UserProfileResponse
+	Completeness *CompletenessResponse `json:"completeness"`
+}
+
+// CompletenessFromResult creates a CompletenessResponse from a completeness.Result
+func CompletenessFromResult(result *completeness.Result) *CompletenessResponse {
+	response := &CompletenessResponse{
+		Score:    result.Score,
+		Sections: make([]CompletenessSection, len(result.Sections)),
+		Missing:  checklistItems(result.Missing),
+	}
+	for i, section := range result.Sections {
+		response.Sections[i] = CompletenessSection{
+			Name:    section.Name,
+			Weight:  section.Weight,
+			Score:   section.Score,
+			Missing: checklistItems(section.Missing),
+		}
+	}
+	return response
+}
+
+// checklistItems converts completeness items, returning an empty list rather than nil
+func checklistItems(items []completeness.Item) []ChecklistItem {
+	result := make([]ChecklistItem, len(items))
+	for i, item := range items {
+		result[i] = ChecklistItem{
+			Section: item.Section,
+			Field:   item.Field,
+			Label:   item.Label,
+		}
+	}
+	return result
+}
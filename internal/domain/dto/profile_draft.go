@@ -0,0 +1,188 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// DraftStepRequest is the request payload of one step of a profile draft. Its fields
+// have the same JSON names as CreateUserProfileRequest, which the steps add up to.
+type DraftStepRequest interface {
+	// Step returns the step the payload belongs to
+	Step() model.ProfileDraftStep
+}
+
+// DraftBasicRequest represents the basic details step of a profile draft
+type DraftBasicRequest struct {
+	IsGroom             *bool  `json:"is_groom" binding:"required"`
+	ProfileCreatedBy    string `json:"profile_created_by" binding:"required,oneof=Self Brother Sister Parents Friend Relative"`
+	Name                string `json:"name" binding:"required,min=2,max=100"`
+	DateOfBirth         string `json:"date_of_birth" binding:"required"`
+	DateOfBirthCalendar string `json:"date_of_birth_calendar" binding:"omitempty,oneof=gregorian hijri"`
+	MaritalStatus       string `json:"marital_status" binding:"required,oneof='Never married' Widower Divorced 'Nikah Divorce'"`
+}
+
+// DraftPhysicalRequest represents the physical details step of a profile draft
+type DraftPhysicalRequest struct {
+	Height                 float64 `json:"height" binding:"required,gt=0"`
+	Weight                 float64 `json:"weight" binding:"required,gt=0"`
+	IsPhysicallyChallenged bool    `json:"is_physically_challenged"`
+}
+
+// DraftLocationRequest represents the location step of a profile draft
+type DraftLocationRequest struct {
+	Nationality   string `json:"nationality" binding:"required,oneof=India UAE UK USA"`
+	HomeDistrict  string `json:"home_district" binding:"required,min=2,max=50"`
+	ResidenceCity string `json:"residence_city" binding:"max=100"`
+}
+
+// DraftReligiousRequest represents the religious details step of a profile draft
+type DraftReligiousRequest struct {
+	Community string `json:"community" binding:"required,oneof='A muslim' Hanafi Salafi Sunni Thableegh Shia 'Jamat Islami'"`
+}
+
+// DraftFamilyRequest represents the family details step of a profile draft. All of it
+// is optional, but the step is saved to mark it done.
+type DraftFamilyRequest struct {
+	FatherOccupation string `json:"father_occupation" binding:"max=100"`
+	MotherOccupation string `json:"mother_occupation" binding:"max=100"`
+	Siblings         string `json:"siblings" binding:"max=200"`
+}
+
+// Step returns the basic details step
+func (req *DraftBasicRequest) Step() model.ProfileDraftStep { return model.ProfileDraftStepBasic }
+
+// Step returns the physical details step
+func (req *DraftPhysicalRequest) Step() model.ProfileDraftStep { return model.ProfileDraftStepPhysical }
+
+// Step returns the location step
+func (req *DraftLocationRequest) Step() model.ProfileDraftStep { return model.ProfileDraftStepLocation }
+
+// Step returns the religious details step
+func (req *DraftReligiousRequest) Step() model.ProfileDraftStep {
+	return model.ProfileDraftStepReligious
+}
+
+// Step returns the family details step
+func (req *DraftFamilyRequest) Step() model.ProfileDraftStep { return model.ProfileDraftStepFamily }
+
+// NewDraftStepRequest returns an empty payload to bind the request of a step to,
+// or false if there is no such step
+func NewDraftStepRequest(step string) (DraftStepRequest, bool) {
+	switch model.ProfileDraftStep(step) {
+	case model.ProfileDraftStepBasic:
+		return &DraftBasicRequest{}, true
+	case model.ProfileDraftStepPhysical:
+		return &DraftPhysicalRequest{}, true
+	case model.ProfileDraftStepLocation:
+		return &DraftLocationRequest{}, true
+	case model.ProfileDraftStepReligious:
+		return &DraftReligiousRequest{}, true
+	case model.ProfileDraftStepFamily:
+		return &DraftFamilyRequest{}, true
+	}
+	return nil, false
+}
+
+// DraftStepFields returns the JSON names of the fields of a step, all of which are
+// present in the draft data once the step has been saved
+func DraftStepFields(step model.ProfileDraftStep) []string {
+	req, ok := NewDraftStepRequest(string(step))
+	if !ok {
+		return nil
+	}
+
+	// The step payloads have no omitempty fields, so their zero values list every field
+	data, _ := json.Marshal(req)
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(data, &fields)
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	return names
+}
+
+// ProfileDraftResponse represents a profile draft and where to resume it
+type ProfileDraftResponse struct {
+	ID             uuid.UUID                 `json:"id"`
+	LastStep       string                    `json:"last_step"`
+	CompletedSteps []string                  `json:"completed_steps"`
+	NextStep       string                    `json:"next_step,omitempty"` // empty when every step is done and the draft can be submitted
+	Data           *CreateUserProfileRequest `json:"data"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// ProfileDraftFromModel creates a ProfileDraftResponse from a model.ProfileDraft
+func ProfileDraftFromModel(draft *model.ProfileDraft) (*ProfileDraftResponse, error) {
+	data, err := DraftRequest(draft)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := CompletedDraftSteps(draft)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ProfileDraftResponse{
+		ID:             draft.ID,
+		LastStep:       string(draft.LastStep),
+		CompletedSteps: make([]string, 0, len(completed)),
+		Data:           data,
+		CreatedAt:      draft.CreatedAt,
+		UpdatedAt:      draft.UpdatedAt,
+	}
+
+	done := make(map[model.ProfileDraftStep]bool, len(completed))
+	for _, step := range completed {
+		response.CompletedSteps = append(response.CompletedSteps, string(step))
+		done[step] = true
+	}
+	for _, step := range model.ProfileDraftSteps {
+		if !done[step] {
+			response.NextStep = string(step)
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// CompletedDraftSteps returns the steps saved to a draft, in step order
+func CompletedDraftSteps(draft *model.ProfileDraft) ([]model.ProfileDraftStep, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(draft.Data, &fields); err != nil {
+		return nil, err
+	}
+
+	var completed []model.ProfileDraftStep
+	for _, step := range model.ProfileDraftSteps {
+		done := true
+		for _, name := range DraftStepFields(step) {
+			if _, ok := fields[name]; !ok {
+				done = false
+				break
+			}
+		}
+		if done {
+			completed = append(completed, step)
+		}
+	}
+
+	return completed, nil
+}
+
+// DraftRequest returns the create profile request the draft adds up to
+func DraftRequest(draft *model.ProfileDraft) (*CreateUserProfileRequest, error) {
+	var req CreateUserProfileRequest
+	if err := json.Unmarshal(draft.Data, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
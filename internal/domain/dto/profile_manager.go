@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// InviteProfileManagerRequest represents the request payload for inviting a user to manage a profile
+type InviteProfileManagerRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	Scopes []string  `json:"scopes" binding:"required,min=1,dive,oneof=edit respond_interests view_contacts delete"`
+}
+
+// UpdateProfileManagerRequest represents the request payload for changing a manager's scopes
+type UpdateProfileManagerRequest struct {
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=edit respond_interests view_contacts delete"`
+}
+
+// ProfileManagerResponse represents a delegation to manage a profile in API responses
+type ProfileManagerResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ProfileID     uuid.UUID  `json:"profile_id"`
+	ManagerUserID uuid.UUID  `json:"manager_user_id"`
+	InvitedBy     uuid.UUID  `json:"invited_by"`
+	Status        string     `json:"status"`
+	Scopes        []string   `json:"scopes"`
+	AcceptedAt    *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ManagedProfileResponse represents a profile the user manages, or is invited to manage
+type ManagedProfileResponse struct {
+	*ProfileManagerResponse
+	Profile *UserProfileResponse `json:"profile,omitempty"`
+}
+
+// ProfileManagerFromModel creates a ProfileManagerResponse from a model.ProfileManager
+func ProfileManagerFromModel(manager *model.ProfileManager) *ProfileManagerResponse {
+	scopes := manager.Scopes()
+	response := &ProfileManagerResponse{
+		ID:            manager.ID,
+		ProfileID:     manager.ProfileID,
+		ManagerUserID: manager.ManagerUserID,
+		InvitedBy:     manager.InvitedBy,
+		Status:        string(manager.Status),
+		Scopes:        make([]string, len(scopes)),
+		AcceptedAt:    manager.AcceptedAt,
+		CreatedAt:     manager.CreatedAt,
+	}
+	for i, scope := range scopes {
+		response.Scopes[i] = string(scope)
+	}
+
+	return response
+}
+
+// ManagedProfileFromModel creates a ManagedProfileResponse from a model.ProfileManager
+// with its profile loaded
+func ManagedProfileFromModel(manager *model.ProfileManager) *ManagedProfileResponse {
+	response := &ManagedProfileResponse{
+		ProfileManagerResponse: ProfileManagerFromModel(manager),
+	}
+	if manager.Profile != nil {
+		response.Profile = FromModel(manager.Profile)
+	}
+
+	return response
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/revision"
+)
+
+// ProfileRevisionResponse represents one change made to a profile
+type ProfileRevisionResponse struct {
+	ID         uuid.UUID        `json:"id"`
+	ProfileID  uuid.UUID        `json:"profile_id"`
+	Action     string           `json:"action"`
+	ActorID    uuid.UUID        `json:"actor_id"`
+	ActorRole  string           `json:"actor_role"`
+	RequestID  string           `json:"request_id,omitempty"`
+	Changes    revision.Changes `json:"changes"`
+	RevertedTo *uuid.UUID       `json:"reverted_to,omitempty"`
+	Flagged    bool             `json:"flagged,omitempty"`
+	FlagReason string           `json:"flag_reason,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// ProfileRevisionListResponse represents a page of profile revisions
+type ProfileRevisionListResponse struct {
+	Revisions []*ProfileRevisionResponse `json:"revisions"`
+	Total     int64                      `json:"total"`
+	Page      int                        `json:"page"`
+	Limit     int                        `json:"limit"`
+}
+
+// ProfileRevisionFromModel creates a ProfileRevisionResponse from a model.ProfileRevision,
+// which fails only if its stored changes are corrupt
+func ProfileRevisionFromModel(rev *model.ProfileRevision) (*ProfileRevisionResponse, error) {
+	changes, err := revision.Parse(rev.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProfileRevisionResponse{
+		ID:         rev.ID,
+		ProfileID:  rev.ProfileID,
+		Action:     string(rev.Action),
+		ActorID:    rev.ActorID,
+		ActorRole:  string(rev.ActorRole),
+		RequestID:  rev.RequestID,
+		Changes:    changes,
+		RevertedTo: rev.RevertedTo,
+		Flagged:    rev.Flagged,
+		FlagReason: rev.FlagReason,
+		CreatedAt:  rev.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,180 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// UpdateProfilePrivacyRequest represents the request payload for changing privacy settings.
+// Settings left out keep their current value.
+type UpdateProfilePrivacyRequest struct {
+	ShowPhoto       *bool `json:"show_photo"`
+	ShowDateOfBirth *bool `json:"show_date_of_birth"`
+	ShowResidence   *bool `json:"show_residence"`
+	ShowEducation   *bool `json:"show_education"`
+	ShowOccupation  *bool `json:"show_occupation"`
+	ShowAboutMe     *bool `json:"show_about_me"`
+}
+
+// Apply copies the settings present in the request onto settings
+func (req *UpdateProfilePrivacyRequest) Apply(settings *model.ProfilePrivacySettings) {
+	for _, field := range []struct {
+		value  *bool
+		target *bool
+	}{
+		{req.ShowPhoto, &settings.ShowPhoto},
+		{req.ShowDateOfBirth, &settings.ShowDateOfBirth},
+		{req.ShowResidence, &settings.ShowResidence},
+		{req.ShowEducation, &settings.ShowEducation},
+		{req.ShowOccupation, &settings.ShowOccupation},
+		{req.ShowAboutMe, &settings.ShowAboutMe},
+	} {
+		if field.value != nil {
+			*field.target = *field.value
+		}
+	}
+}
+
+// ProfilePrivacyResponse represents the privacy settings of a profile in API responses
+type ProfilePrivacyResponse struct {
+	ProfileID       uuid.UUID `json:"profile_id"`
+	ShowPhoto       bool      `json:"show_photo"`
+	ShowDateOfBirth bool      `json:"show_date_of_birth"`
+	ShowResidence   bool      `json:"show_residence"`
+	ShowEducation   bool      `json:"show_education"`
+	ShowOccupation  bool      `json:"show_occupation"`
+	ShowAboutMe     bool      `json:"show_about_me"`
+}
+
+// ProfilePrivacyFromModel creates a ProfilePrivacyResponse from a model.ProfilePrivacySettings
+func ProfilePrivacyFromModel(settings *model.ProfilePrivacySettings) *ProfilePrivacyResponse {
+	return &ProfilePrivacyResponse{
+		ProfileID:       settings.ProfileID,
+		ShowPhoto:       settings.ShowPhoto,
+		ShowDateOfBirth: settings.ShowDateOfBirth,
+		ShowResidence:   settings.ShowResidence,
+		ShowEducation:   settings.ShowEducation,
+		ShowOccupation:  settings.ShowOccupation,
+		ShowAboutMe:     settings.ShowAboutMe,
+	}
+}
+
+// ProfileSnapshot is the read-only view of a profile given to people outside the
+// platform, on biodata sheets and share links. Details hidden by the privacy
+// settings are left empty, and contact details are never part of it.
+type ProfileSnapshot struct {
+	ProfileCode            string    `json:"profile_code"`
+	IsGroom                bool      `json:"is_groom"`
+	ProfileCreatedBy       string    `json:"profile_created_by"`
+	Name                   string    `json:"name"`
+	Age                    int       `json:"age"`
+	DateOfBirth            string    `json:"date_of_birth,omitempty"`
+	DateOfBirthHijri       string    `json:"date_of_birth_hijri,omitempty"`
+	Community              string    `json:"community"`
+	Nationality            string    `json:"nationality"`
+	Height                 float64   `json:"height"`
+	Weight                 float64   `json:"weight"`
+	MaritalStatus          string    `json:"marital_status"`
+	IsPhysicallyChallenged bool      `json:"is_physically_challenged"`
+	HomeDistrict           string    `json:"home_district"`
+	ResidenceCity          string    `json:"residence_city,omitempty"`
+	Education              string    `json:"education,omitempty"`
+	Occupation             string    `json:"occupation,omitempty"`
+	AboutMe                string    `json:"about_me,omitempty"`
+	ShowPhoto              bool      `json:"show_photo"`
+	TakenAt                time.Time `json:"taken_at"`
+}
+
+// ProfileSnapshotFromModel creates a ProfileSnapshot of a profile as its privacy settings allow
+func ProfileSnapshotFromModel(profile *model.UserProfile, settings *model.ProfilePrivacySettings, takenAt time.Time) *ProfileSnapshot {
+	snapshot := &ProfileSnapshot{
+		ProfileCode:            profile.ProfileCode,
+		IsGroom:                profile.IsGroom,
+		ProfileCreatedBy:       string(profile.ProfileCreatedBy),
+		Name:                   profile.Name,
+		Age:                    profile.Age(),
+		Community:              string(profile.Community),
+		Nationality:            string(profile.Nationality),
+		Height:                 profile.Height,
+		Weight:                 profile.Weight,
+		MaritalStatus:          string(profile.MaritalStatus),
+		IsPhysicallyChallenged: profile.IsPhysicallyChallenged,
+		HomeDistrict:           string(profile.HomeDistrict),
+		ShowPhoto:              settings.ShowPhoto,
+		TakenAt:                takenAt,
+	}
+
+	if settings.ShowDateOfBirth {
+		snapshot.DateOfBirth = profile.DateOfBirth.Format("2006-01-02")
+		snapshot.DateOfBirthHijri = hijriDateOfBirth(profile)
+	}
+	if settings.ShowResidence {
+		snapshot.ResidenceCity = profile.ResidenceCity
+	}
+	if settings.ShowEducation {
+		snapshot.Education = profile.Education
+	}
+	if settings.ShowOccupation {
+		snapshot.Occupation = profile.Occupation
+	}
+	if settings.ShowAboutMe {
+		snapshot.AboutMe = profile.AboutMe
+	}
+
+	return snapshot
+}
+
+// CreateShareLinkRequest represents the request payload for minting a public share link
+type CreateShareLinkRequest struct {
+	Label          string `json:"label" binding:"max=100"`
+	ExpiresInHours int    `json:"expires_in_hours" binding:"omitempty,min=1"`
+}
+
+// ShareLinkResponse represents a public share link in API responses
+type ShareLinkResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	ProfileID    uuid.UUID  `json:"profile_id"`
+	Label        string     `json:"label,omitempty"`
+	Active       bool       `json:"active"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ViewCount    int64      `json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ShareLinkCreatedResponse represents a new share link along with its token. The token
+// is only returned here; a lost link can be revoked and minted again.
+type ShareLinkCreatedResponse struct {
+	*ShareLinkResponse
+	Token string `json:"token"`
+}
+
+// ShareLinkFromModel creates a ShareLinkResponse from a model.ProfileShareLink
+func ShareLinkFromModel(link *model.ProfileShareLink, at time.Time) *ShareLinkResponse {
+	return &ShareLinkResponse{
+		ID:           link.ID,
+		ProfileID:    link.ProfileID,
+		Label:        link.Label,
+		Active:       link.IsActive(at),
+		ExpiresAt:    link.ExpiresAt,
+		RevokedAt:    link.RevokedAt,
+		ViewCount:    link.ViewCount,
+		LastViewedAt: link.LastViewedAt,
+		CreatedAt:    link.CreatedAt,
+	}
+}
+
+// SharedProfileResponse represents the profile snapshot shown to the holder of a share link
+type SharedProfileResponse struct {
+	Profile   *ProfileSnapshot `json:"profile"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+// BiodataDocument is a rendered biodata sheet
+type BiodataDocument struct {
+	Filename string
+	Content  []byte
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// InitiateProfileTransferRequest represents the request payload for offering a profile to another account
+type InitiateProfileTransferRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
+
+// AcceptProfileTransferRequest represents the request payload for taking over a profile
+type AcceptProfileTransferRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ProfileTransferResponse represents a profile ownership transfer in API responses
+type ProfileTransferResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	ProfileID        uuid.UUID  `json:"profile_id"`
+	FromUserID       uuid.UUID  `json:"from_user_id"`
+	ToUserID         uuid.UUID  `json:"to_user_id"`
+	ProfileCreatedBy string     `json:"profile_created_by"`
+	Status           string     `json:"status"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	AcceptedAt       *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ProfileTransferCreatedResponse represents a new transfer along with its one-time token,
+// which is only ever returned here and must be passed on to the receiving user
+type ProfileTransferCreatedResponse struct {
+	*ProfileTransferResponse
+	Token string `json:"token"`
+}
+
+// ProfileTransferFromModel creates a ProfileTransferResponse from a model.ProfileTransfer
+func ProfileTransferFromModel(transfer *model.ProfileTransfer) *ProfileTransferResponse {
+	return &ProfileTransferResponse{
+		ID:               transfer.ID,
+		ProfileID:        transfer.ProfileID,
+		FromUserID:       transfer.FromUserID,
+		ToUserID:         transfer.ToUserID,
+		ProfileCreatedBy: string(transfer.ProfileCreatedBy),
+		Status:           string(transfer.Status),
+		ExpiresAt:        transfer.ExpiresAt,
+		AcceptedAt:       transfer.AcceptedAt,
+		CreatedAt:        transfer.CreatedAt,
+	}
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileViewerResponse represents a member who viewed the user's profile
+type ProfileViewerResponse struct {
+	Viewer       *UserProfileResponse `json:"viewer"`
+	ViewCount    int                  `json:"view_count"`
+	LastViewedAt time.Time            `json:"last_viewed_at"`
+}
+
+// ProfileViewerListResponse represents a page of profile viewers
+type ProfileViewerListResponse struct {
+	Viewers []*ProfileViewerResponse `json:"viewers"`
+	Total   int64                    `json:"total"`
+	Page    int                      `json:"page"`
+	Limit   int                      `json:"limit"`
+}
+
+// ProfileViewerFromModel creates a ProfileViewerResponse from a model.ProfileView with its viewer loaded
+func ProfileViewerFromModel(view *model.ProfileView) *ProfileViewerResponse {
+	return &ProfileViewerResponse{
+		Viewer:       FromModel(view.Viewer),
+		ViewCount:    view.ViewCount,
+		LastViewedAt: view.LastViewedAt,
+	}
+}
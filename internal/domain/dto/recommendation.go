@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// RecommendationFeedbackRequest represents the request payload for feedback on a recommendation
+type RecommendationFeedbackRequest struct {
+	Action string `json:"action" binding:"required,oneof=skip not_interested"`
+}
+
+// RecommendationResponse represents a recommended profile in API responses
+type RecommendationResponse struct {
+	ID      uuid.UUID            `json:"id"`
+	Rank    int                  `json:"rank"`
+	Score   float64              `json:"score"`
+	Profile *UserProfileResponse `json:"profile"`
+}
+
+// RecommendationListResponse represents a page of the day's recommendations
+type RecommendationListResponse struct {
+	Recommendations []*RecommendationResponse `json:"recommendations"`
+	GeneratedOn     string                    `json:"generated_on,omitempty"`
+	Total           int64                     `json:"total"`
+	Page            int                       `json:"page"`
+	Limit           int                       `json:"limit"`
+}
+
+// RecommendationFromModel creates a RecommendationResponse from a model.Recommendation
+// with its candidate profile loaded
+func RecommendationFromModel(recommendation *model.Recommendation) *RecommendationResponse {
+	return &RecommendationResponse{
+		ID:      recommendation.ID,
+		Rank:    recommendation.Rank,
+		Score:   recommendation.Score,
+		Profile: FromModel(recommendation.Candidate),
+	}
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// CreateSavedSearchRequest represents the request payload for saving a search
+type CreateSavedSearchRequest struct {
+	Name      string          `json:"name" binding:"required,min=1,max=100"`
+	Frequency string          `json:"frequency" binding:"required,oneof=instant daily weekly"`
+	Filter    json.RawMessage `json:"filter" binding:"required"`
+}
+
+// SavedSearchResponse represents a saved search in API responses
+type SavedSearchResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	Frequency string          `json:"frequency"`
+	Filter    json.RawMessage `json:"filter"`
+	LastRunAt time.Time       `json:"last_run_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// SearchAlertResponse represents a saved search alert in API responses
+type SearchAlertResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	SavedSearchID uuid.UUID  `json:"saved_search_id"`
+	ProfileID     uuid.UUID  `json:"profile_id"`
+	NotifiedAt    *time.Time `json:"notified_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// SearchAlertListResponse represents a page of saved search alerts
+type SearchAlertListResponse struct {
+	Alerts []*SearchAlertResponse `json:"alerts"`
+	Total  int64                  `json:"total"`
+	Page   int                    `json:"page"`
+	Limit  int                    `json:"limit"`
+}
+
+// SavedSearchFromModel creates a SavedSearchResponse from a model.SavedSearch
+func SavedSearchFromModel(search *model.SavedSearch) *SavedSearchResponse {
+	return &SavedSearchResponse{
+		ID:        search.ID,
+		Name:      search.Name,
+		Frequency: string(search.Frequency),
+		Filter:    json.RawMessage(search.Filter),
+		LastRunAt: search.LastRunAt,
+		CreatedAt: search.CreatedAt,
+	}
+}
+
+// SearchAlertFromModel creates a SearchAlertResponse from a model.SearchAlert
+func SearchAlertFromModel(alert *model.SearchAlert) *SearchAlertResponse {
+	return &SearchAlertResponse{
+		ID:            alert.ID,
+		SavedSearchID: alert.SavedSearchID,
+		ProfileID:     alert.ProfileID,
+		NotifiedAt:    alert.NotifiedAt,
+		CreatedAt:     alert.CreatedAt,
+	}
+}
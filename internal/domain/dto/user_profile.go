@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/hijri"
 )
 
 // CreateUserProfileRequest represents the request payload for creating a user profile
@@ -12,24 +13,35 @@ type CreateUserProfileRequest struct {
 	IsGroom                bool    `json:"is_groom" binding:"required"`
 	ProfileCreatedBy       string  `json:"profile_created_by" binding:"required,oneof=Self Brother Sister Parents Friend Relative"`
 	Name                   string  `json:"name" binding:"required,min=2,max=100"`
-	DateOfBirth            string  `json:"date_of_birth" binding:"required,datetime=2006-01-02"`
-	Community              string  `json:"community" binding:"required,oneof=A\ muslim Hanafi Salafi Sunni Thableegh Shia Jamat\ Islami"`
+	DateOfBirth            string  `json:"date_of_birth" binding:"required"`
+	DateOfBirthCalendar    string  `json:"date_of_birth_calendar" binding:"omitempty,oneof=gregorian hijri"`
+	Community              string  `json:"community" binding:"required,oneof='A muslim' Hanafi Salafi Sunni Thableegh Shia 'Jamat Islami'"`
 	Nationality            string  `json:"nationality" binding:"required,oneof=India UAE UK USA"`
 	Height                 float64 `json:"height" binding:"required,gt=0"`
 	Weight                 float64 `json:"weight" binding:"required,gt=0"`
-	MaritalStatus          string  `json:"marital_status" binding:"required,oneof=Never\ married Widower Divorced Nikah\ Divorce"`
+	MaritalStatus          string  `json:"marital_status" binding:"required,oneof='Never married' Widower Divorced 'Nikah Divorce'"`
 	IsPhysicallyChallenged bool    `json:"is_physically_challenged"`
 	HomeDistrict           string  `json:"home_district" binding:"required,min=2,max=50"`
+	ResidenceCity          string  `json:"residence_city" binding:"max=100"`
+	Education              string  `json:"education" binding:"max=100"`
+	Occupation             string  `json:"occupation" binding:"max=100"`
+	AboutMe                string  `json:"about_me" binding:"max=2000"`
+	FatherOccupation       string  `json:"father_occupation" binding:"max=100"`
+	MotherOccupation       string  `json:"mother_occupation" binding:"max=100"`
+	Siblings               string  `json:"siblings" binding:"max=200"`
 }
 
 // UserProfileResponse represents the response after creating a user profile
 type UserProfileResponse struct {
 	ID                     uuid.UUID `json:"id"`
+	ProfileCode            string    `json:"profile_code"`
 	UserID                 uuid.UUID `json:"user_id"`
 	IsGroom                bool      `json:"is_groom"`
 	ProfileCreatedBy       string    `json:"profile_created_by"`
 	Name                   string    `json:"name"`
 	DateOfBirth            string    `json:"date_of_birth"`
+	DateOfBirthCalendar    string    `json:"date_of_birth_calendar"`
+	DateOfBirthHijri       string    `json:"date_of_birth_hijri,omitempty"`
 	Age                    int       `json:"age"`
 	Community              string    `json:"community"`
 	Nationality            string    `json:"nationality"`
@@ -38,12 +50,41 @@ type UserProfileResponse struct {
 	MaritalStatus          string    `json:"marital_status"`
 	IsPhysicallyChallenged bool      `json:"is_physically_challenged"`
 	HomeDistrict           string    `json:"home_district"`
+	ResidenceCity          string    `json:"residence_city,omitempty"`
+	Education              string    `json:"education,omitempty"`
+	Occupation             string    `json:"occupation,omitempty"`
+	AboutMe                string    `json:"about_me,omitempty"`
+	FatherOccupation       string    `json:"father_occupation,omitempty"`
+	MotherOccupation       string    `json:"mother_occupation,omitempty"`
+	Siblings               string    `json:"siblings,omitempty"`
+	CompletenessScore      int       `json:"completeness_score"`
 	CreatedAt              time.Time `json:"created_at"`
 }
 
+// Calendar returns the calendar the date of birth is given in, Gregorian unless stated
+func (req *CreateUserProfileRequest) Calendar() model.DateCalendar {
+	if req.DateOfBirthCalendar == "" {
+		return model.DateCalendarGregorian
+	}
+	return model.DateCalendar(req.DateOfBirthCalendar)
+}
+
+// GregorianDateOfBirth parses the date of birth, converting it from the Hijri calendar if it's given in Hijri
+func (req *CreateUserProfileRequest) GregorianDateOfBirth() (time.Time, error) {
+	if req.Calendar() == model.DateCalendarHijri {
+		date, err := hijri.Parse(req.DateOfBirth)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return date.Gregorian()
+	}
+
+	return time.Parse("2006-01-02", req.DateOfBirth)
+}
+
 // ToModel converts the DTO to a model.UserProfile
 func (req *CreateUserProfileRequest) ToModel(userID uuid.UUID) (*model.UserProfile, error) {
-	dob, err := time.Parse("2006-01-02", req.DateOfBirth)
+	dob, err := req.GregorianDateOfBirth()
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +95,7 @@ func (req *CreateUserProfileRequest) ToModel(userID uuid.UUID) (*model.UserProfi
 		ProfileCreatedBy:       model.ProfileCreatedBy(req.ProfileCreatedBy),
 		Name:                   req.Name,
 		DateOfBirth:            dob,
+		DateOfBirthCalendar:    req.Calendar(),
 		Community:              model.Community(req.Community),
 		Nationality:            model.Nationality(req.Nationality),
 		Height:                 req.Height,
@@ -61,18 +103,29 @@ func (req *CreateUserProfileRequest) ToModel(userID uuid.UUID) (*model.UserProfi
 		MaritalStatus:          model.MaritalStatus(req.MaritalStatus),
 		IsPhysicallyChallenged: req.IsPhysicallyChallenged,
 		HomeDistrict:           model.HomeDistrict(req.HomeDistrict),
+		ResidenceCity:          req.ResidenceCity,
+		Education:              req.Education,
+		Occupation:             req.Occupation,
+		AboutMe:                req.AboutMe,
+		FatherOccupation:       req.FatherOccupation,
+		MotherOccupation:       req.MotherOccupation,
+		Siblings:               req.Siblings,
 	}, nil
 }
 
-// FromModel creates a UserProfileResponse from a model.UserProfile
+// FromModel creates a UserProfileResponse from a model.UserProfile. The Hijri date of
+// birth is included for profiles whose date of birth was entered in Hijri.
 func FromModel(profile *model.UserProfile) *UserProfileResponse {
 	return &UserProfileResponse{
 		ID:                     profile.ID,
+		ProfileCode:            profile.ProfileCode,
 		UserID:                 profile.UserID,
 		IsGroom:                profile.IsGroom,
 		ProfileCreatedBy:       string(profile.ProfileCreatedBy),
 		Name:                   profile.Name,
 		DateOfBirth:            profile.DateOfBirth.Format("2006-01-02"),
+		DateOfBirthCalendar:    string(profile.Calendar()),
+		DateOfBirthHijri:       hijriDateOfBirth(profile),
 		Age:                    profile.Age(),
 		Community:              string(profile.Community),
 		Nationality:            string(profile.Nationality),
@@ -81,6 +134,83 @@ func FromModel(profile *model.UserProfile) *UserProfileResponse {
 		MaritalStatus:          string(profile.MaritalStatus),
 		IsPhysicallyChallenged: profile.IsPhysicallyChallenged,
 		HomeDistrict:           string(profile.HomeDistrict),
+		ResidenceCity:          profile.ResidenceCity,
+		Education:              profile.Education,
+		Occupation:             profile.Occupation,
+		AboutMe:                profile.AboutMe,
+		FatherOccupation:       profile.FatherOccupation,
+		MotherOccupation:       profile.MotherOccupation,
+		Siblings:               profile.Siblings,
+		CompletenessScore:      profile.CompletenessScore,
 		CreatedAt:              profile.CreatedAt,
 	}
 }
+
+// hijriDateOfBirth returns the Hijri date of birth of a profile entered in Hijri, or ""
+func hijriDateOfBirth(profile *model.UserProfile) string {
+	if profile.Calendar() != model.DateCalendarHijri {
+		return ""
+	}
+
+	date, err := hijri.FromGregorian(profile.DateOfBirth)
+	if err != nil {
+		return ""
+	}
+	return date.String()
+}
+
+// AccountProfileResponse represents one of the profiles owned by an account
+type AccountProfileResponse struct {
+	*UserProfileResponse
+	Active bool `json:"active"`
+}
+
+// DeletedProfileResponse represents a deleted profile of the user's account that can still be restored
+type DeletedProfileResponse struct {
+	*UserProfileResponse
+	DeletedAt       time.Time `json:"deleted_at"`
+	RestorableUntil time.Time `json:"restorable_until"`
+}
+
+// ProfileSearchResult represents a single profile in search results with its relevance details
+type ProfileSearchResult struct {
+	*UserProfileResponse
+	Relevance  float64  `json:"relevance,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+	Boosted    bool     `json:"boosted,omitempty"`
+	// BoostID is passed back when opening a boosted profile from the results, to count the click
+	BoostID *uuid.UUID `json:"boost_id,omitempty"`
+}
+
+// ProfileSearchResponse represents a page of profile search results
+type ProfileSearchResponse struct {
+	Profiles       []*ProfileSearchResult `json:"profiles"`
+	Total          *int64                 `json:"total,omitempty"`
+	TotalEstimated bool                   `json:"total_estimated,omitempty"`
+	Page           int                    `json:"page,omitempty"`
+	Limit          int                    `json:"limit"`
+	NextCursor     string                 `json:"next_cursor,omitempty"`
+	Facets         *SearchFacets          `json:"facets,omitempty"`
+}
+
+// FacetValue represents the number of matching profiles for one filter value
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// SearchFacets represents facet counts per filter, each computed under the other active filters
+type SearchFacets struct {
+	Community     []FacetValue `json:"community"`
+	Nationality   []FacetValue `json:"nationality"`
+	MaritalStatus []FacetValue `json:"marital_status"`
+	HomeDistrict  []FacetValue `json:"home_district"`
+	AgeRange      []FacetValue `json:"age_range"`
+}
+
+// SimilarProfileResponse represents a profile similar to another one, with its similarity from 0 to 1
+type SimilarProfileResponse struct {
+	*UserProfileResponse
+	Similarity float64 `json:"similarity"`
+}
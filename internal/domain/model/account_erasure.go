@@ -0,0 +1,91 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccountErasureStatus represents the state of an account erasure
+type AccountErasureStatus string
+
+// AccountErasureStep names one stage of erasing an account, and the records it covers
+type AccountErasureStep string
+
+// Enum values for AccountErasureStatus
+const (
+	AccountErasureStatusPending   AccountErasureStatus = "pending"
+	AccountErasureStatusRunning   AccountErasureStatus = "running"
+	AccountErasureStatusCompleted AccountErasureStatus = "completed"
+)
+
+// Enum values for AccountErasureStep
+const (
+	AccountErasureStepProfiles           AccountErasureStep = "profiles"
+	AccountErasureStepContactDetails     AccountErasureStep = "contact_details"
+	AccountErasureStepProfileHistory     AccountErasureStep = "profile_history"
+	AccountErasureStepProfileDrafts      AccountErasureStep = "profile_drafts"
+	AccountErasureStepPartnerPreferences AccountErasureStep = "partner_preferences"
+	AccountErasureStepPrivacySettings    AccountErasureStep = "privacy_settings"
+	AccountErasureStepShareLinks         AccountErasureStep = "share_links"
+	AccountErasureStepSavedSearches      AccountErasureStep = "saved_searches"
+	AccountErasureStepInterestMessages   AccountErasureStep = "interest_messages"
+	AccountErasureStepRecommendations    AccountErasureStep = "recommendations"
+	AccountErasureStepProfileManagers    AccountErasureStep = "profile_managers"
+	AccountErasureStepDataExports        AccountErasureStep = "data_exports"
+	AccountErasureStepConsentRecords     AccountErasureStep = "consent_records"
+)
+
+// AccountErasureSteps lists the steps in the order they are carried out. The profiles
+// are anonymized first, so that they drop out of search before the rest is erased.
+var AccountErasureSteps = []AccountErasureStep{
+	AccountErasureStepProfiles,
+	AccountErasureStepContactDetails,
+	AccountErasureStepProfileHistory,
+	AccountErasureStepProfileDrafts,
+	AccountErasureStepPartnerPreferences,
+	AccountErasureStepPrivacySettings,
+	AccountErasureStepShareLinks,
+	AccountErasureStepSavedSearches,
+	AccountErasureStepInterestMessages,
+	AccountErasureStepRecommendations,
+	AccountErasureStepProfileManagers,
+	AccountErasureStepDataExports,
+	AccountErasureStepConsentRecords,
+}
+
+// AccountErasure represents the erasure of everything identifying an account, at its
+// owner's request. CompletedSteps counts the steps of AccountErasureSteps done so far
+// and Summary holds the rows each affected, as JSON. On completion UserID is cleared,
+// leaving SubjectHash to match the account, and the receipt is sealed into a chain:
+// ReceiptHash covers the receipt, which includes the previous receipt's hash.
+type AccountErasure struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primary_key" json:"id"`
+	UserID         *uuid.UUID           `gorm:"type:uuid" json:"user_id"`
+	SubjectHash    string               `gorm:"type:varchar(64);not null" json:"subject_hash"`
+	Status         AccountErasureStatus `gorm:"type:varchar(10);not null;default:pending" json:"status"`
+	CompletedSteps int                  `gorm:"type:smallint;not null;default:0" json:"completed_steps"`
+	Summary        []byte               `gorm:"type:jsonb;not null" json:"summary"`
+	RequestID      string               `gorm:"type:varchar(64);not null;default:''" json:"request_id"`
+	Sequence       *int64               `json:"sequence"`
+	PreviousHash   string               `gorm:"type:varchar(64);not null;default:''" json:"previous_hash"`
+	ReceiptHash    string               `gorm:"type:varchar(64);not null;default:''" json:"receipt_hash"`
+	Receipt        string               `gorm:"type:text;not null;default:''" json:"receipt"`
+	CreatedAt      time.Time            `gorm:"not null" json:"created_at"`
+	StartedAt      *time.Time           `json:"started_at"`
+	CompletedAt    *time.Time           `json:"completed_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (ae *AccountErasure) BeforeCreate(tx *gorm.DB) error {
+	if ae.ID == uuid.Nil {
+		ae.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for AccountErasure model
+func (AccountErasure) TableName() string {
+	return "account_erasures"
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActiveProfile records the profile an account last switched to. Requests that
+// don't name an acting profile act as this one.
+type ActiveProfile struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
+	ProfileID uuid.UUID `gorm:"type:uuid;not null" json:"profile_id"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// TableName specifies the table name for ActiveProfile model
+func (ActiveProfile) TableName() string {
+	return "active_profiles"
+}
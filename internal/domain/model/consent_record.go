@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConsentPolicy names a policy an account can agree to
+type ConsentPolicy string
+
+// ConsentAction represents what an account did about a policy version
+type ConsentAction string
+
+// Enum values for ConsentPolicy
+const (
+	ConsentPolicyTerms         ConsentPolicy = "terms"
+	ConsentPolicyPrivacyPolicy ConsentPolicy = "privacy_policy"
+	ConsentPolicyPhotoDisplay  ConsentPolicy = "photo_display"
+	ConsentPolicyDataSharing   ConsentPolicy = "data_sharing"
+)
+
+// Enum values for ConsentAction
+const (
+	ConsentActionGranted   ConsentAction = "granted"
+	ConsentActionWithdrawn ConsentAction = "withdrawn"
+)
+
+// ConsentPolicies lists the policies an account can agree to, mandatory ones first
+var ConsentPolicies = []ConsentPolicy{
+	ConsentPolicyTerms,
+	ConsentPolicyPrivacyPolicy,
+	ConsentPolicyPhotoDisplay,
+	ConsentPolicyDataSharing,
+}
+
+// IsValid reports whether the policy is one an account can agree to
+func (p ConsentPolicy) IsValid() bool {
+	for _, policy := range ConsentPolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// Mandatory reports whether the current version of the policy must be accepted before
+// the account can work with profiles
+func (p ConsentPolicy) Mandatory() bool {
+	return p == ConsentPolicyTerms || p == ConsentPolicyPrivacyPolicy
+}
+
+// ConsentRecord represents an entry of the consent ledger: an account granting or
+// withdrawing its agreement to a version of a policy. Records are only ever appended;
+// SourceIP and RequestID tie each to the request that made it.
+type ConsentRecord struct {
+	ID            uuid.UUID     `gorm:"type:uuid;primary_key" json:"id"`
+	UserID        uuid.UUID     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Policy        ConsentPolicy `gorm:"type:varchar(20);not null" json:"policy"`
+	PolicyVersion string        `gorm:"type:varchar(50);not null" json:"policy_version"`
+	Action        ConsentAction `gorm:"type:varchar(10);not null" json:"action"`
+	SourceIP      *string       `gorm:"type:varchar(45)" json:"source_ip"`
+	RequestID     string        `gorm:"type:varchar(64);not null;default:''" json:"request_id"`
+	CreatedAt     time.Time     `gorm:"not null" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (cr *ConsentRecord) BeforeCreate(tx *gorm.DB) error {
+	if cr.ID == uuid.Nil {
+		cr.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ConsentRecord model
+func (ConsentRecord) TableName() string {
+	return "consent_records"
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContactRevealOutcome represents the result of a request to reveal contact details
+type ContactRevealOutcome string
+
+// Enum values for ContactRevealOutcome
+const (
+	ContactRevealOutcomeRevealed        ContactRevealOutcome = "revealed"
+	ContactRevealOutcomeAlreadyRevealed ContactRevealOutcome = "already_revealed"
+	ContactRevealOutcomeDenied          ContactRevealOutcome = "denied"
+)
+
+// ContactRevealBasis represents what entitled a viewer to see contact details
+type ContactRevealBasis string
+
+// Enum values for ContactRevealBasis
+const (
+	ContactRevealBasisInterest ContactRevealBasis = "interest"
+	ContactRevealBasisQuota    ContactRevealBasis = "quota"
+)
+
+// ContactDetail holds the encrypted contact details of a profile
+type ContactDetail struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"profile_id"`
+	DetailsEncrypted []byte    `gorm:"type:bytea;not null" json:"-"`
+	CreatedAt        time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (cd *ContactDetail) BeforeCreate(tx *gorm.DB) error {
+	if cd.ID == uuid.Nil {
+		cd.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ContactDetail model
+func (ContactDetail) TableName() string {
+	return "contact_details"
+}
+
+// ContactReveal is an audit record of a request to reveal a profile's contact details
+type ContactReveal struct {
+	ID              uuid.UUID            `gorm:"type:uuid;primary_key" json:"id"`
+	ViewerProfileID uuid.UUID            `gorm:"type:uuid;not null" json:"viewer_profile_id"`
+	ViewerUserID    uuid.UUID            `gorm:"type:uuid;not null" json:"viewer_user_id"`
+	ProfileID       uuid.UUID            `gorm:"type:uuid;not null" json:"profile_id"`
+	Outcome         ContactRevealOutcome `gorm:"type:contact_reveal_outcome_type;not null" json:"outcome"`
+	Basis           *ContactRevealBasis  `gorm:"type:contact_reveal_basis_type" json:"basis"`
+	RequestID       string               `gorm:"type:varchar(64);not null;default:''" json:"request_id"`
+	CreatedAt       time.Time            `gorm:"not null" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (cr *ContactReveal) BeforeCreate(tx *gorm.DB) error {
+	if cr.ID == uuid.Nil {
+		cr.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ContactReveal model
+func (ContactReveal) TableName() string {
+	return "contact_reveals"
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExportStatus represents the state of a personal data export
+type DataExportStatus string
+
+// Enum values for DataExportStatus
+const (
+	DataExportStatusPending   DataExportStatus = "pending"
+	DataExportStatusRunning   DataExportStatus = "running"
+	DataExportStatusCompleted DataExportStatus = "completed"
+	DataExportStatusFailed    DataExportStatus = "failed"
+	DataExportStatusExpired   DataExportStatus = "expired"
+)
+
+// DataExport represents a request of an account for a copy of everything the service
+// holds about it. A background job assembles the archive, which can be downloaded
+// until ExpiresAt and is dropped afterwards.
+type DataExport struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primary_key" json:"id"`
+	UserID      uuid.UUID        `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status      DataExportStatus `gorm:"type:varchar(10);not null;default:pending" json:"status"`
+	Archive     []byte           `gorm:"type:bytea" json:"-"`
+	ArchiveSize int64            `gorm:"not null;default:0" json:"archive_size"`
+	Error       string           `gorm:"type:varchar(255);not null;default:''" json:"error"`
+	RequestID   string           `gorm:"type:varchar(64);not null;default:''" json:"request_id"`
+	CreatedAt   time.Time        `gorm:"not null" json:"created_at"`
+	StartedAt   *time.Time       `json:"started_at"`
+	CompletedAt *time.Time       `json:"completed_at"`
+	ExpiresAt   *time.Time       `json:"expires_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (de *DataExport) BeforeCreate(tx *gorm.DB) error {
+	if de.ID == uuid.Nil {
+		de.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for DataExport model
+func (DataExport) TableName() string {
+	return "data_exports"
+}
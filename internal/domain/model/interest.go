@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InterestStatus represents the state of an interest sent to a profile
+type InterestStatus string
+
+// Enum values for InterestStatus
+const (
+	InterestStatusPending  InterestStatus = "pending"
+	InterestStatusAccepted InterestStatus = "accepted"
+	InterestStatusDeclined InterestStatus = "declined"
+)
+
+// Interest represents an expression of interest from one profile in another
+type Interest struct {
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	SenderProfileID   uuid.UUID      `gorm:"type:uuid;not null" json:"sender_profile_id"`
+	ReceiverProfileID uuid.UUID      `gorm:"type:uuid;not null;index" json:"receiver_profile_id"`
+	Status            InterestStatus `gorm:"type:interest_status_type;not null;default:pending" json:"status"`
+	Message           string         `gorm:"type:varchar(500);not null;default:''" json:"message"`
+	RespondedAt       *time.Time     `json:"responded_at"`
+	CreatedAt         time.Time      `gorm:"not null" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"not null" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (i *Interest) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Interest model
+func (Interest) TableName() string {
+	return "interests"
+}
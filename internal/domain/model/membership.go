@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FreePlanCode is the plan of users without an active subscription
+const FreePlanCode = "free"
+
+// MembershipPlan defines a membership tier and the entitlements it grants
+type MembershipPlan struct {
+	Code                  string    `gorm:"type:varchar(50);primary_key" json:"code"`
+	Name                  string    `gorm:"type:varchar(100);not null" json:"name"`
+	PricePaise            int64     `gorm:"not null;default:0" json:"price_paise"`
+	Currency              string    `gorm:"type:char(3);not null;default:INR" json:"currency"`
+	DurationDays          int       `gorm:"not null;default:0" json:"duration_days"`
+	MonthlyContactReveals int       `gorm:"not null;default:0" json:"monthly_contact_reveals"`
+	MonthlyProfileBoosts  int       `gorm:"not null;default:0" json:"monthly_profile_boosts"`
+	WhoViewedMe           bool      `gorm:"not null;default:false" json:"who_viewed_me"`
+	AdvancedFilters       bool      `gorm:"not null;default:false" json:"advanced_filters"`
+	IsActive              bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt             time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt             time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// TableName specifies the table name for MembershipPlan model
+func (MembershipPlan) TableName() string {
+	return "membership_plans"
+}
+
+// SubscriptionStatus represents the state of a subscription
+type SubscriptionStatus string
+
+// Enum values for SubscriptionStatus
+const (
+	SubscriptionStatusPending   SubscriptionStatus = "pending"
+	SubscriptionStatusActive    SubscriptionStatus = "active"
+	SubscriptionStatusFailed    SubscriptionStatus = "failed"
+	SubscriptionStatusCancelled SubscriptionStatus = "cancelled"
+)
+
+// Subscription is a user's purchase of a membership plan
+type Subscription struct {
+	ID                uuid.UUID          `gorm:"type:uuid;primary_key" json:"id"`
+	UserID            uuid.UUID          `gorm:"type:uuid;not null;index" json:"user_id"`
+	PlanCode          string             `gorm:"type:varchar(50);not null" json:"plan_code"`
+	Status            SubscriptionStatus `gorm:"type:subscription_status_type;not null;default:pending" json:"status"`
+	Provider          string             `gorm:"type:varchar(50);not null" json:"provider"`
+	ProviderReference string             `gorm:"type:varchar(255);not null" json:"provider_reference"`
+	StartsAt          *time.Time         `json:"starts_at"`
+	ExpiresAt         *time.Time         `json:"expires_at"`
+	CreatedAt         time.Time          `gorm:"not null" json:"created_at"`
+	UpdatedAt         time.Time          `gorm:"not null" json:"updated_at"`
+
+	Plan *MembershipPlan `gorm:"foreignKey:PlanCode;references:Code" json:"plan,omitempty"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (s *Subscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Subscription model
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+// PaymentEvent is a webhook event received from a payment provider
+type PaymentEvent struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	Provider       string     `gorm:"type:varchar(50);not null" json:"provider"`
+	EventID        string     `gorm:"type:varchar(255);not null" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(100);not null" json:"event_type"`
+	SubscriptionID *uuid.UUID `gorm:"type:uuid" json:"subscription_id"`
+	Payload        []byte     `gorm:"type:jsonb;not null" json:"payload"`
+	CreatedAt      time.Time  `gorm:"not null" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (e *PaymentEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for PaymentEvent model
+func (PaymentEvent) TableName() string {
+	return "payment_events"
+}
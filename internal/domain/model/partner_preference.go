@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PartnerPreference represents the partner preferences of a profile
+type PartnerPreference struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"profile_id"`
+	Criteria  []byte    `gorm:"type:jsonb;not null" json:"criteria"`
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pp *PartnerPreference) BeforeCreate(tx *gorm.DB) error {
+	if pp.ID == uuid.Nil {
+		pp.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for PartnerPreference model
+func (PartnerPreference) TableName() string {
+	return "partner_preferences"
+}
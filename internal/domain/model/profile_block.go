@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileBlock represents a profile blocked by another profile
+type ProfileBlock struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID        uuid.UUID `gorm:"type:uuid;not null" json:"profile_id"`
+	BlockedProfileID uuid.UUID `gorm:"type:uuid;not null;index" json:"blocked_profile_id"`
+	CreatedAt        time.Time `gorm:"not null" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pb *ProfileBlock) BeforeCreate(tx *gorm.DB) error {
+	if pb.ID == uuid.Nil {
+		pb.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ProfileBlock model
+func (ProfileBlock) TableName() string {
+	return "profile_blocks"
+}
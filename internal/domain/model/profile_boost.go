@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileBoost features a profile near the top of search results for a period
+type ProfileBoost struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID   uuid.UUID `gorm:"type:uuid;not null;index" json:"profile_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	StartsAt    time.Time `gorm:"not null" json:"starts_at"`
+	EndsAt      time.Time `gorm:"not null" json:"ends_at"`
+	Impressions int64     `gorm:"not null;default:0" json:"impressions"`
+	Clicks      int64     `gorm:"not null;default:0" json:"clicks"`
+	CreatedAt   time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pb *ProfileBoost) BeforeCreate(tx *gorm.DB) error {
+	if pb.ID == uuid.Nil {
+		pb.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ProfileBoost model
+func (ProfileBoost) TableName() string {
+	return "profile_boosts"
+}
+
+// IsActive reports whether the boost covers the given time
+func (pb *ProfileBoost) IsActive(at time.Time) bool {
+	return !at.Before(pb.StartsAt) && at.Before(pb.EndsAt)
+}
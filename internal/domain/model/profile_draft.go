@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileDraftStep represents one step of filling in a profile
+type ProfileDraftStep string
+
+// Enum values for ProfileDraftStep
+const (
+	ProfileDraftStepBasic     ProfileDraftStep = "basic"
+	ProfileDraftStepPhysical  ProfileDraftStep = "physical"
+	ProfileDraftStepLocation  ProfileDraftStep = "location"
+	ProfileDraftStepReligious ProfileDraftStep = "religious"
+	ProfileDraftStepFamily    ProfileDraftStep = "family"
+)
+
+// ProfileDraftSteps lists the steps in the order they are filled in
+var ProfileDraftSteps = []ProfileDraftStep{
+	ProfileDraftStepBasic,
+	ProfileDraftStepPhysical,
+	ProfileDraftStepLocation,
+	ProfileDraftStepReligious,
+	ProfileDraftStepFamily,
+}
+
+// ProfileDraft represents a profile being filled in step by step, so that an
+// abandoned form can be resumed. Submitting it creates the profile and deletes it.
+type ProfileDraft struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Data      []byte           `gorm:"type:jsonb;not null" json:"data"`
+	LastStep  ProfileDraftStep `gorm:"type:varchar(20);not null" json:"last_step"`
+	CreatedAt time.Time        `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time        `gorm:"not null" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pd *ProfileDraft) BeforeCreate(tx *gorm.DB) error {
+	if pd.ID == uuid.Nil {
+		pd.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ProfileDraft model
+func (ProfileDraft) TableName() string {
+	return "profile_drafts"
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileManagerStatus represents the state of a delegation to manage a profile
+type ProfileManagerStatus string
+
+// Enum values for ProfileManagerStatus
+const (
+	ProfileManagerStatusInvited ProfileManagerStatus = "invited"
+	ProfileManagerStatusActive  ProfileManagerStatus = "active"
+	ProfileManagerStatusRevoked ProfileManagerStatus = "revoked"
+)
+
+// ProfileManagerScope is a permission the owner can grant to a profile manager
+type ProfileManagerScope string
+
+// Enum values for ProfileManagerScope
+const (
+	ProfileManagerScopeEdit             ProfileManagerScope = "edit"
+	ProfileManagerScopeRespondInterests ProfileManagerScope = "respond_interests"
+	ProfileManagerScopeViewContacts     ProfileManagerScope = "view_contacts"
+	ProfileManagerScopeDelete           ProfileManagerScope = "delete"
+)
+
+// ProfileManager represents a user other than the owner, such as a parent or
+// sibling, who has been invited to act on a profile
+type ProfileManager struct {
+	ID                  uuid.UUID            `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID           uuid.UUID            `gorm:"type:uuid;not null" json:"profile_id"`
+	ManagerUserID       uuid.UUID            `gorm:"type:uuid;not null;index" json:"manager_user_id"`
+	InvitedBy           uuid.UUID            `gorm:"type:uuid;not null" json:"invited_by"`
+	Status              ProfileManagerStatus `gorm:"type:profile_manager_status_type;not null;default:invited" json:"status"`
+	CanEdit             bool                 `gorm:"not null;default:false" json:"can_edit"`
+	CanRespondInterests bool                 `gorm:"not null;default:false" json:"can_respond_interests"`
+	CanViewContacts     bool                 `gorm:"not null;default:false" json:"can_view_contacts"`
+	CanDelete           bool                 `gorm:"not null;default:false" json:"can_delete"`
+	AcceptedAt          *time.Time           `json:"accepted_at"`
+	RevokedAt           *time.Time           `json:"revoked_at"`
+	CreatedAt           time.Time            `gorm:"not null" json:"created_at"`
+	UpdatedAt           time.Time            `gorm:"not null" json:"updated_at"`
+
+	Profile *UserProfile `gorm:"foreignKey:ProfileID" json:"profile,omitempty"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pm *ProfileManager) BeforeCreate(tx *gorm.DB) error {
+	if pm.ID == uuid.Nil {
+		pm.ID = uuid.New()
+	}
+	return nil
+}
+
+// Allows reports whether the manager may currently act on the profile within scope
+func (pm *ProfileManager) Allows(scope ProfileManagerScope) bool {
+	if pm.Status != ProfileManagerStatusActive {
+		return false
+	}
+
+	switch scope {
+	case ProfileManagerScopeEdit:
+		return pm.CanEdit
+	case ProfileManagerScopeRespondInterests:
+		return pm.CanRespondInterests
+	case ProfileManagerScopeViewContacts:
+		return pm.CanViewContacts
+	case ProfileManagerScopeDelete:
+		return pm.CanDelete
+	}
+	return false
+}
+
+// Scopes returns the scopes granted to the manager
+func (pm *ProfileManager) Scopes() []ProfileManagerScope {
+	scopes := make([]ProfileManagerScope, 0, 4)
+	if pm.CanEdit {
+		scopes = append(scopes, ProfileManagerScopeEdit)
+	}
+	if pm.CanRespondInterests {
+		scopes = append(scopes, ProfileManagerScopeRespondInterests)
+	}
+	if pm.CanViewContacts {
+		scopes = append(scopes, ProfileManagerScopeViewContacts)
+	}
+	if pm.CanDelete {
+		scopes = append(scopes, ProfileManagerScopeDelete)
+	}
+	return scopes
+}
+
+// SetScopes grants exactly the given scopes, revoking any others
+func (pm *ProfileManager) SetScopes(scopes []ProfileManagerScope) {
+	pm.CanEdit, pm.CanRespondInterests, pm.CanViewContacts, pm.CanDelete = false, false, false, false
+	for _, scope := range scopes {
+		switch scope {
+		case ProfileManagerScopeEdit:
+			pm.CanEdit = true
+		case ProfileManagerScopeRespondInterests:
+			pm.CanRespondInterests = true
+		case ProfileManagerScopeViewContacts:
+			pm.CanViewContacts = true
+		case ProfileManagerScopeDelete:
+			pm.CanDelete = true
+		}
+	}
+}
+
+// TableName specifies the table name for ProfileManager model
+func (ProfileManager) TableName() string {
+	return "profile_managers"
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProfilePrivacySettings controls which details of a profile appear on its printable
+// biodata and on the snapshots behind its public share links. Contact details are
+// never included in either. The defaults are set by DefaultProfilePrivacySettings
+// rather than by column defaults, which GORM would apply in place of false.
+type ProfilePrivacySettings struct {
+	ProfileID       uuid.UUID  `gorm:"type:uuid;primary_key" json:"profile_id"`
+	ShowPhoto       bool       `gorm:"not null" json:"show_photo"`
+	ShowDateOfBirth bool       `gorm:"not null" json:"show_date_of_birth"`
+	ShowResidence   bool       `gorm:"not null" json:"show_residence"`
+	ShowEducation   bool       `gorm:"not null" json:"show_education"`
+	ShowOccupation  bool       `gorm:"not null" json:"show_occupation"`
+	ShowAboutMe     bool       `gorm:"not null" json:"show_about_me"`
+	UpdatedBy       *uuid.UUID `gorm:"type:uuid" json:"updated_by"`
+	UpdatedAt       time.Time  `gorm:"not null" json:"updated_at"`
+}
+
+// DefaultProfilePrivacySettings returns the settings of a profile whose owner hasn't
+// changed them: everything is shown except the exact date of birth, in place of which
+// the age is shown
+func DefaultProfilePrivacySettings(profileID uuid.UUID) *ProfilePrivacySettings {
+	return &ProfilePrivacySettings{
+		ProfileID:      profileID,
+		ShowPhoto:      true,
+		ShowResidence:  true,
+		ShowEducation:  true,
+		ShowOccupation: true,
+		ShowAboutMe:    true,
+	}
+}
+
+// TableName specifies the table name for ProfilePrivacySettings model
+func (ProfilePrivacySettings) TableName() string {
+	return "profile_privacy_settings"
+}
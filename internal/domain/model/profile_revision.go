@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileRevisionAction represents what produced a profile revision
+type ProfileRevisionAction string
+
+// ProfileRevisionActorRole represents the capacity in which someone changed a profile
+type ProfileRevisionActorRole string
+
+// Enum values for ProfileRevisionAction
+const (
+	ProfileRevisionActionCreate ProfileRevisionAction = "create"
+	ProfileRevisionActionUpdate ProfileRevisionAction = "update"
+	ProfileRevisionActionRevert ProfileRevisionAction = "revert"
+)
+
+// Enum values for ProfileRevisionActorRole
+const (
+	ProfileRevisionActorOwner   ProfileRevisionActorRole = "owner"
+	ProfileRevisionActorManager ProfileRevisionActorRole = "manager"
+	ProfileRevisionActorAdmin   ProfileRevisionActorRole = "admin"
+)
+
+// ProfileRevision represents one change made to a profile. Changes holds the old and
+// new value of each field that changed, keyed by the field's JSON name.
+type ProfileRevision struct {
+	ID         uuid.UUID                `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID  uuid.UUID                `gorm:"type:uuid;not null;index" json:"profile_id"`
+	Action     ProfileRevisionAction    `gorm:"type:varchar(10);not null" json:"action"`
+	ActorID    uuid.UUID                `gorm:"type:uuid;not null" json:"actor_id"`
+	ActorRole  ProfileRevisionActorRole `gorm:"type:varchar(10);not null" json:"actor_role"`
+	RequestID  string                   `gorm:"type:varchar(64);not null;default:''" json:"request_id"`
+	Changes    []byte                   `gorm:"type:jsonb;not null" json:"changes"`
+	RevertedTo *uuid.UUID               `gorm:"type:uuid" json:"reverted_to"` // the revision a revert restored
+	Flagged    bool                     `gorm:"not null;default:false" json:"flagged"`
+	FlagReason string                   `gorm:"type:varchar(255);not null;default:''" json:"flag_reason"`
+	CreatedAt  time.Time                `gorm:"not null" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pr *ProfileRevision) BeforeCreate(tx *gorm.DB) error {
+	if pr.ID == uuid.Nil {
+		pr.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ProfileRevision model
+func (ProfileRevision) TableName() string {
+	return "profile_revisions"
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileShareLink represents a public link to a read-only snapshot of a profile,
+// shared with relatives who aren't members. The token in the link is signed rather
+// than stored; the link is looked up by the ID it carries.
+type ProfileShareLink struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"profile_id"`
+	CreatedBy    uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
+	Label        string     `gorm:"type:varchar(100);not null;default:''" json:"label"`
+	Snapshot     []byte     `gorm:"type:jsonb;not null" json:"snapshot"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	RevokedBy    *uuid.UUID `gorm:"type:uuid" json:"revoked_by"`
+	ViewCount    int64      `gorm:"not null;default:0" json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at"`
+	CreatedAt    time.Time  `gorm:"not null" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (sl *ProfileShareLink) BeforeCreate(tx *gorm.DB) error {
+	if sl.ID == uuid.Nil {
+		sl.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the link can still be opened at the given time
+func (sl *ProfileShareLink) IsActive(at time.Time) bool {
+	return sl.RevokedAt == nil && at.Before(sl.ExpiresAt)
+}
+
+// TableName specifies the table name for ProfileShareLink model
+func (ProfileShareLink) TableName() string {
+	return "profile_share_links"
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileTransferStatus represents the state of a profile ownership transfer
+type ProfileTransferStatus string
+
+// Enum values for ProfileTransferStatus
+const (
+	ProfileTransferStatusPending   ProfileTransferStatus = "pending"
+	ProfileTransferStatusAccepted  ProfileTransferStatus = "accepted"
+	ProfileTransferStatusCancelled ProfileTransferStatus = "cancelled"
+	ProfileTransferStatusExpired   ProfileTransferStatus = "expired"
+)
+
+// ProfileTransfer represents the hand-over of a profile from the account that owns
+// it to another account, which takes it over by presenting a one-time token.
+// The profile keeps its ProfileCreatedBy across owners; the copy recorded here
+// shows who had created it as of each transfer.
+type ProfileTransfer struct {
+	ID               uuid.UUID             `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID        uuid.UUID             `gorm:"type:uuid;not null" json:"profile_id"`
+	FromUserID       uuid.UUID             `gorm:"type:uuid;not null;index" json:"from_user_id"`
+	ToUserID         uuid.UUID             `gorm:"type:uuid;not null;index" json:"to_user_id"`
+	TokenHash        string                `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ProfileCreatedBy ProfileCreatedBy      `gorm:"type:profile_created_by;not null" json:"profile_created_by"`
+	Status           ProfileTransferStatus `gorm:"type:profile_transfer_status_type;not null;default:pending" json:"status"`
+	ExpiresAt        time.Time             `gorm:"not null" json:"expires_at"`
+	AcceptedAt       *time.Time            `json:"accepted_at"`
+	CancelledAt      *time.Time            `json:"cancelled_at"`
+	CancelledBy      *uuid.UUID            `gorm:"type:uuid" json:"cancelled_by"`
+	RequestID        string                `gorm:"type:varchar(100)" json:"request_id"`
+	CreatedAt        time.Time             `gorm:"not null" json:"created_at"`
+	UpdatedAt        time.Time             `gorm:"not null" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pt *ProfileTransfer) BeforeCreate(tx *gorm.DB) error {
+	if pt.ID == uuid.Nil {
+		pt.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsOpen reports whether the transfer can still be accepted at the given time
+func (pt *ProfileTransfer) IsOpen(at time.Time) bool {
+	return pt.Status == ProfileTransferStatusPending && at.Before(pt.ExpiresAt)
+}
+
+// TableName specifies the table name for ProfileTransfer model
+func (ProfileTransfer) TableName() string {
+	return "profile_transfers"
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProfileView records that a profile was viewed by another profile
+type ProfileView struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID       uuid.UUID `gorm:"type:uuid;not null" json:"profile_id"`
+	ViewerProfileID uuid.UUID `gorm:"type:uuid;not null" json:"viewer_profile_id"`
+	ViewCount       int       `gorm:"not null;default:1" json:"view_count"`
+	LastViewedAt    time.Time `gorm:"not null" json:"last_viewed_at"`
+	CreatedAt       time.Time `gorm:"not null" json:"created_at"`
+
+	Viewer *UserProfile `gorm:"foreignKey:ViewerProfileID" json:"viewer,omitempty"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (pv *ProfileView) BeforeCreate(tx *gorm.DB) error {
+	if pv.ID == uuid.Nil {
+		pv.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ProfileView model
+func (ProfileView) TableName() string {
+	return "profile_views"
+}
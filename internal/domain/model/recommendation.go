@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecommendationStatus represents the feedback given on a recommendation
+type RecommendationStatus string
+
+// Enum values for RecommendationStatus
+const (
+	RecommendationStatusNew           RecommendationStatus = "new"
+	RecommendationStatusSkipped       RecommendationStatus = "skipped"
+	RecommendationStatusNotInterested RecommendationStatus = "not_interested"
+)
+
+// RecommendationRun records that recommendations were generated for a profile on a day
+type RecommendationRun struct {
+	ProfileID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"profile_id"`
+	GeneratedOn time.Time `gorm:"type:date;primaryKey" json:"generated_on"`
+	Candidates  int       `gorm:"not null" json:"candidates"`
+	CreatedAt   time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName specifies the table name for RecommendationRun model
+func (RecommendationRun) TableName() string {
+	return "recommendation_runs"
+}
+
+// Recommendation represents a candidate profile recommended to a profile on a given day
+type Recommendation struct {
+	ID          uuid.UUID            `gorm:"type:uuid;primary_key" json:"id"`
+	ProfileID   uuid.UUID            `gorm:"type:uuid;not null" json:"profile_id"`
+	CandidateID uuid.UUID            `gorm:"type:uuid;not null" json:"candidate_id"`
+	Candidate   *UserProfile         `gorm:"foreignKey:CandidateID" json:"-"`
+	GeneratedOn time.Time            `gorm:"type:date;not null" json:"generated_on"`
+	Rank        int                  `gorm:"not null" json:"rank"`
+	Score       float64              `gorm:"not null" json:"score"`
+	Status      RecommendationStatus `gorm:"type:recommendation_status_type;not null;default:new" json:"status"`
+	CreatedAt   time.Time            `gorm:"not null" json:"created_at"`
+	UpdatedAt   time.Time            `gorm:"not null" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (r *Recommendation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Recommendation model
+func (Recommendation) TableName() string {
+	return "recommendations"
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AlertFrequency represents how often a saved search is checked for new matches
+type AlertFrequency string
+
+// Enum values for AlertFrequency
+const (
+	AlertFrequencyInstant AlertFrequency = "instant"
+	AlertFrequencyDaily   AlertFrequency = "daily"
+	AlertFrequencyWeekly  AlertFrequency = "weekly"
+)
+
+// SavedSearch represents a named set of search filters stored by a user
+type SavedSearch struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name      string         `gorm:"type:varchar(100);not null" json:"name"`
+	Filter    []byte         `gorm:"type:jsonb;not null" json:"filter"`
+	Frequency AlertFrequency `gorm:"type:alert_frequency_type;not null" json:"frequency"`
+	LastRunAt time.Time      `gorm:"not null" json:"last_run_at"`
+	CreatedAt time.Time      `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"not null" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (ss *SavedSearch) BeforeCreate(tx *gorm.DB) error {
+	if ss.ID == uuid.Nil {
+		ss.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for SavedSearch model
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// SearchAlert represents a new profile matching a user's saved search
+type SearchAlert struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	SavedSearchID uuid.UUID  `gorm:"type:uuid;not null" json:"saved_search_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ProfileID     uuid.UUID  `gorm:"type:uuid;not null" json:"profile_id"`
+	NotifiedAt    *time.Time `json:"notified_at"`
+	CreatedAt     time.Time  `gorm:"not null" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID rather than numeric ID
+func (sa *SearchAlert) BeforeCreate(tx *gorm.DB) error {
+	if sa.ID == uuid.Nil {
+		sa.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for SearchAlert model
+func (SearchAlert) TableName() string {
+	return "search_alerts"
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/age"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/phonetic"
 	"gorm.io/gorm"
 )
 
@@ -22,6 +24,9 @@ type MaritalStatus string
 // HomeDistrict represents the home district of the profile in Kerala
 type HomeDistrict string
 
+// DateCalendar represents the calendar a date was given in
+type DateCalendar string
+
 // Enum values for ProfileCreatedBy
 const (
 	ProfileCreatedBySelf     ProfileCreatedBy = "Self"
@@ -59,6 +64,12 @@ const (
 	MaritalStatusNikahDivorce MaritalStatus = "Nikah Divorce"
 )
 
+// Enum values for DateCalendar
+const (
+	DateCalendarGregorian DateCalendar = "gregorian"
+	DateCalendarHijri     DateCalendar = "hijri"
+)
+
 // Enum values for HomeDistrict (the 14 districts of Kerala)
 const (
 	HomeDistrictThiruvananthapuram HomeDistrict = "Thiruvananthapuram"
@@ -77,14 +88,57 @@ const (
 	HomeDistrictKasaragod          HomeDistrict = "Kasaragod"
 )
 
+// IsValid reports whether the community is one of the enum values
+func (c Community) IsValid() bool {
+	switch c {
+	case CommunityAMuslim, CommunityHanafi, CommunitySalafi, CommunitySunni,
+		CommunityThableegh, CommunityShia, CommunityJamatIslami:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the nationality is one of the enum values
+func (n Nationality) IsValid() bool {
+	switch n {
+	case NationalityIndia, NationalityUAE, NationalityUK, NationalityUSA:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the marital status is one of the enum values
+func (m MaritalStatus) IsValid() bool {
+	switch m {
+	case MaritalStatusNeverMarried, MaritalStatusWidower, MaritalStatusDivorced, MaritalStatusNikahDivorce:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the home district is one of the enum values
+func (d HomeDistrict) IsValid() bool {
+	switch d {
+	case HomeDistrictThiruvananthapuram, HomeDistrictKollam, HomeDistrictPathanamthitta,
+		HomeDistrictAlappuzha, HomeDistrictKottayam, HomeDistrictIdukki, HomeDistrictErnakulam,
+		HomeDistrictThrissur, HomeDistrictPalakkad, HomeDistrictMalappuram, HomeDistrictKozhikode,
+		HomeDistrictWayanad, HomeDistrictKannur, HomeDistrictKasaragod:
+		return true
+	}
+	return false
+}
+
 // UserProfile represents the profile information of a user in the matrimony platform
 type UserProfile struct {
 	ID                     uuid.UUID        `gorm:"type:uuid;primary_key" json:"id"`
-	UserID                 uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	ProfileCode            string           `gorm:"type:varchar(20);<-:create;default:next_profile_code()" json:"profile_code"`
+	UserID                 uuid.UUID        `gorm:"type:uuid;not null;index" json:"user_id"`
 	IsGroom                bool             `gorm:"not null" json:"is_groom"`
 	ProfileCreatedBy       ProfileCreatedBy `gorm:"type:profile_created_by;not null" json:"profile_created_by"`
 	Name                   string           `gorm:"type:varchar(100);not null" json:"name"`
+	NamePhonetic           string           `gorm:"type:varchar(255);not null" json:"-"`
 	DateOfBirth            time.Time        `gorm:"type:date;not null" json:"date_of_birth"`
+	DateOfBirthCalendar    DateCalendar     `gorm:"type:varchar(10);not null;default:gregorian" json:"date_of_birth_calendar"`
 	Community              Community        `gorm:"type:community_type;not null" json:"community"`
 	Nationality            Nationality      `gorm:"type:nationality_type;not null" json:"nationality"`
 	Height                 float64          `gorm:"type:decimal(5,2);not null" json:"height"`
@@ -92,9 +146,22 @@ type UserProfile struct {
 	MaritalStatus          MaritalStatus    `gorm:"type:marital_status_type;not null" json:"marital_status"`
 	IsPhysicallyChallenged bool             `gorm:"not null;default:false" json:"is_physically_challenged"`
 	HomeDistrict           HomeDistrict     `gorm:"type:home_district_type;not null" json:"home_district"`
+	ResidenceCity          string           `gorm:"type:varchar(100);not null;default:''" json:"residence_city"`
+	Latitude               *float64         `gorm:"type:decimal(9,6)" json:"latitude"`
+	Longitude              *float64         `gorm:"type:decimal(9,6)" json:"longitude"`
+	Education              string           `gorm:"type:varchar(100);not null;default:''" json:"education"`
+	Occupation             string           `gorm:"type:varchar(100);not null;default:''" json:"occupation"`
+	AboutMe                string           `gorm:"type:text;not null;default:''" json:"about_me"`
+	FatherOccupation       string           `gorm:"type:varchar(100);not null;default:''" json:"father_occupation"`
+	MotherOccupation       string           `gorm:"type:varchar(100);not null;default:''" json:"mother_occupation"`
+	Siblings               string           `gorm:"type:varchar(200);not null;default:''" json:"siblings"`
+	CompletenessScore      int              `gorm:"type:smallint;not null;default:0" json:"completeness_score"`
 	CreatedAt              time.Time        `gorm:"not null" json:"created_at"`
 	UpdatedAt              time.Time        `gorm:"not null" json:"updated_at"`
+	UpdatedBy              *uuid.UUID       `gorm:"type:uuid" json:"updated_by"`
 	DeletedAt              gorm.DeletedAt   `gorm:"index" json:"deleted_at"`
+	DeletedBy              *uuid.UUID       `gorm:"type:uuid" json:"deleted_by"`
+	PurgedAt               *time.Time       `json:"purged_at"` // when a deleted profile was anonymized past retention
 }
 
 // BeforeCreate will set a UUID rather than numeric ID
@@ -105,20 +172,23 @@ func (up *UserProfile) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-// Age calculates the user's age based on the date of birth
-func (up *UserProfile) Age() int {
-	now := time.Now()
-	years := now.Year() - up.DateOfBirth.Year()
-
-	// Adjust age if birthday hasn't occurred yet this year
-	birthMonth, birthDay := up.DateOfBirth.Month(), up.DateOfBirth.Day()
-	currentMonth, currentDay := now.Month(), now.Day()
+// BeforeSave keeps the phonetic name key in sync with the name
+func (up *UserProfile) BeforeSave(tx *gorm.DB) error {
+	up.NamePhonetic = phonetic.Key(up.Name)
+	return nil
+}
 
-	if currentMonth < birthMonth || (currentMonth == birthMonth && currentDay < birthDay) {
-		years--
+// Calendar returns the calendar the date of birth was entered in, Gregorian if none is set
+func (up *UserProfile) Calendar() DateCalendar {
+	if up.DateOfBirthCalendar == "" {
+		return DateCalendarGregorian
 	}
+	return up.DateOfBirthCalendar
+}
 
-	return years
+// Age calculates the user's age based on the date of birth, as of today in IST
+func (up *UserProfile) Age() int {
+	return age.Of(up.DateOfBirth)
 }
 
 // TableName specifies the table name for UserProfile model
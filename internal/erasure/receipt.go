@@ -0,0 +1,82 @@
+package erasure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrTampered is returned for a receipt that doesn't match its hash or its place in the chain
+var ErrTampered = errors.New("erasure receipt does not match the chain")
+
+// Receipt records that an account was erased. Receipts form a chain: each holds the
+// hash of the one before it, so altering, removing or reordering a receipt breaks
+// every hash after it.
+type Receipt struct {
+	ErasureID    uuid.UUID        `json:"erasure_id"`
+	Sequence     int64            `json:"sequence"`
+	SubjectHash  string           `json:"subject_hash"`
+	RequestID    string           `json:"request_id,omitempty"`
+	RequestedAt  time.Time        `json:"requested_at"`
+	CompletedAt  time.Time        `json:"completed_at"`
+	Rows         map[string]int64 `json:"rows"` // rows anonymized or removed per kind of record
+	PreviousHash string           `json:"previous_hash"`
+}
+
+// SubjectHash returns the hash identifying an erased account. The account's ID can be
+// matched against it, but not recovered from it.
+func SubjectHash(userID uuid.UUID) string {
+	sum := sha256.Sum256([]byte(userID.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Seal encodes a receipt and returns the encoding with its hash. The encoding must be
+// stored as it is, since the hash covers its exact bytes.
+func Seal(receipt *Receipt) (string, string, error) {
+	data, err := json.Marshal(receipt)
+	if err != nil {
+		return "", "", err
+	}
+	return string(data), hash(data), nil
+}
+
+// Chain verifies stored receipts in sequence order, one at a time
+type Chain struct {
+	sequence int64
+	lastHash string
+}
+
+// Next checks that a stored receipt matches its hash and follows the receipts checked
+// before it, and returns the decoded receipt
+func (c *Chain) Next(encoded, receiptHash string) (*Receipt, error) {
+	if hash([]byte(encoded)) != receiptHash {
+		return nil, ErrTampered
+	}
+
+	var receipt Receipt
+	if err := json.Unmarshal([]byte(encoded), &receipt); err != nil {
+		return nil, ErrTampered
+	}
+	if receipt.Sequence != c.sequence+1 || receipt.PreviousHash != c.lastHash {
+		return nil, ErrTampered
+	}
+
+	c.sequence = receipt.Sequence
+	c.lastHash = receiptHash
+	return &receipt, nil
+}
+
+// LastHash returns the hash of the last receipt checked, which vouches for the whole chain
+func (c *Chain) LastHash() string {
+	return c.lastHash
+}
+
+// hash returns the hex-encoded SHA-256 of data
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,202 @@
+package geo
+
+import (
+	"sort"
+	"strings"
+)
+
+// Place is a named location from the bundled gazetteer
+type Place struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Point
+}
+
+// places is the offline gazetteer of cities and districts where members live.
+// Coordinates are city centres, which is precise enough for radius searches.
+var places = []Place{
+	// Kerala district headquarters
+	{"Thiruvananthapuram", "India", Point{8.5241, 76.9366}},
+	{"Kollam", "India", Point{8.8932, 76.6141}},
+	{"Pathanamthitta", "India", Point{9.2648, 76.7870}},
+	{"Alappuzha", "India", Point{9.4981, 76.3388}},
+	{"Kottayam", "India", Point{9.5916, 76.5222}},
+	{"Idukki", "India", Point{9.8497, 76.9720}},
+	{"Ernakulam", "India", Point{9.9816, 76.2999}},
+	{"Thrissur", "India", Point{10.5276, 76.2144}},
+	{"Palakkad", "India", Point{10.7867, 76.6548}},
+	{"Malappuram", "India", Point{11.0510, 76.0711}},
+	{"Kozhikode", "India", Point{11.2588, 75.7804}},
+	{"Wayanad", "India", Point{11.6085, 76.0830}},
+	{"Kannur", "India", Point{11.8745, 75.3704}},
+	{"Kasaragod", "India", Point{12.4996, 74.9869}},
+
+	// Other Kerala towns
+	{"Kochi", "India", Point{9.9312, 76.2673}},
+	{"Aluva", "India", Point{10.1004, 76.3570}},
+	{"Perumbavoor", "India", Point{10.1155, 76.4766}},
+	{"Kodungallur", "India", Point{10.2316, 76.1956}},
+	{"Guruvayur", "India", Point{10.5946, 76.0410}},
+	{"Kayamkulam", "India", Point{9.1748, 76.5013}},
+	{"Attingal", "India", Point{8.6966, 76.8150}},
+	{"Ottapalam", "India", Point{10.7735, 76.3776}},
+	{"Pattambi", "India", Point{10.8060, 76.1960}},
+	{"Ponnani", "India", Point{10.7677, 75.9259}},
+	{"Tirur", "India", Point{10.9146, 75.9217}},
+	{"Kottakkal", "India", Point{10.9990, 76.0030}},
+	{"Perinthalmanna", "India", Point{10.9760, 76.2254}},
+	{"Manjeri", "India", Point{11.1203, 76.1199}},
+	{"Kondotty", "India", Point{11.1473, 75.9620}},
+	{"Nilambur", "India", Point{11.2769, 76.2254}},
+	{"Koyilandy", "India", Point{11.4390, 75.6950}},
+	{"Vadakara", "India", Point{11.6093, 75.5917}},
+	{"Thalassery", "India", Point{11.7491, 75.4890}},
+	{"Kanhangad", "India", Point{12.3106, 75.0917}},
+	{"Mananthavady", "India", Point{11.8014, 76.0044}},
+	{"Sulthan Bathery", "India", Point{11.6640, 76.2590}},
+
+	// Other Indian cities
+	{"Bengaluru", "India", Point{12.9716, 77.5946}},
+	{"Chennai", "India", Point{13.0827, 80.2707}},
+	{"Mumbai", "India", Point{19.0760, 72.8777}},
+	{"Delhi", "India", Point{28.7041, 77.1025}},
+	{"Hyderabad", "India", Point{17.3850, 78.4867}},
+	{"Pune", "India", Point{18.5204, 73.8567}},
+	{"Mangaluru", "India", Point{12.9141, 74.8560}},
+	{"Mysuru", "India", Point{12.2958, 76.6394}},
+	{"Coimbatore", "India", Point{11.0168, 76.9558}},
+
+	// UAE
+	{"Dubai", "UAE", Point{25.2048, 55.2708}},
+	{"Abu Dhabi", "UAE", Point{24.4539, 54.3773}},
+	{"Sharjah", "UAE", Point{25.3463, 55.4209}},
+	{"Ajman", "UAE", Point{25.4052, 55.5136}},
+	{"Umm Al Quwain", "UAE", Point{25.5647, 55.5552}},
+	{"Ras Al Khaimah", "UAE", Point{25.8007, 55.9762}},
+	{"Fujairah", "UAE", Point{25.1288, 56.3265}},
+	{"Al Ain", "UAE", Point{24.2075, 55.7447}},
+
+	// Other Gulf countries
+	{"Doha", "Qatar", Point{25.2854, 51.5310}},
+	{"Riyadh", "Saudi Arabia", Point{24.7136, 46.6753}},
+	{"Jeddah", "Saudi Arabia", Point{21.4858, 39.1925}},
+	{"Makkah", "Saudi Arabia", Point{21.3891, 39.8579}},
+	{"Madinah", "Saudi Arabia", Point{24.5247, 39.5692}},
+	{"Dammam", "Saudi Arabia", Point{26.4207, 50.0888}},
+	{"Al Khobar", "Saudi Arabia", Point{26.2172, 50.1971}},
+	{"Kuwait City", "Kuwait", Point{29.3759, 47.9774}},
+	{"Muscat", "Oman", Point{23.5880, 58.3829}},
+	{"Sohar", "Oman", Point{24.3470, 56.7090}},
+	{"Salalah", "Oman", Point{17.0151, 54.0924}},
+	{"Manama", "Bahrain", Point{26.2285, 50.5860}},
+
+	// UK
+	{"London", "UK", Point{51.5074, -0.1278}},
+	{"Birmingham", "UK", Point{52.4862, -1.8904}},
+	{"Manchester", "UK", Point{53.4808, -2.2426}},
+	{"Leicester", "UK", Point{52.6369, -1.1398}},
+	{"Leeds", "UK", Point{53.8008, -1.5491}},
+	{"Bradford", "UK", Point{53.7960, -1.7594}},
+	{"Glasgow", "UK", Point{55.8642, -4.2518}},
+	{"Cardiff", "UK", Point{51.4816, -3.1791}},
+
+	// USA
+	{"New York", "USA", Point{40.7128, -74.0060}},
+	{"Jersey City", "USA", Point{40.7178, -74.0431}},
+	{"Boston", "USA", Point{42.3601, -71.0589}},
+	{"Washington", "USA", Point{38.9072, -77.0369}},
+	{"Atlanta", "USA", Point{33.7490, -84.3880}},
+	{"Chicago", "USA", Point{41.8781, -87.6298}},
+	{"Houston", "USA", Point{29.7604, -95.3698}},
+	{"Dallas", "USA", Point{32.7767, -96.7970}},
+	{"Los Angeles", "USA", Point{34.0522, -118.2437}},
+	{"San Francisco", "USA", Point{37.7749, -122.4194}},
+	{"San Jose", "USA", Point{37.3382, -121.8863}},
+	{"Seattle", "USA", Point{47.6062, -122.3321}},
+}
+
+// aliases maps former and alternative spellings to gazetteer names
+var aliases = map[string]string{
+	"trivandrum":     "Thiruvananthapuram",
+	"quilon":         "Kollam",
+	"alleppey":       "Alappuzha",
+	"painavu":        "Idukki",
+	"cochin":         "Kochi",
+	"trichur":        "Thrissur",
+	"palghat":        "Palakkad",
+	"calicut":        "Kozhikode",
+	"kalpetta":       "Wayanad",
+	"cannanore":      "Kannur",
+	"kasargod":       "Kasaragod",
+	"tellicherry":    "Thalassery",
+	"badagara":       "Vadakara",
+	"quilandy":       "Koyilandy",
+	"sultanbathery":  "Sulthan Bathery",
+	"bangalore":      "Bengaluru",
+	"madras":         "Chennai",
+	"bombay":         "Mumbai",
+	"newdelhi":       "Delhi",
+	"mangalore":      "Mangaluru",
+	"mysore":         "Mysuru",
+	"rak":            "Ras Al Khaimah",
+	"mecca":          "Makkah",
+	"medina":         "Madinah",
+	"khobar":         "Al Khobar",
+	"kuwait":         "Kuwait City",
+	"nyc":            "New York",
+	"newyorkcity":    "New York",
+	"washingtondc":   "Washington",
+	"sanfranciscoca": "San Francisco",
+}
+
+// index maps normalized names to places
+var index = func() map[string]Place {
+	m := make(map[string]Place, len(places)+len(aliases))
+	for _, p := range places {
+		m[normalize(p.Name)] = p
+	}
+	for alias, name := range aliases {
+		m[alias] = m[normalize(name)]
+	}
+	return m
+}()
+
+// Lookup finds a place by name, ignoring case, spaces and punctuation and
+// accepting common alternative spellings such as Calicut or Trivandrum
+func Lookup(name string) (Place, bool) {
+	p, ok := index[normalize(name)]
+	return p, ok
+}
+
+// LookupResidence finds where a member lives: the residence city when one is given,
+// otherwise the home district, which is all that profiles predating residence cities have
+func LookupResidence(city, homeDistrict string) (Place, bool) {
+	if city != "" {
+		return Lookup(city)
+	}
+	return Lookup(homeDistrict)
+}
+
+// Places returns all gazetteer places sorted by country and name
+func Places() []Place {
+	list := make([]Place, len(places))
+	copy(list, places)
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Country != list[j].Country {
+			return list[i].Country < list[j].Country
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// normalize lower-cases a name and drops everything but letters
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package geo
+
+import "math"
+
+// earthRadiusKm is the mean radius of the Earth used for distance calculations
+const earthRadiusKm = 6371.0
+
+// Point is a geographic coordinate in decimal degrees
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Valid reports whether the point is within the valid coordinate range
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// DistanceKm returns the great-circle distance between two points using the haversine formula
+func DistanceKm(a, b Point) float64 {
+	dLat := toRadians(b.Lat - a.Lat)
+	dLng := toRadians(b.Lng - a.Lng)
+
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(toRadians(a.Lat))*math.Cos(toRadians(b.Lat))*math.Pow(math.Sin(dLng/2), 2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// BoundingBox is a latitude/longitude rectangle
+type BoundingBox struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+	// WrapsLng is set when the box crosses the antimeridian; the longitude range is then not usable
+	WrapsLng bool
+}
+
+// BoundingBoxAround returns a box that contains every point within radiusKm of center.
+// It is used as a cheap prefilter before the exact haversine distance check.
+func BoundingBoxAround(center Point, radiusKm float64) BoundingBox {
+	latDelta := radiusKm / earthRadiusKm * 180 / math.Pi
+
+	box := BoundingBox{
+		MinLat: math.Max(center.Lat-latDelta, -90),
+		MaxLat: math.Min(center.Lat+latDelta, 90),
+	}
+
+	// Near the poles every longitude can be within range
+	cosLat := math.Cos(toRadians(center.Lat))
+	if box.MinLat == -90 || box.MaxLat == 90 || cosLat < 1e-9 {
+		box.MinLng, box.MaxLng = -180, 180
+		return box
+	}
+
+	lngDelta := latDelta / cosLat
+	box.MinLng = center.Lng - lngDelta
+	box.MaxLng = center.Lng + lngDelta
+	if box.MinLng < -180 || box.MaxLng > 180 {
+		box.WrapsLng = true
+	}
+
+	return box
+}
+
+// toRadians converts degrees to radians
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// AccountErasureHandler handles HTTP requests for erasing accounts
+type AccountErasureHandler struct {
+	erasureService service.AccountErasureService
+	logger         *logger.Logger
+}
+
+// NewAccountErasureHandler creates a new account erasure handler
+func NewAccountErasureHandler(erasureService service.AccountErasureService, logger *logger.Logger) *AccountErasureHandler {
+	return &AccountErasureHandler{
+		erasureService: erasureService,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the routes for erasing the user's account
+func (h *AccountErasureHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// POST /user/account/erasure - Request the erasure of the account
+	router.POST("/account/erasure", h.RequestErasure)
+
+	// GET /user/account/erasure - Follow the erasure of the account and get its receipt
+	router.GET("/account/erasure", h.GetErasure)
+}
+
+// RegisterAdminRoutes registers the routes for auditing erasures
+func (h *AccountErasureHandler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	// GET /admin/erasures/receipts/verify - Check that no erasure receipt was tampered with
+	router.GET("/erasures/receipts/verify", h.VerifyReceipts)
+}
+
+// RequestErasure handles requesting the erasure of the user's account
+func (h *AccountErasureHandler) RequestErasure(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req dto.EraseAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		BadRequest(c, "Erasing the account must be confirmed", err)
+		return
+	}
+
+	erasure, err := h.erasureService.RequestErasure(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "RequestErasure")
+		return
+	}
+
+	Accepted(c, "Account erasure requested successfully", erasure)
+}
+
+// GetErasure handles following the erasure of the user's account
+func (h *AccountErasureHandler) GetErasure(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	erasure, err := h.erasureService.GetErasure(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "GetErasure")
+		return
+	}
+
+	Success(c, "Account erasure retrieved successfully", erasure)
+}
+
+// VerifyReceipts handles checking the erasure receipt chain
+func (h *AccountErasureHandler) VerifyReceipts(c *gin.Context) {
+	chain, err := h.erasureService.VerifyReceipts(c.Request.Context())
+	if err != nil {
+		HandleServiceError(c, err, "VerifyReceipts")
+		return
+	}
+
+	Success(c, "Erasure receipts verified", chain)
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// actorFromRequest identifies the authenticated user and the profile of their account the
+// request acts as. It writes the error response and returns false when either is invalid.
+func actorFromRequest(c *gin.Context, log *logger.Logger) (service.Actor, bool) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		log.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return service.Actor{}, false
+	}
+
+	profileID, profileCode, err := middleware.GetActingProfile(c)
+	if err != nil {
+		BadRequest(c, "Invalid "+middleware.ActingProfileHeader+" header", err)
+		return service.Actor{}, false
+	}
+
+	return service.Actor{UserID: userID, ProfileID: profileID, ProfileCode: profileCode}, true
+}
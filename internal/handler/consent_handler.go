@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ConsentHandler handles HTTP requests for the policies users agree to
+type ConsentHandler struct {
+	consentService service.ConsentService
+	logger         *logger.Logger
+}
+
+// NewConsentHandler creates a new consent handler
+func NewConsentHandler(consentService service.ConsentService, logger *logger.Logger) *ConsentHandler {
+	return &ConsentHandler{
+		consentService: consentService,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the consent routes. They stay reachable without the
+// mandatory policies accepted, since they are how the user accepts them.
+func (h *ConsentHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/consents - Get where the user stands on each policy
+	router.GET("/consents", h.GetConsents)
+
+	// GET /user/consents/history - List every grant and withdrawal of the user
+	router.GET("/consents/history", h.ListConsentHistory)
+
+	// POST /user/consents - Agree to the current version of a policy
+	router.POST("/consents", h.GrantConsent)
+
+	// DELETE /user/consents/:policy - Withdraw the agreement to a policy
+	router.DELETE("/consents/:policy", h.WithdrawConsent)
+}
+
+// GetConsents handles getting where the user stands on each policy
+func (h *ConsentHandler) GetConsents(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	consents, err := h.consentService.GetConsents(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "GetConsents")
+		return
+	}
+
+	Success(c, "Consents retrieved successfully", consents)
+}
+
+// ListConsentHistory handles listing the user's consent ledger
+func (h *ConsentHandler) ListConsentHistory(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	history, err := h.consentService.ListConsentHistory(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "ListConsentHistory")
+		return
+	}
+
+	Success(c, "Consent history retrieved successfully", history)
+}
+
+// GrantConsent handles agreeing to a policy
+func (h *ConsentHandler) GrantConsent(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req dto.GrantConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	consent, err := h.consentService.GrantConsent(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "GrantConsent")
+		return
+	}
+
+	Success(c, "Consent recorded successfully", consent)
+}
+
+// WithdrawConsent handles withdrawing the agreement to a policy
+func (h *ConsentHandler) WithdrawConsent(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	consent, err := h.consentService.WithdrawConsent(c.Request.Context(), userID, model.ConsentPolicy(c.Param("policy")))
+	if err != nil {
+		HandleServiceError(c, err, "WithdrawConsent")
+		return
+	}
+
+	Success(c, "Consent withdrawn successfully", consent)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ContactHandler handles HTTP requests for contact details
+type ContactHandler struct {
+	contactService service.ContactService
+	profiles       service.ProfileResolver
+	logger         *logger.Logger
+}
+
+// NewContactHandler creates a new contact handler
+func NewContactHandler(
+	contactService service.ContactService,
+	profiles service.ProfileResolver,
+	logger *logger.Logger,
+) *ContactHandler {
+	return &ContactHandler{
+		contactService: contactService,
+		profiles:       profiles,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the contact details routes
+func (h *ContactHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/contact - Get own contact details
+	router.GET("/profile/contact", h.GetContactDetails)
+
+	// PUT /user/profile/contact - Set own contact details
+	router.PUT("/profile/contact", h.UpdateContactDetails)
+
+	// GET /user/profile/contact/quota - Get this month's contact reveal quota
+	router.GET("/profile/contact/quota", h.GetRevealQuota)
+
+	// GET /user/profile/:id/contact - Get the contact details of a profile the user manages
+	router.GET("/profile/:id/contact", h.GetManagedContactDetails)
+
+	// POST /user/profile/:id/contact/reveal - Reveal another profile's contact details
+	router.POST("/profile/:id/contact/reveal", h.RevealContact)
+}
+
+// GetContactDetails handles retrieving the user's own contact details
+func (h *ContactHandler) GetContactDetails(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	details, err := h.contactService.GetContactDetails(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "GetContactDetails")
+		return
+	}
+
+	Success(c, "Contact details retrieved successfully", details)
+}
+
+// GetManagedContactDetails handles retrieving the contact details of a profile the user manages
+func (h *ContactHandler) GetManagedContactDetails(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "GetManagedContactDetails", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	details, err := h.contactService.GetManagedContactDetails(c.Request.Context(), userID, profileID)
+	if err != nil {
+		HandleServiceError(c, err, "GetManagedContactDetails")
+		return
+	}
+
+	Success(c, "Contact details retrieved successfully", details)
+}
+
+// UpdateContactDetails handles setting the user's own contact details
+func (h *ContactHandler) UpdateContactDetails(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.ContactDetailsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	details, err := h.contactService.UpdateContactDetails(c.Request.Context(), actor, &req)
+	if err != nil {
+		HandleServiceError(c, err, "UpdateContactDetails")
+		return
+	}
+
+	Success(c, "Contact details updated successfully", details)
+}
+
+// GetRevealQuota handles retrieving the user's contact reveal quota
+func (h *ContactHandler) GetRevealQuota(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	quota, err := h.contactService.GetRevealQuota(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "GetRevealQuota")
+		return
+	}
+
+	Success(c, "Contact reveal quota retrieved successfully", quota)
+}
+
+// RevealContact handles revealing another profile's contact details
+func (h *ContactHandler) RevealContact(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "RevealContact", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	contact, err := h.contactService.RevealContact(c.Request.Context(), actor, profileID)
+	if err != nil {
+		HandleServiceError(c, err, "RevealContact")
+		return
+	}
+
+	Success(c, "Contact details revealed successfully", contact)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// DataExportHandler handles HTTP requests for exports of everything held about an account
+type DataExportHandler struct {
+	exportService service.DataExportService
+	logger        *logger.Logger
+}
+
+// NewDataExportHandler creates a new data export handler
+func NewDataExportHandler(exportService service.DataExportService, logger *logger.Logger) *DataExportHandler {
+	return &DataExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers the routes for requesting and following data exports
+func (h *DataExportHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// POST /user/data-exports - Request an export of the account's data
+	router.POST("/data-exports", h.RequestExport)
+
+	// GET /user/data-exports - List the account's exports
+	router.GET("/data-exports", h.ListExports)
+
+	// GET /user/data-exports/:id - Poll the status of an export, with a download token once it is ready
+	router.GET("/data-exports/:id", h.GetExport)
+}
+
+// RegisterPublicRoutes registers the archive download route. It authenticates requests
+// by the signed token in the path, so that the link can be opened outside the app.
+func (h *DataExportHandler) RegisterPublicRoutes(router *gin.RouterGroup) {
+	// GET /data-exports/:token/download - Download the archive of an export
+	router.GET("/data-exports/:token/download", h.DownloadExport)
+}
+
+// RequestExport handles requesting an export of the account's data
+func (h *DataExportHandler) RequestExport(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	export, err := h.exportService.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		var svcErr *service.ServiceError
+		if errors.As(err, &svcErr) && errors.Is(svcErr.Unwrap(), service.ErrDuplicate) {
+			Conflict(c, "An export of your account is already being prepared")
+			return
+		}
+
+		HandleServiceError(c, err, "RequestExport")
+		return
+	}
+
+	Accepted(c, "Data export requested successfully", export)
+}
+
+// ListExports handles listing the account's exports
+func (h *DataExportHandler) ListExports(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	exports, err := h.exportService.ListExports(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "ListExports")
+		return
+	}
+
+	// Ready exports come with download tokens
+	noStore(c)
+	Success(c, "Data exports retrieved successfully", exports)
+}
+
+// GetExport handles polling the status of an export
+func (h *DataExportHandler) GetExport(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid export ID", err)
+		return
+	}
+
+	export, err := h.exportService.GetExport(c.Request.Context(), userID, exportID)
+	if err != nil {
+		HandleServiceError(c, err, "GetExport")
+		return
+	}
+
+	// Ready exports come with download tokens
+	noStore(c)
+	Success(c, "Data export retrieved successfully", export)
+}
+
+// DownloadExport handles downloading the archive of an export
+func (h *DataExportHandler) DownloadExport(c *gin.Context) {
+	archive, err := h.exportService.DownloadExport(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		HandleServiceError(c, err, "DownloadExport")
+		return
+	}
+
+	noStore(c)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.Filename))
+	c.Data(http.StatusOK, "application/zip", archive.Content)
+}
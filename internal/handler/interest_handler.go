@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// InterestHandler handles HTTP requests for interests between profiles
+type InterestHandler struct {
+	interestService service.InterestService
+	profiles        service.ProfileResolver
+	logger          *logger.Logger
+}
+
+// NewInterestHandler creates a new interest handler
+func NewInterestHandler(
+	interestService service.InterestService,
+	profiles service.ProfileResolver,
+	logger *logger.Logger,
+) *InterestHandler {
+	return &InterestHandler{
+		interestService: interestService,
+		profiles:        profiles,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers the interest routes
+func (h *InterestHandler) RegisterRoutes(router *gin.RouterGroup) {
+	interestRoutes := router.Group("/interests")
+	{
+		// POST /user/interests - Send an interest to a profile
+		interestRoutes.POST("", h.SendInterest)
+
+		// GET /user/interests/received - List received interests
+		interestRoutes.GET("/received", h.ListReceivedInterests)
+
+		// GET /user/interests/sent - List sent interests
+		interestRoutes.GET("/sent", h.ListSentInterests)
+
+		// POST /user/interests/:id/respond - Accept or decline a received interest
+		interestRoutes.POST("/:id/respond", h.RespondToInterest)
+	}
+}
+
+// SendInterest handles sending an interest
+func (h *InterestHandler) SendInterest(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.SendInterestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "SendInterest", req.ProfileID)
+	if !ok {
+		return
+	}
+
+	interest, err := h.interestService.SendInterest(c.Request.Context(), actor, profileID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "SendInterest")
+		return
+	}
+
+	Created(c, "Interest sent successfully", interest)
+}
+
+// RespondToInterest handles accepting or declining an interest
+func (h *InterestHandler) RespondToInterest(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	interestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid interest ID", err)
+		return
+	}
+
+	var req dto.RespondInterestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	interest, err := h.interestService.RespondToInterest(c.Request.Context(), userID, interestID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "RespondToInterest")
+		return
+	}
+
+	Success(c, "Interest response recorded successfully", interest)
+}
+
+// ListReceivedInterests handles listing interests received by the user's profile
+func (h *InterestHandler) ListReceivedInterests(c *gin.Context) {
+	h.listInterests(c, "ListReceivedInterests", h.interestService.ListReceivedInterests)
+}
+
+// ListSentInterests handles listing interests sent by the user's profile
+func (h *InterestHandler) ListSentInterests(c *gin.Context) {
+	h.listInterests(c, "ListSentInterests", h.interestService.ListSentInterests)
+}
+
+// listInterests parses the status and pagination parameters shared by the interest lists
+func (h *InterestHandler) listInterests(
+	c *gin.Context,
+	operation string,
+	list func(ctx context.Context, actor service.Actor, status string, page, limit int) (*dto.InterestListResponse, error),
+) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	limit, err := queryInt(c, "limit", 10)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	interests, err := list(c.Request.Context(), actor, c.Query("status"), page, limit)
+	if err != nil {
+		HandleServiceError(c, err, operation)
+		return
+	}
+
+	Success(c, "Interests retrieved successfully", interests)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// maxWebhookBodyBytes limits the size of payment webhook bodies
+const maxWebhookBodyBytes = 1 << 20
+
+// MembershipHandler handles HTTP requests for membership plans and payments
+type MembershipHandler struct {
+	membershipService service.MembershipService
+	logger            *logger.Logger
+}
+
+// NewMembershipHandler creates a new membership handler
+func NewMembershipHandler(membershipService service.MembershipService, logger *logger.Logger) *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: membershipService,
+		logger:            logger,
+	}
+}
+
+// RegisterRoutes registers the membership routes
+func (h *MembershipHandler) RegisterRoutes(router *gin.RouterGroup) {
+	membershipRoutes := router.Group("/membership")
+	{
+		// GET /user/membership - Get the current plan and entitlements
+		membershipRoutes.GET("", h.GetMembership)
+
+		// GET /user/membership/plans - List plans that can be subscribed to
+		membershipRoutes.GET("/plans", h.ListPlans)
+
+		// POST /user/membership/subscribe - Start a subscription to a paid plan
+		membershipRoutes.POST("/subscribe", h.Subscribe)
+	}
+}
+
+// RegisterWebhookRoutes registers the payment provider webhook routes. They are
+// called by the provider, not by users, and authenticate requests by signature.
+func (h *MembershipHandler) RegisterWebhookRoutes(router *gin.RouterGroup) {
+	// POST /payments/webhook/:provider - Receive a payment provider event
+	router.POST("/payments/webhook/:provider", h.HandlePaymentWebhook)
+}
+
+// GetMembership handles retrieving the user's current plan
+func (h *MembershipHandler) GetMembership(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	membership, err := h.membershipService.GetMembership(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "GetMembership")
+		return
+	}
+
+	Success(c, "Membership retrieved successfully", membership)
+}
+
+// ListPlans handles listing membership plans
+func (h *MembershipHandler) ListPlans(c *gin.Context) {
+	plans, err := h.membershipService.ListPlans(c.Request.Context())
+	if err != nil {
+		HandleServiceError(c, err, "ListPlans")
+		return
+	}
+
+	Success(c, "Plans retrieved successfully", plans)
+}
+
+// Subscribe handles starting a subscription
+func (h *MembershipHandler) Subscribe(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req dto.SubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	checkout, err := h.membershipService.Subscribe(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "Subscribe")
+		return
+	}
+
+	Created(c, "Subscription started, complete the payment to activate it", checkout)
+}
+
+// HandlePaymentWebhook handles an event posted by a payment provider
+func (h *MembershipHandler) HandlePaymentWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	err = h.membershipService.HandlePaymentWebhook(c.Request.Context(), c.Param("provider"), c.Request.Header, body)
+	if err != nil {
+		HandleServiceError(c, err, "HandlePaymentWebhook")
+		return
+	}
+
+	Success(c, "Event processed", nil)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// PartnerPreferenceHandler handles HTTP requests for partner preferences
+type PartnerPreferenceHandler struct {
+	preferenceService service.PartnerPreferenceService
+	logger            *logger.Logger
+}
+
+// NewPartnerPreferenceHandler creates a new partner preference handler
+func NewPartnerPreferenceHandler(preferenceService service.PartnerPreferenceService, logger *logger.Logger) *PartnerPreferenceHandler {
+	return &PartnerPreferenceHandler{
+		preferenceService: preferenceService,
+		logger:            logger,
+	}
+}
+
+// RegisterRoutes registers the partner preference routes
+func (h *PartnerPreferenceHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/preferences - Get partner preferences
+	router.GET("/profile/preferences", h.GetPreferences)
+
+	// PUT /user/profile/preferences - Replace partner preferences
+	router.PUT("/profile/preferences", h.UpdatePreferences)
+}
+
+// GetPreferences handles retrieving the user's partner preferences
+func (h *PartnerPreferenceHandler) GetPreferences(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.preferenceService.GetPreferences(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "GetPreferences")
+		return
+	}
+
+	Success(c, "Partner preferences retrieved successfully", preferences)
+}
+
+// UpdatePreferences handles replacing the user's partner preferences
+func (h *PartnerPreferenceHandler) UpdatePreferences(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.PartnerPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	preferences, err := h.preferenceService.UpdatePreferences(c.Request.Context(), actor, &req)
+	if err != nil {
+		HandleServiceError(c, err, "UpdatePreferences")
+		return
+	}
+
+	Success(c, "Partner preferences updated successfully", preferences)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ProfileBlockHandler handles HTTP requests for blocking profiles
+type ProfileBlockHandler struct {
+	blockService service.ProfileBlockService
+	profiles     service.ProfileResolver
+	logger       *logger.Logger
+}
+
+// NewProfileBlockHandler creates a new profile block handler
+func NewProfileBlockHandler(
+	blockService service.ProfileBlockService,
+	profiles service.ProfileResolver,
+	logger *logger.Logger,
+) *ProfileBlockHandler {
+	return &ProfileBlockHandler{
+		blockService: blockService,
+		profiles:     profiles,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers the profile block routes
+func (h *ProfileBlockHandler) RegisterRoutes(router *gin.RouterGroup) {
+	blockRoutes := router.Group("/blocks")
+	{
+		// POST /user/blocks - Block a profile
+		blockRoutes.POST("", h.BlockProfile)
+
+		// GET /user/blocks - List blocked profiles
+		blockRoutes.GET("", h.ListBlockedProfiles)
+
+		// DELETE /user/blocks/:profile_id - Unblock a profile
+		blockRoutes.DELETE("/:profile_id", h.UnblockProfile)
+	}
+}
+
+// BlockProfile handles blocking a profile
+func (h *ProfileBlockHandler) BlockProfile(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.BlockProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "BlockProfile", req.ProfileID)
+	if !ok {
+		return
+	}
+
+	if err := h.blockService.BlockProfile(c.Request.Context(), actor, profileID); err != nil {
+		HandleServiceError(c, err, "BlockProfile")
+		return
+	}
+
+	Success(c, "Profile blocked successfully", nil)
+}
+
+// ListBlockedProfiles handles listing the user's blocked profiles
+func (h *ProfileBlockHandler) ListBlockedProfiles(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	blocks, err := h.blockService.ListBlockedProfiles(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "ListBlockedProfiles")
+		return
+	}
+
+	Success(c, "Blocked profiles retrieved successfully", blocks)
+}
+
+// UnblockProfile handles removing a block
+func (h *ProfileBlockHandler) UnblockProfile(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "UnblockProfile", c.Param("profile_id"))
+	if !ok {
+		return
+	}
+
+	if err := h.blockService.UnblockProfile(c.Request.Context(), actor, profileID); err != nil {
+		HandleServiceError(c, err, "UnblockProfile")
+		return
+	}
+
+	Success(c, "Profile unblocked successfully", nil)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+)
+
+// ProfileBoostHandler handles HTTP requests for profile boosts
+type ProfileBoostHandler struct {
+	boostService service.ProfileBoostService
+	logger       *logger.Logger
+}
+
+// NewProfileBoostHandler creates a new profile boost handler
+func NewProfileBoostHandler(boostService service.ProfileBoostService, logger *logger.Logger) *ProfileBoostHandler {
+	return &ProfileBoostHandler{
+		boostService: boostService,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers the profile boost routes
+func (h *ProfileBoostHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// POST /user/profile/boosts - Boost the user's profile in search results
+	router.POST("/profile/boosts", h.StartBoost)
+
+	// GET /user/profile/boosts - List the user's boosts with their impressions and clicks
+	router.GET("/profile/boosts", h.ListBoosts)
+}
+
+// StartBoost handles boosting the user's profile
+func (h *ProfileBoostHandler) StartBoost(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	boost, err := h.boostService.StartBoost(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "StartBoost")
+		return
+	}
+
+	Created(c, "Profile boosted successfully", boost)
+}
+
+// ListBoosts handles listing the user's profile boosts
+func (h *ProfileBoostHandler) ListBoosts(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	boosts, err := h.boostService.ListBoosts(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "ListBoosts")
+		return
+	}
+
+	Success(c, "Profile boosts retrieved successfully", boosts)
+}
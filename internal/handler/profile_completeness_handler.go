@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+)
+
+// ProfileCompletenessHandler handles HTTP requests for the user's own profile and its completeness
+type ProfileCompletenessHandler struct {
+	completenessService service.ProfileCompletenessService
+	logger              *logger.Logger
+}
+
+// NewProfileCompletenessHandler creates a new profile completeness handler
+func NewProfileCompletenessHandler(completenessService service.ProfileCompletenessService, logger *logger.Logger) *ProfileCompletenessHandler {
+	return &ProfileCompletenessHandler{
+		completenessService: completenessService,
+		logger:              logger,
+	}
+}
+
+// RegisterRoutes registers the profile completeness routes
+func (h *ProfileCompletenessHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/me - Get the user's profile with its completeness and onboarding checklist
+	router.GET("/profile/me", h.GetMyProfile)
+}
+
+// GetMyProfile handles retrieving the user's profile with its completeness
+func (h *ProfileCompletenessHandler) GetMyProfile(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	profile, err := h.completenessService.GetMyProfile(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "GetMyProfile")
+		return
+	}
+
+	Success(c, "Profile retrieved successfully", profile)
+}
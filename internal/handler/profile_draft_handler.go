@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ProfileDraftHandler handles HTTP requests for filling in a profile step by step
+type ProfileDraftHandler struct {
+	draftService service.ProfileDraftService
+	logger       *logger.Logger
+}
+
+// NewProfileDraftHandler creates a new profile draft handler
+func NewProfileDraftHandler(draftService service.ProfileDraftService, logger *logger.Logger) *ProfileDraftHandler {
+	return &ProfileDraftHandler{
+		draftService: draftService,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers the profile draft routes
+func (h *ProfileDraftHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/draft - Get the user's profile draft and the step to resume from
+	router.GET("/profile/draft", h.GetDraft)
+
+	// PUT /user/profile/draft/:step - Save one step (basic, physical, location, religious, family) of the draft
+	router.PUT("/profile/draft/:step", h.SaveDraftStep)
+
+	// POST /user/profile/draft/submit - Create the profile from the completed draft
+	router.POST("/profile/draft/submit", h.SubmitDraft)
+
+	// DELETE /user/profile/draft - Discard the user's profile draft
+	router.DELETE("/profile/draft", h.DiscardDraft)
+}
+
+// GetDraft handles retrieving the user's profile draft
+func (h *ProfileDraftHandler) GetDraft(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	draft, err := h.draftService.GetDraft(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "GetDraft")
+		return
+	}
+
+	Success(c, "Profile draft retrieved successfully", draft)
+}
+
+// SaveDraftStep handles saving one step of the user's profile draft
+func (h *ProfileDraftHandler) SaveDraftStep(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	req, ok := dto.NewDraftStepRequest(c.Param("step"))
+	if !ok {
+		BadRequest(c, "Invalid draft step", fmt.Errorf("unknown step %q", c.Param("step")))
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.String("step", c.Param("step")),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	draft, err := h.draftService.SaveDraftStep(c.Request.Context(), userID, req)
+	if err != nil {
+		HandleServiceError(c, err, "SaveDraftStep")
+		return
+	}
+
+	Success(c, "Profile draft saved successfully", draft)
+}
+
+// SubmitDraft handles creating a profile from the user's completed draft
+func (h *ProfileDraftHandler) SubmitDraft(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profile, err := h.draftService.SubmitDraft(c.Request.Context(), userID)
+	if err != nil {
+		var svcErr *service.ServiceError
+		if errors.As(err, &svcErr) && errors.Is(svcErr.Unwrap(), service.ErrDuplicate) {
+			Conflict(c, "A profile of your own already exists for this account")
+			return
+		}
+
+		HandleServiceError(c, err, "SubmitDraft")
+		return
+	}
+
+	Created(c, "Profile created successfully", profile)
+}
+
+// DiscardDraft handles deleting the user's profile draft
+func (h *ProfileDraftHandler) DiscardDraft(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	if err := h.draftService.DiscardDraft(c.Request.Context(), userID); err != nil {
+		HandleServiceError(c, err, "DiscardDraft")
+		return
+	}
+
+	Success(c, "Profile draft discarded successfully", nil)
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ProfileManagerHandler handles HTTP requests for delegated profile managers
+type ProfileManagerHandler struct {
+	managerService service.ProfileManagerService
+	logger         *logger.Logger
+}
+
+// NewProfileManagerHandler creates a new profile manager handler
+func NewProfileManagerHandler(managerService service.ProfileManagerService, logger *logger.Logger) *ProfileManagerHandler {
+	return &ProfileManagerHandler{
+		managerService: managerService,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the profile manager routes
+func (h *ProfileManagerHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// POST /user/profile/managers - Invite a user to manage the user's profile
+	router.POST("/profile/managers", h.InviteManager)
+
+	// GET /user/profile/managers - List the managers of the user's profile
+	router.GET("/profile/managers", h.ListManagers)
+
+	// PUT /user/profile/managers/:id - Change the scopes of a manager
+	router.PUT("/profile/managers/:id", h.UpdateManagerScopes)
+
+	// DELETE /user/profile/managers/:id - Revoke a manager, or step down as one
+	router.DELETE("/profile/managers/:id", h.RevokeManager)
+
+	// GET /user/profile/managed - List the profiles the user manages or is invited to manage
+	router.GET("/profile/managed", h.ListManagedProfiles)
+
+	// POST /user/profile/managed/:id/accept - Accept an invitation to manage a profile
+	router.POST("/profile/managed/:id/accept", h.AcceptInvitation)
+}
+
+// InviteManager handles inviting a user to manage the user's profile
+func (h *ProfileManagerHandler) InviteManager(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.InviteProfileManagerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	manager, err := h.managerService.InviteManager(c.Request.Context(), actor, &req)
+	if err != nil {
+		HandleServiceError(c, err, "InviteManager")
+		return
+	}
+
+	Created(c, "Manager invited successfully", manager)
+}
+
+// ListManagers handles listing the managers of the user's profile
+func (h *ProfileManagerHandler) ListManagers(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	managers, err := h.managerService.ListManagers(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "ListManagers")
+		return
+	}
+
+	Success(c, "Profile managers retrieved successfully", managers)
+}
+
+// UpdateManagerScopes handles changing the scopes of a manager of the user's profile
+func (h *ProfileManagerHandler) UpdateManagerScopes(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	managerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid manager ID", err)
+		return
+	}
+
+	var req dto.UpdateProfileManagerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	manager, err := h.managerService.UpdateManagerScopes(c.Request.Context(), actor, managerID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "UpdateManagerScopes")
+		return
+	}
+
+	Success(c, "Profile manager updated successfully", manager)
+}
+
+// RevokeManager handles revoking a manager of the user's profile, or stepping down as one
+func (h *ProfileManagerHandler) RevokeManager(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	managerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid manager ID", err)
+		return
+	}
+
+	if err := h.managerService.RevokeManager(c.Request.Context(), userID, managerID); err != nil {
+		HandleServiceError(c, err, "RevokeManager")
+		return
+	}
+
+	Success(c, "Profile manager revoked successfully", nil)
+}
+
+// ListManagedProfiles handles listing the profiles the user manages or is invited to manage
+func (h *ProfileManagerHandler) ListManagedProfiles(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profiles, err := h.managerService.ListManagedProfiles(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "ListManagedProfiles")
+		return
+	}
+
+	Success(c, "Managed profiles retrieved successfully", profiles)
+}
+
+// AcceptInvitation handles accepting an invitation to manage a profile
+func (h *ProfileManagerHandler) AcceptInvitation(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	managerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid invitation ID", err)
+		return
+	}
+
+	profile, err := h.managerService.AcceptInvitation(c.Request.Context(), userID, managerID)
+	if err != nil {
+		HandleServiceError(c, err, "AcceptInvitation")
+		return
+	}
+
+	Success(c, "Invitation accepted successfully", profile)
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+)
+
+// profileIDFromRef resolves a profile named in a request by its ID or its public code.
+// It writes the error response and returns false when the reference is invalid.
+func profileIDFromRef(c *gin.Context, resolver service.ProfileResolver, operation, ref string) (uuid.UUID, bool) {
+	profileID, err := resolver.ResolveProfileRef(c.Request.Context(), ref)
+	if err != nil {
+		HandleServiceError(c, err, operation)
+		return uuid.Nil, false
+	}
+
+	return profileID, true
+}
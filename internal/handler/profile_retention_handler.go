@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ProfileRetentionHandler handles HTTP requests for restoring deleted profiles
+type ProfileRetentionHandler struct {
+	retentionService service.ProfileRetentionService
+	logger           *logger.Logger
+}
+
+// NewProfileRetentionHandler creates a new profile retention handler
+func NewProfileRetentionHandler(retentionService service.ProfileRetentionService, logger *logger.Logger) *ProfileRetentionHandler {
+	return &ProfileRetentionHandler{
+		retentionService: retentionService,
+		logger:           logger,
+	}
+}
+
+// RegisterRoutes registers the deleted profile routes
+func (h *ProfileRetentionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/deleted - List the account's deleted profiles that can still be restored
+	router.GET("/profile/deleted", h.ListDeletedProfiles)
+
+	// POST /user/profile/:id/restore - Restore a deleted profile within the restore window
+	router.POST("/profile/:id/restore", h.RestoreProfile)
+}
+
+// ListDeletedProfiles handles listing the account's restorable deleted profiles
+func (h *ProfileRetentionHandler) ListDeletedProfiles(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profiles, err := h.retentionService.ListDeletedProfiles(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "ListDeletedProfiles")
+		return
+	}
+
+	Success(c, "Deleted profiles retrieved successfully", profiles)
+}
+
+// RestoreProfile handles restoring a deleted profile. Deleted profiles are named by
+// their ID, as their public code no longer resolves.
+func (h *ProfileRetentionHandler) RestoreProfile(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid profile ID", err)
+		return
+	}
+
+	profile, err := h.retentionService.RestoreProfile(c.Request.Context(), userID, profileID)
+	if err != nil {
+		var svcErr *service.ServiceError
+		if errors.As(err, &svcErr) && errors.Is(svcErr.Unwrap(), service.ErrDuplicate) {
+			Conflict(c, "The account already has another profile of its own")
+			return
+		}
+
+		HandleServiceError(c, err, "RestoreProfile")
+		return
+	}
+
+	Success(c, "Profile restored successfully", profile)
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ProfileRevisionHandler handles HTTP requests for the change history of profiles
+type ProfileRevisionHandler struct {
+	revisionService service.ProfileRevisionService
+	profiles        service.ProfileResolver
+	logger          *logger.Logger
+}
+
+// NewProfileRevisionHandler creates a new profile revision handler
+func NewProfileRevisionHandler(
+	revisionService service.ProfileRevisionService,
+	profiles service.ProfileResolver,
+	logger *logger.Logger,
+) *ProfileRevisionHandler {
+	return &ProfileRevisionHandler{
+		revisionService: revisionService,
+		profiles:        profiles,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers the profile history routes of profile owners and managers
+func (h *ProfileRevisionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/:id/history - List the changes made to a profile the user owns or manages
+	router.GET("/profile/:id/history", h.GetProfileHistory)
+}
+
+// RegisterAdminRoutes registers the profile history routes of admins
+func (h *ProfileRevisionHandler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	// GET /admin/profiles/revisions/flagged - List changes flagged as suspicious
+	router.GET("/profiles/revisions/flagged", h.ListFlaggedRevisions)
+
+	// GET /admin/profiles/:id/history - List the changes made to any profile, with their flags
+	router.GET("/profiles/:id/history", h.GetProfileHistoryAsAdmin)
+
+	// POST /admin/profiles/:id/revisions/:revisionId/revert - Restore a profile to its state after a revision
+	router.POST("/profiles/:id/revisions/:revisionId/revert", h.RevertProfile)
+}
+
+// GetProfileHistory handles listing the changes made to a profile the user owns or manages
+func (h *ProfileRevisionHandler) GetProfileHistory(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "GetProfileHistory", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	page, limit, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.revisionService.GetProfileHistory(c.Request.Context(), userID, profileID, page, limit)
+	if err != nil {
+		HandleServiceError(c, err, "GetProfileHistory")
+		return
+	}
+
+	Success(c, "Profile history retrieved successfully", history)
+}
+
+// GetProfileHistoryAsAdmin handles listing the changes made to any profile
+func (h *ProfileRevisionHandler) GetProfileHistoryAsAdmin(c *gin.Context) {
+	profileID, ok := profileIDFromRef(c, h.profiles, "GetProfileHistoryAsAdmin", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	page, limit, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.revisionService.GetProfileHistoryAsAdmin(c.Request.Context(), profileID, page, limit)
+	if err != nil {
+		HandleServiceError(c, err, "GetProfileHistoryAsAdmin")
+		return
+	}
+
+	Success(c, "Profile history retrieved successfully", history)
+}
+
+// ListFlaggedRevisions handles listing the changes flagged as suspicious
+func (h *ProfileRevisionHandler) ListFlaggedRevisions(c *gin.Context) {
+	page, limit, ok := pagination(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.revisionService.ListFlaggedRevisions(c.Request.Context(), page, limit)
+	if err != nil {
+		HandleServiceError(c, err, "ListFlaggedRevisions")
+		return
+	}
+
+	Success(c, "Flagged profile changes retrieved successfully", revisions)
+}
+
+// RevertProfile handles restoring a profile to its state after one of its revisions
+func (h *ProfileRevisionHandler) RevertProfile(c *gin.Context) {
+	adminID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "RevertProfile", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	revisionID, err := uuid.Parse(c.Param("revisionId"))
+	if err != nil {
+		BadRequest(c, "Invalid revision ID", err)
+		return
+	}
+
+	profile, err := h.revisionService.RevertProfile(c.Request.Context(), adminID, profileID, revisionID)
+	if err != nil {
+		HandleServiceError(c, err, "RevertProfile")
+		return
+	}
+
+	Success(c, "Profile reverted successfully", profile)
+}
+
+// pagination parses the page and limit query parameters, responding with an error when they are invalid
+func pagination(c *gin.Context) (page, limit int, ok bool) {
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return 0, 0, false
+	}
+
+	limit, err = queryInt(c, "limit", 10)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return 0, 0, false
+	}
+
+	return page, limit, true
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ProfileShareHandler handles HTTP requests for privacy settings, biodata and share links
+type ProfileShareHandler struct {
+	shareService service.ProfileShareService
+	profiles     service.ProfileResolver
+	logger       *logger.Logger
+}
+
+// NewProfileShareHandler creates a new profile share handler
+func NewProfileShareHandler(
+	shareService service.ProfileShareService,
+	profiles service.ProfileResolver,
+	logger *logger.Logger,
+) *ProfileShareHandler {
+	return &ProfileShareHandler{
+		shareService: shareService,
+		profiles:     profiles,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers the privacy settings, biodata and share link routes
+func (h *ProfileShareHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/privacy - Get what the user's profile shows on its biodata and share links
+	router.GET("/profile/privacy", h.GetPrivacySettings)
+
+	// PUT /user/profile/privacy - Change what the user's profile shows on its biodata and share links
+	router.PUT("/profile/privacy", h.UpdatePrivacySettings)
+
+	// GET /user/profile/:id/biodata - Download the printable biodata of a profile
+	router.GET("/profile/:id/biodata", h.GetBiodata)
+
+	// POST /user/profile/shares - Mint a public share link to the user's profile
+	router.POST("/profile/shares", h.CreateShareLink)
+
+	// GET /user/profile/shares - List the share links of the user's profile
+	router.GET("/profile/shares", h.ListShareLinks)
+
+	// DELETE /user/profile/shares/:id - Revoke a share link
+	router.DELETE("/profile/shares/:id", h.RevokeShareLink)
+}
+
+// RegisterPublicRoutes registers the share link routes. They are opened by people
+// without an account and authenticate requests by the signed token in the path.
+func (h *ProfileShareHandler) RegisterPublicRoutes(router *gin.RouterGroup) {
+	// GET /shared/profiles/:token - View the profile snapshot behind a share link
+	router.GET("/shared/profiles/:token", h.GetSharedProfile)
+
+	// GET /shared/profiles/:token/biodata - Download the biodata of the snapshot behind a share link
+	router.GET("/shared/profiles/:token/biodata", h.GetSharedBiodata)
+}
+
+// GetPrivacySettings handles retrieving the privacy settings of the user's profile
+func (h *ProfileShareHandler) GetPrivacySettings(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	settings, err := h.shareService.GetPrivacySettings(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "GetPrivacySettings")
+		return
+	}
+
+	Success(c, "Privacy settings retrieved successfully", settings)
+}
+
+// UpdatePrivacySettings handles changing the privacy settings of the user's profile
+func (h *ProfileShareHandler) UpdatePrivacySettings(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateProfilePrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	settings, err := h.shareService.UpdatePrivacySettings(c.Request.Context(), actor, &req)
+	if err != nil {
+		HandleServiceError(c, err, "UpdatePrivacySettings")
+		return
+	}
+
+	Success(c, "Privacy settings updated successfully", settings)
+}
+
+// GetBiodata handles downloading the printable biodata of a profile
+func (h *ProfileShareHandler) GetBiodata(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "GetBiodata", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	document, err := h.shareService.GetBiodata(c.Request.Context(), profileID, actor)
+	if err != nil {
+		HandleServiceError(c, err, "GetBiodata")
+		return
+	}
+
+	sendBiodata(c, document)
+}
+
+// CreateShareLink handles minting a public share link to the user's profile
+func (h *ProfileShareHandler) CreateShareLink(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	link, err := h.shareService.CreateShareLink(c.Request.Context(), actor, &req)
+	if err != nil {
+		HandleServiceError(c, err, "CreateShareLink")
+		return
+	}
+
+	Created(c, "Share link created successfully", link)
+}
+
+// ListShareLinks handles listing the share links of the user's profile
+func (h *ProfileShareHandler) ListShareLinks(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	links, err := h.shareService.ListShareLinks(c.Request.Context(), actor)
+	if err != nil {
+		HandleServiceError(c, err, "ListShareLinks")
+		return
+	}
+
+	Success(c, "Share links retrieved successfully", links)
+}
+
+// RevokeShareLink handles revoking a share link
+func (h *ProfileShareHandler) RevokeShareLink(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	linkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid share link ID", err)
+		return
+	}
+
+	if err := h.shareService.RevokeShareLink(c.Request.Context(), userID, linkID); err != nil {
+		HandleServiceError(c, err, "RevokeShareLink")
+		return
+	}
+
+	Success(c, "Share link revoked successfully", nil)
+}
+
+// GetSharedProfile handles viewing the profile snapshot behind a share link
+func (h *ProfileShareHandler) GetSharedProfile(c *gin.Context) {
+	profile, err := h.shareService.GetSharedProfile(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		HandleServiceError(c, err, "GetSharedProfile")
+		return
+	}
+
+	noStore(c)
+	Success(c, "Shared profile retrieved successfully", profile)
+}
+
+// GetSharedBiodata handles downloading the biodata of the snapshot behind a share link
+func (h *ProfileShareHandler) GetSharedBiodata(c *gin.Context) {
+	document, err := h.shareService.GetSharedBiodata(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		HandleServiceError(c, err, "GetSharedBiodata")
+		return
+	}
+
+	noStore(c)
+	sendBiodata(c, document)
+}
+
+// sendBiodata writes a biodata PDF to be opened in the browser, from where it can be printed
+func sendBiodata(c *gin.Context, document *dto.BiodataDocument) {
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", document.Filename))
+	c.Data(http.StatusOK, "application/pdf", document.Content)
+}
+
+// noStore keeps shared profiles out of caches and search engines, so revoking a link takes effect
+func noStore(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex")
+}
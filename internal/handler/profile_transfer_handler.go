@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// ProfileTransferHandler handles HTTP requests for profile ownership transfers
+type ProfileTransferHandler struct {
+	transferService service.ProfileTransferService
+	logger          *logger.Logger
+}
+
+// NewProfileTransferHandler creates a new profile transfer handler
+func NewProfileTransferHandler(transferService service.ProfileTransferService, logger *logger.Logger) *ProfileTransferHandler {
+	return &ProfileTransferHandler{
+		transferService: transferService,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers the profile transfer routes
+func (h *ProfileTransferHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// POST /user/profile/transfers - Offer the user's profile to another account
+	router.POST("/profile/transfers", h.InitiateTransfer)
+
+	// GET /user/profile/transfers - List the pending transfers the user has offered or been offered
+	router.GET("/profile/transfers", h.ListTransfers)
+
+	// POST /user/profile/transfers/accept - Take over a profile with a transfer token
+	router.POST("/profile/transfers/accept", h.AcceptTransfer)
+
+	// DELETE /user/profile/transfers/:id - Withdraw or decline a pending transfer
+	router.DELETE("/profile/transfers/:id", h.CancelTransfer)
+}
+
+// InitiateTransfer handles offering the user's profile to another account
+func (h *ProfileTransferHandler) InitiateTransfer(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var req dto.InitiateProfileTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	transfer, err := h.transferService.InitiateTransfer(c.Request.Context(), actor, &req)
+	if err != nil {
+		HandleServiceError(c, err, "InitiateTransfer")
+		return
+	}
+
+	Created(c, "Profile transfer started successfully", transfer)
+}
+
+// ListTransfers handles listing the pending transfers the user has offered or been offered
+func (h *ProfileTransferHandler) ListTransfers(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	transfers, err := h.transferService.ListTransfers(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "ListTransfers")
+		return
+	}
+
+	Success(c, "Profile transfers retrieved successfully", transfers)
+}
+
+// AcceptTransfer handles taking over a profile with a transfer token
+func (h *ProfileTransferHandler) AcceptTransfer(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req dto.AcceptProfileTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	profile, err := h.transferService.AcceptTransfer(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "AcceptTransfer")
+		return
+	}
+
+	Success(c, "Profile transferred successfully", profile)
+}
+
+// CancelTransfer handles withdrawing or declining a pending transfer
+func (h *ProfileTransferHandler) CancelTransfer(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid transfer ID", err)
+		return
+	}
+
+	if err := h.transferService.CancelTransfer(c.Request.Context(), userID, transferID); err != nil {
+		HandleServiceError(c, err, "CancelTransfer")
+		return
+	}
+
+	Success(c, "Profile transfer cancelled successfully", nil)
+}
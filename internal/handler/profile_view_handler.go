@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+)
+
+// ProfileViewHandler handles HTTP requests for profile views
+type ProfileViewHandler struct {
+	viewService service.ProfileViewService
+	entitler    service.Entitler
+	logger      *logger.Logger
+}
+
+// NewProfileViewHandler creates a new profile view handler
+func NewProfileViewHandler(viewService service.ProfileViewService, entitler service.Entitler, logger *logger.Logger) *ProfileViewHandler {
+	return &ProfileViewHandler{
+		viewService: viewService,
+		entitler:    entitler,
+		logger:      logger,
+	}
+}
+
+// RegisterRoutes registers the profile view routes
+func (h *ProfileViewHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/views - List who viewed the user's profile (premium)
+	router.GET("/profile/views",
+		middleware.RequireFeature(h.entitler, service.FeatureWhoViewedMe, h.logger),
+		h.ListProfileViewers)
+}
+
+// ListProfileViewers handles listing who viewed the user's profile
+func (h *ProfileViewHandler) ListProfileViewers(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	limit, err := queryInt(c, "limit", 10)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	viewers, err := h.viewService.ListProfileViewers(c.Request.Context(), actor, page, limit)
+	if err != nil {
+		HandleServiceError(c, err, "ListProfileViewers")
+		return
+	}
+
+	Success(c, "Profile viewers retrieved successfully", viewers)
+}
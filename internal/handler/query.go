@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// queryList returns the non-empty values of a repeated or comma-separated query parameter
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// queryBool parses an optional boolean query parameter
+func queryBool(c *gin.Context, key string) (*bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a boolean", key)
+	}
+	return &v, nil
+}
+
+// queryIntPtr parses an optional integer query parameter
+func queryIntPtr(c *gin.Context, key string) (*int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer", key)
+	}
+	return &v, nil
+}
+
+// queryInt parses an integer query parameter, returning def when it is absent
+func queryInt(c *gin.Context, key string, def int) (int, error) {
+	v, err := queryIntPtr(c, key)
+	if err != nil || v == nil {
+		return def, err
+	}
+	return *v, nil
+}
+
+// queryFloatPtr parses an optional decimal query parameter
+func queryFloatPtr(c *gin.Context, key string) (*float64, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", key)
+	}
+	return &v, nil
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// RecommendationHandler handles HTTP requests for daily match recommendations
+type RecommendationHandler struct {
+	recommendationService service.RecommendationService
+	logger                *logger.Logger
+}
+
+// NewRecommendationHandler creates a new recommendation handler
+func NewRecommendationHandler(recommendationService service.RecommendationService, logger *logger.Logger) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+		logger:                logger,
+	}
+}
+
+// RegisterRoutes registers the recommendation routes
+func (h *RecommendationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	recommendationRoutes := router.Group("/recommendations")
+	{
+		// GET /user/recommendations - List today's matches
+		recommendationRoutes.GET("", h.ListRecommendations)
+
+		// POST /user/recommendations/:id/feedback - Skip a match or mark it as not interested
+		recommendationRoutes.POST("/:id/feedback", h.GiveFeedback)
+	}
+}
+
+// ListRecommendations handles listing the user's latest recommendations
+func (h *RecommendationHandler) ListRecommendations(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	limit, err := queryInt(c, "limit", 10)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	recommendations, err := h.recommendationService.ListRecommendations(c.Request.Context(), actor, page, limit)
+	if err != nil {
+		HandleServiceError(c, err, "ListRecommendations")
+		return
+	}
+
+	Success(c, "Recommendations retrieved successfully", recommendations)
+}
+
+// GiveFeedback handles feedback on a recommendation
+func (h *RecommendationHandler) GiveFeedback(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	recommendationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid recommendation ID", err)
+		return
+	}
+
+	var req dto.RecommendationFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	if err := h.recommendationService.GiveFeedback(c.Request.Context(), actor, recommendationID, &req); err != nil {
+		HandleServiceError(c, err, "GiveFeedback")
+		return
+	}
+
+	Success(c, "Feedback recorded successfully", nil)
+}
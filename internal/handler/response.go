@@ -34,6 +34,15 @@ func Created(c *gin.Context, message string, data interface{}) {
 	})
 }
 
+// Accepted sends a response for a request that is carried out in the background
+func Accepted(c *gin.Context, message string, data interface{}) {
+	c.JSON(http.StatusAccepted, StandardResponse{
+		Status:  true,
+		Message: message,
+		Data:    data,
+	})
+}
+
 // BadRequest sends a 400 bad request response
 func BadRequest(c *gin.Context, message string, err error) {
 	var svcErr *service.ServiceError
@@ -107,6 +116,10 @@ func HandleServiceError(c *gin.Context, err error, operation string) {
 			Conflict(c, "Resource already exists")
 		case errors.Is(svcErr.Unwrap(), service.ErrUnauthorized):
 			Forbidden(c, "You don't have permission to perform this action")
+		case errors.Is(svcErr.Unwrap(), service.ErrQuotaExceeded):
+			Forbidden(c, "Quota exhausted for this period")
+		case errors.Is(svcErr.Unwrap(), service.ErrNotEntitled):
+			Forbidden(c, "Your membership plan doesn't include this feature")
 		default:
 			InternalServerError(c, "An unexpected error occurred")
 		}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// SavedSearchHandler handles HTTP requests for saved searches and their alerts
+type SavedSearchHandler struct {
+	savedSearchService service.SavedSearchService
+	logger             *logger.Logger
+}
+
+// NewSavedSearchHandler creates a new saved search handler
+func NewSavedSearchHandler(savedSearchService service.SavedSearchService, logger *logger.Logger) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+		logger:             logger,
+	}
+}
+
+// RegisterRoutes registers the saved search routes
+func (h *SavedSearchHandler) RegisterRoutes(router *gin.RouterGroup) {
+	searchRoutes := router.Group("/saved-searches")
+	{
+		// POST /user/saved-searches - Save a search
+		searchRoutes.POST("", h.CreateSavedSearch)
+
+		// GET /user/saved-searches - List saved searches
+		searchRoutes.GET("", h.ListSavedSearches)
+
+		// GET /user/saved-searches/:id/run - Run a saved search
+		searchRoutes.GET("/:id/run", h.RunSavedSearch)
+
+		// DELETE /user/saved-searches/:id - Delete a saved search
+		searchRoutes.DELETE("/:id", h.DeleteSavedSearch)
+	}
+
+	// GET /user/search-alerts - List new matches found for saved searches
+	router.GET("/search-alerts", h.ListAlerts)
+}
+
+// CreateSavedSearch handles saving a search
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req dto.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSavedSearch(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "CreateSavedSearch")
+		return
+	}
+
+	Created(c, "Search saved successfully", search)
+}
+
+// ListSavedSearches handles listing the user's saved searches
+func (h *SavedSearchHandler) ListSavedSearches(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	searches, err := h.savedSearchService.ListSavedSearches(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "ListSavedSearches")
+		return
+	}
+
+	Success(c, "Saved searches retrieved successfully", searches)
+}
+
+// RunSavedSearch handles running a saved search
+func (h *SavedSearchHandler) RunSavedSearch(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid saved search ID", err)
+		return
+	}
+
+	opts, err := parseSearchOptions(c)
+	if err != nil {
+		BadRequest(c, "Invalid search parameters", err)
+		return
+	}
+
+	response, err := h.savedSearchService.RunSavedSearch(c.Request.Context(), userID, searchID, opts)
+	if err != nil {
+		HandleServiceError(c, err, "RunSavedSearch")
+		return
+	}
+
+	Success(c, "Profiles retrieved successfully", response)
+}
+
+// DeleteSavedSearch handles deleting a saved search
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		BadRequest(c, "Invalid saved search ID", err)
+		return
+	}
+
+	if err := h.savedSearchService.DeleteSavedSearch(c.Request.Context(), userID, searchID); err != nil {
+		HandleServiceError(c, err, "DeleteSavedSearch")
+		return
+	}
+
+	Success(c, "Saved search deleted successfully", nil)
+}
+
+// ListAlerts handles listing the user's saved search alerts
+func (h *SavedSearchHandler) ListAlerts(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	limit, err := queryInt(c, "limit", 10)
+	if err != nil {
+		BadRequest(c, "Invalid pagination parameters", err)
+		return
+	}
+
+	alerts, err := h.savedSearchService.ListAlerts(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		HandleServiceError(c, err, "ListAlerts")
+		return
+	}
+
+	Success(c, "Search alerts retrieved successfully", alerts)
+}
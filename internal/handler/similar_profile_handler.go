@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+)
+
+// SimilarProfileHandler handles HTTP requests for similar profiles
+type SimilarProfileHandler struct {
+	similarService service.SimilarProfileService
+	profiles       service.ProfileResolver
+	logger         *logger.Logger
+}
+
+// NewSimilarProfileHandler creates a new similar profile handler
+func NewSimilarProfileHandler(
+	similarService service.SimilarProfileService,
+	profiles service.ProfileResolver,
+	logger *logger.Logger,
+) *SimilarProfileHandler {
+	return &SimilarProfileHandler{
+		similarService: similarService,
+		profiles:       profiles,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the similar profile routes
+func (h *SimilarProfileHandler) RegisterRoutes(router *gin.RouterGroup) {
+	// GET /user/profile/:id/similar - List members similar to a profile
+	router.GET("/profile/:id/similar", h.GetSimilarProfiles)
+}
+
+// GetSimilarProfiles handles listing profiles similar to a profile
+func (h *SimilarProfileHandler) GetSimilarProfiles(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profiles, "GetSimilarProfiles", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	limit, err := queryInt(c, "limit", 0)
+	if err != nil {
+		BadRequest(c, "Invalid limit", err)
+		return
+	}
+
+	profiles, err := h.similarService.GetSimilarProfiles(c.Request.Context(), profileID, actor, limit)
+	if err != nil {
+		HandleServiceError(c, err, "GetSimilarProfiles")
+		return
+	}
+
+	Success(c, "Similar profiles retrieved successfully", profiles)
+}
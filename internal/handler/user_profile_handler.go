@@ -2,10 +2,17 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/geo"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/middleware"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
 	"go.uber.org/zap"
@@ -32,6 +39,27 @@ func (h *UserProfileHandler) RegisterRoutes(router *gin.RouterGroup) {
 		// POST /user/profile - Create a user profile
 		profileRoutes.POST("", h.CreateProfile)
 
+		// GET /user/profile/search - Search profiles by filter criteria
+		profileRoutes.GET("/search", h.SearchProfiles)
+
+		// GET /user/profile/code/:code - Get a profile by its public code, e.g. QK-104523;
+		// ?boost_id= counts a click when opened from a boosted search result
+		profileRoutes.GET("/code/:code", h.GetProfileByCode)
+
+		// GET /user/profile/mine - List the profiles owned by the user's account
+		profileRoutes.GET("/mine", h.ListAccountProfiles)
+
+		// POST /user/profile/mine/:id/switch - Make one of the account's profiles the active one
+		profileRoutes.POST("/mine/:id/switch", h.SwitchProfile)
+
+		// GET /user/profile/places - List cities that can be used as residence and search location
+		profileRoutes.GET("/places", h.ListPlaces)
+
+		// PUT /user/profile/:id - Update a profile the user owns or manages
+		profileRoutes.PUT("/:id", h.UpdateProfile)
+
+		// DELETE /user/profile/:id - Delete a profile the user owns or manages
+		profileRoutes.DELETE("/:id", h.DeleteProfile)
 	}
 }
 
@@ -64,7 +92,7 @@ func (h *UserProfileHandler) CreateProfile(c *gin.Context) {
 		var svcErr *service.ServiceError
 		if err != nil {
 			if errors.As(err, &svcErr) && errors.Is(svcErr.Unwrap(), service.ErrDuplicate) {
-				Conflict(c, "A profile already exists for this user")
+				Conflict(c, "A profile of your own already exists for this account")
 				return
 			}
 		}
@@ -79,3 +107,283 @@ func (h *UserProfileHandler) CreateProfile(c *gin.Context) {
 		zap.String("profile_id", profile.ID.String()))
 	Created(c, "Profile created successfully", profile)
 }
+
+// GetProfileByCode handles retrieving a profile by its public code
+func (h *UserProfileHandler) GetProfileByCode(c *gin.Context) {
+	actor, ok := actorFromRequest(c, h.logger)
+	if !ok {
+		return
+	}
+
+	// Profiles opened from a boosted search result pass its boost ID along
+	var boostID uuid.UUID
+	if raw := c.Query("boost_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			BadRequest(c, "Invalid boost ID", err)
+			return
+		}
+		boostID = id
+	}
+
+	profile, err := h.profileService.GetProfileByCode(c.Request.Context(), c.Param("code"), actor, boostID)
+	if err != nil {
+		HandleServiceError(c, err, "GetProfileByCode")
+		return
+	}
+
+	Success(c, "Profile retrieved successfully", profile)
+}
+
+// ListAccountProfiles handles listing the profiles owned by the user's account
+func (h *UserProfileHandler) ListAccountProfiles(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profiles, err := h.profileService.ListAccountProfiles(c.Request.Context(), userID)
+	if err != nil {
+		HandleServiceError(c, err, "ListAccountProfiles")
+		return
+	}
+
+	Success(c, "Account profiles retrieved successfully", profiles)
+}
+
+// SwitchProfile handles making one of the account's profiles the active one
+func (h *UserProfileHandler) SwitchProfile(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profileService, "SwitchProfile", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	profile, err := h.profileService.SwitchProfile(c.Request.Context(), userID, profileID)
+	if err != nil {
+		HandleServiceError(c, err, "SwitchProfile")
+		return
+	}
+
+	Success(c, "Active profile switched successfully", profile)
+}
+
+// UpdateProfile handles updating a profile owned or managed by the user
+func (h *UserProfileHandler) UpdateProfile(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profileService, "UpdateProfile", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req dto.CreateUserProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	profile, err := h.profileService.UpdateProfile(c.Request.Context(), userID, profileID, &req)
+	if err != nil {
+		HandleServiceError(c, err, "UpdateProfile")
+		return
+	}
+
+	Success(c, "Profile updated successfully", profile)
+}
+
+// DeleteProfile handles deleting a profile owned or managed by the user
+func (h *UserProfileHandler) DeleteProfile(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	profileID, ok := profileIDFromRef(c, h.profileService, "DeleteProfile", c.Param("id"))
+	if !ok {
+		return
+	}
+
+	if err := h.profileService.DeleteProfile(c.Request.Context(), userID, profileID); err != nil {
+		HandleServiceError(c, err, "DeleteProfile")
+		return
+	}
+
+	Success(c, "Profile deleted successfully", nil)
+}
+
+// SearchProfiles handles profile search with filters passed as query parameters
+func (h *UserProfileHandler) SearchProfiles(c *gin.Context) {
+	userID, err := middleware.GetAuthenticatedUserID(c)
+	if err != nil {
+		h.logger.Warn("Failed to get authenticated user ID", zap.Error(err))
+		Unauthorized(c, "Authentication required")
+		return
+	}
+
+	filter, err := parseProfileFilter(c)
+	if err != nil {
+		BadRequest(c, "Invalid search parameters", err)
+		return
+	}
+
+	opts, err := parseSearchOptions(c)
+	if err != nil {
+		BadRequest(c, "Invalid search parameters", err)
+		return
+	}
+	opts.UserID = userID
+
+	response, err := h.profileService.SearchProfiles(c.Request.Context(), filter, opts)
+	if err != nil {
+		h.logger.Error("Failed to search profiles",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		HandleServiceError(c, err, "SearchProfiles")
+		return
+	}
+
+	Success(c, "Profiles retrieved successfully", response)
+}
+
+// parseSearchOptions reads pagination and search extras from the request query
+func parseSearchOptions(c *gin.Context) (service.SearchOptions, error) {
+	var opts service.SearchOptions
+	var err error
+
+	if opts.Page, err = queryInt(c, "page", 1); err != nil {
+		return opts, err
+	}
+	if opts.Limit, err = queryInt(c, "limit", 0); err != nil {
+		return opts, err
+	}
+
+	opts.Cursor = c.Query("cursor")
+
+	switch count := repository.CountMode(c.Query("count")); count {
+	case "", repository.CountExact, repository.CountEstimate, repository.CountNone:
+		opts.Count = count
+	default:
+		return opts, fmt.Errorf("count must be one of exact, estimate or none")
+	}
+
+	facets, err := queryBool(c, "facets")
+	if err != nil {
+		return opts, err
+	}
+	opts.Facets = facets != nil && *facets
+
+	return opts, nil
+}
+
+// defaultSearchRadiusKm is used when a location search doesn't specify radius_km
+const defaultSearchRadiusKm = 50.0
+
+// parseLocation resolves a search location given as a gazetteer place name or as "lat,lng"
+func parseLocation(value string) (geo.Point, error) {
+	if place, ok := geo.Lookup(value); ok {
+		return place.Point, nil
+	}
+
+	if parts := strings.Split(value, ","); len(parts) == 2 {
+		lat, latErr := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		lng, lngErr := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if latErr == nil && lngErr == nil {
+			return geo.Point{Lat: lat, Lng: lng}, nil
+		}
+	}
+
+	return geo.Point{}, fmt.Errorf("unknown location %q", value)
+}
+
+// ListPlaces handles listing the gazetteer places
+func (h *UserProfileHandler) ListPlaces(c *gin.Context) {
+	Success(c, "Places retrieved successfully", geo.Places())
+}
+
+// parseProfileFilter builds a repository.ProfileFilter from the request query.
+// List parameters accept repeated keys or comma-separated values.
+func parseProfileFilter(c *gin.Context) (repository.ProfileFilter, error) {
+	var filter repository.ProfileFilter
+	var err error
+
+	if name := strings.TrimSpace(c.Query("name")); name != "" {
+		filter.Name = &name
+	}
+
+	if keywords := strings.TrimSpace(c.Query("q")); keywords != "" {
+		filter.Keywords = &keywords
+	}
+
+	if filter.IsGroom, err = queryBool(c, "is_groom"); err != nil {
+		return filter, err
+	}
+	if filter.IsPhysicallyChallenged, err = queryBool(c, "is_physically_challenged"); err != nil {
+		return filter, err
+	}
+
+	for _, v := range queryList(c, "community") {
+		filter.Community = append(filter.Community, model.Community(v))
+	}
+	for _, v := range queryList(c, "nationality") {
+		filter.Nationality = append(filter.Nationality, model.Nationality(v))
+	}
+	for _, v := range queryList(c, "marital_status") {
+		filter.MaritalStatus = append(filter.MaritalStatus, model.MaritalStatus(v))
+	}
+	for _, v := range queryList(c, "home_district") {
+		filter.HomeDistrict = append(filter.HomeDistrict, model.HomeDistrict(v))
+	}
+
+	if filter.MinAge, err = queryIntPtr(c, "min_age"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAge, err = queryIntPtr(c, "max_age"); err != nil {
+		return filter, err
+	}
+	if filter.MinHeight, err = queryFloatPtr(c, "min_height"); err != nil {
+		return filter, err
+	}
+	if filter.MaxHeight, err = queryFloatPtr(c, "max_height"); err != nil {
+		return filter, err
+	}
+	if filter.MinCompleteness, err = queryIntPtr(c, "min_completeness"); err != nil {
+		return filter, err
+	}
+
+	if near := strings.TrimSpace(c.Query("near")); near != "" {
+		point, err := parseLocation(near)
+		if err != nil {
+			return filter, err
+		}
+		filter.Near = &point
+
+		if filter.RadiusKm, err = queryFloatPtr(c, "radius_km"); err != nil {
+			return filter, err
+		}
+		if filter.RadiusKm == nil {
+			radius := defaultSearchRadiusKm
+			filter.RadiusKm = &radius
+		}
+	}
+
+	return filter, nil
+}
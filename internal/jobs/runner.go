@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// Job is a unit of background work run periodically
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Runner runs registered jobs on their intervals until its context is cancelled
+type Runner struct {
+	jobs   []Job
+	logger *logger.Logger
+	wg     sync.WaitGroup
+}
+
+// NewRunner creates a new job runner
+func NewRunner(logger *logger.Logger) *Runner {
+	return &Runner{
+		logger: logger,
+	}
+}
+
+// Register adds a job to the runner. Jobs must be registered before Start.
+func (r *Runner) Register(job Job) {
+	r.jobs = append(r.jobs, job)
+}
+
+// Start runs every registered job in its own goroutine. Each job runs once
+// immediately and then on every tick of its interval.
+func (r *Runner) Start(ctx context.Context) {
+	for _, job := range r.jobs {
+		r.wg.Add(1)
+		go r.loop(ctx, job)
+	}
+}
+
+// Wait blocks until all jobs have stopped after the context was cancelled
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// loop runs a single job until the context is cancelled
+func (r *Runner) loop(ctx context.Context, job Job) {
+	defer r.wg.Done()
+
+	r.logger.Info("Starting background job",
+		zap.String("job", job.Name),
+		zap.Duration("interval", job.Interval))
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Stopped background job", zap.String("job", job.Name))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs a job a single time, recovering from panics so one failing run
+// doesn't stop future runs
+func (r *Runner) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			r.logger.Error("Background job panicked",
+				zap.String("job", job.Name),
+				zap.Any("panic", rec))
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		r.logger.Error("Background job failed",
+			zap.String("job", job.Name),
+			zap.Duration("duration", time.Since(start)),
+			zap.Error(err))
+		return
+	}
+
+	r.logger.Debug("Background job completed",
+		zap.String("job", job.Name),
+		zap.Duration("duration", time.Since(start)))
+}
@@ -7,12 +7,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/profilecode"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/requestinfo"
 	"go.uber.org/zap"
 )
 
 const (
 	// AuthUserIDKey is the context key for the authenticated user ID
 	AuthUserIDKey = "auth_user_id"
+
+	// ActingProfileHeader names the profile of the user's account that a request acts as
+	ActingProfileHeader = "X-Acting-Profile-ID"
 )
 
 // AuthError represents authentication related errors
@@ -54,7 +59,7 @@ func Authentication(logger *logger.Logger) gin.HandlerFunc {
 		c.Set(AuthUserIDKey, uid)
 
 		// Add user ID to request context for logging
-		reqID := c.GetHeader("X-Request-ID")
+		reqID := requestinfo.RequestID(c.Request.Context())
 		logger.Debug("Authenticated request",
 			zap.String("user_id", uid.String()),
 			zap.String("request_id", reqID),
@@ -79,3 +84,25 @@ func GetAuthenticatedUserID(c *gin.Context) (uuid.UUID, error) {
 
 	return uid, nil
 }
+
+// GetActingProfile retrieves the profile the request acts as from the X-Acting-Profile-ID
+// header, which holds either a profile ID or a public profile code. It returns the ID, or
+// the normalized code, and neither when the header is absent, leaving the choice to the
+// account's active profile.
+func GetActingProfile(c *gin.Context) (uuid.UUID, string, error) {
+	raw := c.GetHeader(ActingProfileHeader)
+	if raw == "" {
+		return uuid.Nil, "", nil
+	}
+
+	if profileID, err := uuid.Parse(raw); err == nil {
+		return profileID, "", nil
+	}
+
+	code, err := profilecode.Parse(raw)
+	if err != nil {
+		return uuid.Nil, "", errors.New("acting profile is neither a valid profile ID nor a profile code")
+	}
+
+	return uuid.Nil, code, nil
+}
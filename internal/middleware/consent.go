@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// RequireConsent middleware rejects requests from users who haven't accepted the
+// current version of every mandatory policy, naming the policies still to accept.
+// It must run after Authentication.
+func RequireConsent(checker service.ConsentChecker, logger *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := GetAuthenticatedUserID(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status":  false,
+				"message": "Authentication required",
+				"error":   "Missing authentication information",
+			})
+			return
+		}
+
+		missing, err := checker.MissingConsents(c.Request.Context(), userID)
+		if err != nil {
+			logger.Error("Failed to check consents",
+				zap.String("user_id", userID.String()),
+				zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"status":  false,
+				"message": "An unexpected error occurred",
+			})
+			return
+		}
+
+		if len(missing) > 0 {
+			policies := make([]string, len(missing))
+			for i, policy := range missing {
+				policies[i] = string(policy)
+			}
+
+			logger.Debug("Mandatory policies not accepted",
+				zap.String("user_id", userID.String()),
+				zap.Strings("policies", policies))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  false,
+				"message": "You must accept the current terms and privacy policy to continue",
+				"error":   strings.Join(policies, ","),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
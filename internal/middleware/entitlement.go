@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/service"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// RequireFeature middleware rejects requests from users whose membership plan
+// doesn't include the feature. It must run after Authentication.
+func RequireFeature(entitler service.Entitler, feature service.Feature, logger *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := GetAuthenticatedUserID(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status":  false,
+				"message": "Authentication required",
+				"error":   "Missing authentication information",
+			})
+			return
+		}
+
+		entitlements, err := entitler.Entitlements(c.Request.Context(), userID)
+		if err != nil {
+			logger.Error("Failed to resolve entitlements",
+				zap.String("user_id", userID.String()),
+				zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"status":  false,
+				"message": "An unexpected error occurred",
+			})
+			return
+		}
+
+		if !entitlements.Allows(feature) {
+			logger.Debug("Feature not included in membership plan",
+				zap.String("user_id", userID.String()),
+				zap.String("plan_code", entitlements.PlanCode),
+				zap.String("feature", string(feature)))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  false,
+				"message": "Your membership plan doesn't include this feature",
+				"error":   string(feature),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/requestinfo"
 	"go.uber.org/zap"
 )
 
@@ -17,15 +18,21 @@ func RequestLogger(logger *logger.Logger) gin.HandlerFunc {
 		path := c.Request.URL.Path
 		method := c.Request.Method
 
-		// Get or generate request ID
+		// Use the client's request ID only if it is well formed, since it ends up in
+		// audit records; otherwise generate one
 		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
+		if !requestinfo.ValidRequestID(requestID) {
 			requestID = uuid.New().String()
-			c.Header("X-Request-ID", requestID)
 		}
+		c.Header("X-Request-ID", requestID)
 
-		// Add request ID to context for later use in logging
+		// Add request ID to context for later use in logging, and the request ID and
+		// client IP to the request context so that services can log and audit them
+		clientIP := c.ClientIP()
 		c.Set("request_id", requestID)
+		ctx := requestinfo.WithRequestID(c.Request.Context(), requestID)
+		ctx = requestinfo.WithClientIP(ctx, clientIP)
+		c.Request = c.Request.WithContext(ctx)
 
 		// Process request
 		c.Next()
@@ -41,7 +48,7 @@ func RequestLogger(logger *logger.Logger) gin.HandlerFunc {
 			zap.Int("status", statusCode),
 			zap.Duration("latency", latency),
 			zap.String("request_id", requestID),
-			zap.String("client_ip", c.ClientIP()),
+			zap.String("client_ip", clientIP),
 		}
 
 		switch {
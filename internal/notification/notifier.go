@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// Notification types sent by the user service
+const (
+	TypeSavedSearchMatch = "saved_search_match"
+)
+
+// Notification is a message delivered to a user outside of the API response cycle
+type Notification struct {
+	UserID uuid.UUID
+	Type   string
+	Title  string
+	Body   string
+	Data   map[string]string
+}
+
+// Notifier delivers notifications to users. Implementations may push to a
+// notification service, a message queue, email, etc.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// LogNotifier is a Notifier that only logs notifications. It is used when no
+// delivery channel is configured.
+type LogNotifier struct {
+	logger *logger.Logger
+}
+
+// NewLogNotifier creates a new LogNotifier
+func NewLogNotifier(logger *logger.Logger) *LogNotifier {
+	return &LogNotifier{
+		logger: logger,
+	}
+}
+
+// Notify logs the notification
+func (n *LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.logger.Info("Notification",
+		zap.String("user_id", notification.UserID.String()),
+		zap.String("type", notification.Type),
+		zap.String("title", notification.Title),
+		zap.String("body", notification.Body),
+		zap.Any("data", notification.Data))
+	return nil
+}
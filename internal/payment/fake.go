@@ -0,0 +1,90 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// FakeProviderName is the name of the fake payment provider
+const FakeProviderName = "fake"
+
+// FakeSignatureHeader carries the hex HMAC-SHA256 of a fake webhook body
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// FakeProvider is a Provider that collects no money. Checkouts are only
+// references, and payments are completed by posting a signed webhook, e.g.
+//
+//	body='{"id":"evt_1","type":"payment.succeeded","reference":"fake_..."}'
+//	sig=$(printf '%s' "$body" | openssl dgst -sha256 -hmac "$PAYMENT_WEBHOOK_SECRET" -hex | cut -d' ' -f2)
+//	curl -H "X-Fake-Signature: $sig" -d "$body" localhost:8080/api/v1/payments/webhook/fake
+type FakeProvider struct {
+	secret []byte
+}
+
+// fakeWebhookBody is the JSON body of a fake webhook
+type fakeWebhookBody struct {
+	ID        string    `json:"id"`
+	Type      EventType `json:"type"`
+	Reference string    `json:"reference"`
+}
+
+// NewFakeProvider creates a FakeProvider that accepts webhooks signed with secret
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		secret: []byte(secret),
+	}
+}
+
+// Name returns the provider name
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+// CreateCheckout returns a reference derived from the subscription ID
+func (p *FakeProvider) CreateCheckout(ctx context.Context, req CheckoutRequest) (*Checkout, error) {
+	reference := "fake_" + req.SubscriptionID.String()
+	return &Checkout{
+		Reference: reference,
+		URL:       "fake://checkout/" + reference,
+	}, nil
+}
+
+// ParseWebhook verifies the body signature and decodes the event
+func (p *FakeProvider) ParseWebhook(header http.Header, body []byte) (*Event, error) {
+	signature, err := hex.DecodeString(header.Get(FakeSignatureHeader))
+	if err != nil || len(p.secret) == 0 || !hmac.Equal(signature, p.sign(body)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidWebhook)
+	}
+
+	var event fakeWebhookBody
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if event.ID == "" || event.Reference == "" {
+		return nil, fmt.Errorf("%w: id and reference are required", ErrInvalidWebhook)
+	}
+
+	return &Event{
+		ID:        event.ID,
+		Type:      event.Type,
+		Reference: event.Reference,
+		Payload:   body,
+	}, nil
+}
+
+// Sign returns the signature header value for a webhook body
+func (p *FakeProvider) Sign(body []byte) string {
+	return hex.EncodeToString(p.sign(body))
+}
+
+// sign computes the HMAC-SHA256 of body
+func (p *FakeProvider) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
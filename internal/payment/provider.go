@@ -0,0 +1,61 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidWebhook is returned when a webhook request can't be authenticated or parsed
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// EventType is the kind of a payment provider event
+type EventType string
+
+// Event types the membership service acts on
+const (
+	EventPaymentSucceeded EventType = "payment.succeeded"
+	EventPaymentFailed    EventType = "payment.failed"
+	EventPaymentRefunded  EventType = "payment.refunded"
+)
+
+// CheckoutRequest describes a payment to be collected for a subscription
+type CheckoutRequest struct {
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	PlanCode       string
+	AmountPaise    int64
+	Currency       string
+}
+
+// Checkout is a payment session created with a provider. The user completes
+// the payment at URL and the provider reports the outcome through a webhook
+// carrying Reference.
+type Checkout struct {
+	Reference string
+	URL       string
+}
+
+// Event is a webhook event reported by a payment provider
+type Event struct {
+	ID        string
+	Type      EventType
+	Reference string
+	Payload   []byte
+}
+
+// Provider is a payment provider. Implementations wrap a gateway such as
+// Razorpay or Stripe; FakeProvider stands in for one locally and in tests.
+type Provider interface {
+	// Name identifies the provider in webhook URLs and stored subscriptions
+	Name() string
+
+	// CreateCheckout starts collecting a payment
+	CreateCheckout(ctx context.Context, req CheckoutRequest) (*Checkout, error)
+
+	// ParseWebhook authenticates a webhook request and extracts its event.
+	// It returns ErrInvalidWebhook for requests that don't come from the provider.
+	ParseWebhook(header http.Header, body []byte) (*Event, error)
+}
@@ -0,0 +1,47 @@
+package photo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotFound is returned when a profile has no photo
+	ErrNotFound = errors.New("photo not found")
+
+	// ErrUnavailable is returned when the store can't tell which photos a profile has
+	ErrUnavailable = errors.New("photos unavailable")
+)
+
+// Store gives access to the photos uploaded for profiles. Photos are kept by the
+// media service; implementations fetch them from it or from its storage bucket.
+type Store interface {
+	// PrimaryPhoto returns the profile's main photo as a JPEG, or ErrNotFound
+	PrimaryPhoto(ctx context.Context, profileID uuid.UUID) ([]byte, error)
+
+	// CountPhotos returns the number of photos uploaded for the profile, or ErrUnavailable
+	// if the store can't tell
+	CountPhotos(ctx context.Context, profileID uuid.UUID) (int, error)
+}
+
+// NoopStore is a Store without photos. It is the only Store until a client for the media
+// service is written, so for now biodata sheets are rendered and data exports assembled
+// without photos, and the photos section is left out of profile completeness.
+type NoopStore struct{}
+
+// NewNoopStore creates a new NoopStore
+func NewNoopStore() *NoopStore {
+	return &NoopStore{}
+}
+
+// PrimaryPhoto always reports that the profile has no photo
+func (s *NoopStore) PrimaryPhoto(ctx context.Context, profileID uuid.UUID) ([]byte, error) {
+	return nil, ErrNotFound
+}
+
+// CountPhotos always reports that the profile's photos can't be counted
+func (s *NoopStore) CountPhotos(ctx context.Context, profileID uuid.UUID) (int, error) {
+	return 0, ErrUnavailable
+}
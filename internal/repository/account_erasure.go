@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// AccountErasureRepository defines operations for working with account erasures and the
+// records they erase. All methods take part in a transaction started by
+// UserProfileRepository.WithTransaction.
+type AccountErasureRepository interface {
+	// Create queues an erasure. It returns ErrDuplicateKey if the account already has
+	// an erasure in progress.
+	Create(ctx context.Context, erasure *model.AccountErasure) error
+
+	// GetLatestBySubjectHash retrieves the most recent erasure of the account with the given subject hash
+	GetLatestBySubjectHash(ctx context.Context, subjectHash string) (*model.AccountErasure, error)
+
+	// ClaimNext marks the oldest unfinished erasure running and returns it, or returns nil
+	// if there is none. Erasures left running since before staleBefore, by a worker that
+	// stopped midway, are claimed again.
+	ClaimNext(ctx context.Context, at, staleBefore time.Time) (*model.AccountErasure, error)
+
+	// SaveProgress records the steps an erasure has completed and the rows they affected
+	SaveProgress(ctx context.Context, id uuid.UUID, completedSteps int, summary []byte) error
+
+	// EraseStep erases the account's records covered by a step and returns how many rows
+	// it removed or anonymized. Steps can be repeated, affecting only what is left.
+	EraseStep(ctx context.Context, userID uuid.UUID, step model.AccountErasureStep, at time.Time) (int64, error)
+
+	// EraseProfileRecords erases the records of one profile covered by the steps other
+	// than the profiles step, as when a deleted profile is purged, and returns how many
+	// rows it removed or anonymized
+	EraseProfileRecords(ctx context.Context, profileID uuid.UUID) (int64, error)
+
+	// Pseudonymize replaces the account's ID with a random one in the records kept for
+	// statistics, so that they can't be traced back to the account, and returns how
+	// many rows it changed
+	Pseudonymize(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// LatestReceipt locks the receipt chain until the transaction ends and retrieves the
+	// last completed erasure, or returns nil if there is none
+	LatestReceipt(ctx context.Context) (*model.AccountErasure, error)
+
+	// Complete seals a running erasure with its receipt and clears its account ID
+	Complete(ctx context.Context, erasure *model.AccountErasure) error
+
+	// ListReceipts retrieves up to limit completed erasures after the given sequence number, in order
+	ListReceipts(ctx context.Context, afterSequence int64, limit int) ([]*model.AccountErasure, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ConsentRecordRepository defines operations for working with the consent ledger.
+// Records are only ever appended.
+type ConsentRecordRepository interface {
+	// Append adds a grant or withdrawal to the ledger
+	Append(ctx context.Context, record *model.ConsentRecord) error
+
+	// ListLatestByUserID retrieves the latest record of each policy the account has
+	// granted or withdrawn, which holds its current choice
+	ListLatestByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ConsentRecord, error)
+
+	// ListByUserID retrieves every record of an account, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ConsentRecord, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ContactDetailRepository defines operations for working with encrypted contact details
+type ContactDetailRepository interface {
+	// Upsert creates or replaces the contact details of a profile
+	Upsert(ctx context.Context, detail *model.ContactDetail) error
+
+	// GetByProfileID retrieves the contact details of a profile
+	GetByProfileID(ctx context.Context, profileID uuid.UUID) (*model.ContactDetail, error)
+}
+
+// ContactRevealRepository defines operations for the contact reveal audit trail
+type ContactRevealRepository interface {
+	// Record adds an audit record. Recording a second successful reveal for the
+	// same viewer and profile fails with ErrDuplicateKey.
+	Record(ctx context.Context, reveal *model.ContactReveal) error
+
+	// RecordWithinQuota records a successful reveal charged to the viewing account's quota,
+	// unless the account's profiles already made quota reveals since the given time. It reports
+	// whether the reveal was recorded; the check and the insert are atomic per account.
+	RecordWithinQuota(ctx context.Context, reveal *model.ContactReveal, since time.Time, quota int) (bool, error)
+
+	// GetRevealed retrieves the successful reveal of a profile's details to a viewer
+	GetRevealed(ctx context.Context, viewerProfileID, profileID uuid.UUID) (*model.ContactReveal, error)
+
+	// CountQuotaReveals counts the successful reveals charged to an account's quota since the given time
+	CountQuotaReveals(ctx context.Context, viewerUserID uuid.UUID, since time.Time) (int64, error)
+}
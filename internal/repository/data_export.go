@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// DataExportRepository defines operations for working with personal data exports
+type DataExportRepository interface {
+	// Create queues an export. It returns ErrDuplicateKey if the account already has
+	// an export being assembled.
+	Create(ctx context.Context, export *model.DataExport) error
+
+	// GetByID retrieves an export of an account, without its archive
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*model.DataExport, error)
+
+	// ListByUserID retrieves the exports of an account without their archives, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExport, error)
+
+	// GetArchive retrieves a completed export with its archive, if it hasn't expired at the given time
+	GetArchive(ctx context.Context, id uuid.UUID, at time.Time) (*model.DataExport, error)
+
+	// ClaimNext marks the oldest queued export running and returns it, or returns nil if
+	// there is none. Exports left running since before staleBefore, by a worker that
+	// stopped midway, are claimed again.
+	ClaimNext(ctx context.Context, at, staleBefore time.Time) (*model.DataExport, error)
+
+	// Complete stores the archive of a running export
+	Complete(ctx context.Context, id uuid.UUID, archive []byte, completedAt, expiresAt time.Time) error
+
+	// Fail marks a running export failed with the given reason
+	Fail(ctx context.Context, id uuid.UUID, reason string, at time.Time) error
+
+	// ExpireArchives drops the archives of the exports expired at the given time and
+	// returns how many were expired
+	ExpireArchives(ctx context.Context, at time.Time) (int64, error)
+
+	// CollectUserData retrieves everything held about an account
+	CollectUserData(ctx context.Context, userID uuid.UUID) (*UserData, error)
+}
+
+// UserData is everything held about an account: its profiles, including deleted ones,
+// and the records tied to the account or to any of its profiles
+type UserData struct {
+	Profiles       []*model.UserProfile
+	ContactDetails []*model.ContactDetail
+	Revisions      []*model.ProfileRevision
+	Preferences    []*model.PartnerPreference
+	Privacy        []*model.ProfilePrivacySettings
+	Drafts         []*model.ProfileDraft
+	Interests      []*model.Interest    // sent or received by the profiles
+	ViewsMade      []*model.ProfileView // of other profiles by the profiles
+	ViewsReceived  []*model.ProfileView // of the profiles by others
+	ContactReveals []*model.ContactReveal
+	Blocks         []*model.ProfileBlock // made by the profiles
+	SavedSearches  []*model.SavedSearch
+	Managers       []*model.ProfileManager // granted on the profiles or to the account
+	Transfers      []*model.ProfileTransfer
+	ShareLinks     []*model.ProfileShareLink
+	Boosts         []*model.ProfileBoost
+	Subscriptions  []*model.Subscription
+	Consents       []*model.ConsentRecord
+}
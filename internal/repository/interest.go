@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// InterestRepository defines operations for working with interests between profiles
+type InterestRepository interface {
+	// Create adds a new interest
+	Create(ctx context.Context, interest *model.Interest) error
+
+	// GetByID retrieves an interest by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Interest, error)
+
+	// GetBetween retrieves the interest between two profiles, sent in either direction
+	GetBetween(ctx context.Context, profileID, otherProfileID uuid.UUID) (*model.Interest, error)
+
+	// ListReceived retrieves interests received by a profile with pagination, newest first.
+	// An empty status lists interests of every status.
+	ListReceived(ctx context.Context, profileID uuid.UUID, status model.InterestStatus, page, limit int) ([]*model.Interest, int64, error)
+
+	// ListSent retrieves interests sent by a profile with pagination, newest first
+	ListSent(ctx context.Context, profileID uuid.UUID, status model.InterestStatus, page, limit int) ([]*model.Interest, int64, error)
+
+	// Respond records the receiver's response to a pending interest
+	Respond(ctx context.Context, id uuid.UUID, status model.InterestStatus, respondedAt time.Time) error
+}
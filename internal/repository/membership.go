@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// MembershipPlanRepository defines operations for working with membership plans
+type MembershipPlanRepository interface {
+	// List retrieves the plans that can be subscribed to, cheapest first
+	List(ctx context.Context) ([]*model.MembershipPlan, error)
+
+	// GetByCode retrieves a plan by its code
+	GetByCode(ctx context.Context, code string) (*model.MembershipPlan, error)
+}
+
+// SubscriptionRepository defines operations for working with subscriptions
+type SubscriptionRepository interface {
+	// Create adds a new subscription
+	Create(ctx context.Context, subscription *model.Subscription) error
+
+	// GetByReference retrieves a subscription by its payment provider reference
+	GetByReference(ctx context.Context, provider, reference string) (*model.Subscription, error)
+
+	// GetCurrent retrieves the active subscription covering the given time, with its plan.
+	// When subscriptions overlap, the one expiring last is returned.
+	GetCurrent(ctx context.Context, userID uuid.UUID, at time.Time) (*model.Subscription, error)
+
+	// Activate records a payment event and activates a pending subscription for its plan's
+	// duration, starting now or when the user's last active subscription expires.
+	// A payment event that was already recorded fails with ErrDuplicateKey.
+	Activate(ctx context.Context, subscriptionID uuid.UUID, event *model.PaymentEvent) (*model.Subscription, error)
+
+	// SetStatus records a payment event and changes the status of a subscription.
+	// A payment event that was already recorded fails with ErrDuplicateKey.
+	SetStatus(ctx context.Context, subscriptionID uuid.UUID, status model.SubscriptionStatus, event *model.PaymentEvent) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// PartnerPreferenceRepository defines operations for working with partner preferences
+type PartnerPreferenceRepository interface {
+	// Upsert creates or replaces the preferences of a profile
+	Upsert(ctx context.Context, preference *model.PartnerPreference) error
+
+	// GetByProfileID retrieves the preferences of a profile
+	GetByProfileID(ctx context.Context, profileID uuid.UUID) (*model.PartnerPreference, error)
+
+	// ListByProfileIDs retrieves the preferences of several profiles; profiles without preferences are omitted
+	ListByProfileIDs(ctx context.Context, profileIDs []uuid.UUID) ([]*model.PartnerPreference, error)
+}
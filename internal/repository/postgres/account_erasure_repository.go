@@ -0,0 +1,420 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityAccountErasure = "AccountErasure"
+
+	// erasureChainLock is the advisory lock key serializing appends to the receipt chain
+	erasureChainLock = "account_erasure_receipts"
+)
+
+// accountColumns are the columns outside the erased records that hold an account ID,
+// in records kept for statistics or belonging to other members' profiles
+var accountColumns = []struct {
+	table  string
+	column string
+}{
+	{"user_profiles", "user_id"},
+	{"user_profiles", "updated_by"},
+	{"user_profiles", "deleted_by"},
+	{"profile_revisions", "actor_id"},
+	{"profile_privacy_settings", "updated_by"},
+	{"profile_share_links", "created_by"},
+	{"profile_share_links", "revoked_by"},
+	{"profile_managers", "invited_by"},
+	{"profile_transfers", "from_user_id"},
+	{"profile_transfers", "to_user_id"},
+	{"profile_transfers", "cancelled_by"},
+	{"profile_boosts", "user_id"},
+	{"profile_boost_clicks", "viewer_user_id"},
+	{"contact_reveals", "viewer_user_id"},
+	{"subscriptions", "user_id"},
+	{"consent_records", "user_id"},
+}
+
+// AccountErasureRepository implements repository.AccountErasureRepository for PostgreSQL
+type AccountErasureRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountErasureRepository creates a new AccountErasureRepository
+func NewAccountErasureRepository(db *gorm.DB) repository.AccountErasureRepository {
+	return &AccountErasureRepository{
+		db: db,
+	}
+}
+
+// Create queues an erasure
+func (r *AccountErasureRepository) Create(ctx context.Context, erasure *model.AccountErasure) error {
+	const op = "Create"
+
+	if erasure.UserID == nil || erasure.SubjectHash == "" {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityAccountErasure, "user_id and subject_hash are required")
+	}
+
+	if erasure.CreatedAt.IsZero() {
+		erasure.CreatedAt = time.Now()
+	}
+	if erasure.Summary == nil {
+		erasure.Summary = []byte("{}")
+	}
+
+	if err := conn(ctx, r.db).Create(erasure).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_open_account_erasure" {
+			return repository.NewError(repository.ErrDuplicateKey, op, entityAccountErasure, "account already has an erasure in progress")
+		}
+		return repository.NewError(err, op, entityAccountErasure, "")
+	}
+
+	return nil
+}
+
+// GetLatestBySubjectHash retrieves the most recent erasure of an account
+func (r *AccountErasureRepository) GetLatestBySubjectHash(ctx context.Context, subjectHash string) (*model.AccountErasure, error) {
+	const op = "GetLatestBySubjectHash"
+
+	var erasure model.AccountErasure
+	err := conn(ctx, r.db).
+		Where("subject_hash = ?", subjectHash).
+		Order("created_at DESC").
+		First(&erasure).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityAccountErasure, "subject")
+		}
+		return nil, repository.NewError(err, op, entityAccountErasure, "")
+	}
+
+	return &erasure, nil
+}
+
+// ClaimNext marks the oldest unfinished erasure running and returns it. Concurrent
+// workers skip the rows locked by each other.
+func (r *AccountErasureRepository) ClaimNext(ctx context.Context, at, staleBefore time.Time) (*model.AccountErasure, error) {
+	const op = "ClaimNext"
+
+	var erasures []*model.AccountErasure
+	err := conn(ctx, r.db).Raw(`
+		UPDATE account_erasures SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM account_erasures
+			WHERE status = ? OR (status = ? AND started_at < ?)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		model.AccountErasureStatusRunning, at,
+		model.AccountErasureStatusPending, model.AccountErasureStatusRunning, staleBefore,
+	).Scan(&erasures).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityAccountErasure, "")
+	}
+
+	if len(erasures) == 0 {
+		return nil, nil
+	}
+	return erasures[0], nil
+}
+
+// SaveProgress records the steps an erasure has completed and the rows they affected
+func (r *AccountErasureRepository) SaveProgress(ctx context.Context, id uuid.UUID, completedSteps int, summary []byte) error {
+	const op = "SaveProgress"
+
+	result := conn(ctx, r.db).Model(&model.AccountErasure{}).
+		Where("id = ? AND status = ?", id, model.AccountErasureStatusRunning).
+		Updates(map[string]interface{}{
+			"completed_steps": completedSteps,
+			"summary":         summary,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityAccountErasure, fmt.Sprintf("id: %s", id))
+	}
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityAccountErasure, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
+
+// EraseStep erases the account's records covered by a step. Records are matched by the
+// account ID or by the IDs of the account's profiles, deleted ones included.
+func (r *AccountErasureRepository) EraseStep(
+	ctx context.Context,
+	userID uuid.UUID,
+	step model.AccountErasureStep,
+	at time.Time,
+) (int64, error) {
+	const op = "EraseStep"
+
+	if !slices.Contains(model.AccountErasureSteps, step) {
+		return 0, repository.NewError(fmt.Errorf("unknown erasure step %q", step), op, entityAccountErasure, fmt.Sprintf("step: %s", step))
+	}
+
+	var erased int64
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		if step == model.AccountErasureStepProfiles {
+			// The account's lock keeps profiles from being created or restored meanwhile
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", userID.String()).Error; err != nil {
+				return err
+			}
+
+			columns := anonymizedProfileColumns(at)
+			columns["deleted_at"] = gorm.Expr("COALESCE(deleted_at, ?)", at)
+			columns["deleted_by"] = gorm.Expr("COALESCE(deleted_by, ?)", userID)
+
+			// UpdateColumns skips the hook that would recompute the phonetic key from the name
+			result := tx.Unscoped().Model(&model.UserProfile{}).
+				Where("user_id = ? AND purged_at IS NULL", userID).
+				UpdateColumns(columns)
+			if result.Error != nil {
+				return result.Error
+			}
+			erased = result.RowsAffected
+
+			return tx.Where("user_id = ?", userID).Delete(&model.ActiveProfile{}).Error
+		}
+
+		profileIDs := tx.Unscoped().Model(&model.UserProfile{}).Select("id").Where("user_id = ?", userID)
+		n, err := eraseProfileRecords(tx, step, profileIDs)
+		erased += n
+		if err != nil {
+			return err
+		}
+
+		n, err = eraseAccountRecords(tx, step, userID)
+		erased += n
+		return err
+	})
+	if err != nil {
+		return 0, repository.NewError(err, op, entityAccountErasure, fmt.Sprintf("step: %s", step))
+	}
+
+	return erased, nil
+}
+
+// EraseProfileRecords erases the records of a single profile covered by every step
+// but the profiles step, which the caller carries out on the profile row itself
+func (r *AccountErasureRepository) EraseProfileRecords(ctx context.Context, profileID uuid.UUID) (int64, error) {
+	const op = "EraseProfileRecords"
+
+	var erased int64
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		for _, step := range model.AccountErasureSteps {
+			if step == model.AccountErasureStepProfiles {
+				continue
+			}
+			n, err := eraseProfileRecords(tx, step, []uuid.UUID{profileID})
+			erased += n
+			if err != nil {
+				return fmt.Errorf("step %s: %w", step, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, repository.NewError(err, op, entityAccountErasure, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return erased, nil
+}
+
+// deleteWhere removes the rows of a model matching the condition and counts them
+func deleteWhere(tx *gorm.DB, value interface{}, query string, args ...interface{}) (int64, error) {
+	result := tx.Unscoped().Where(query, args...).Delete(value)
+	return result.RowsAffected, result.Error
+}
+
+// eraseProfileRecords erases the records of a step that belong to the profiles selected
+// by profileIDs, a list of IDs or a subquery, and returns how many rows it removed or
+// anonymized
+func eraseProfileRecords(tx *gorm.DB, step model.AccountErasureStep, profileIDs interface{}) (int64, error) {
+	switch step {
+	case model.AccountErasureStepContactDetails:
+		return deleteWhere(tx, &model.ContactDetail{}, "profile_id IN (?)", profileIDs)
+
+	case model.AccountErasureStepProfileHistory:
+		return deleteWhere(tx, &model.ProfileRevision{}, "profile_id IN (?)", profileIDs)
+
+	case model.AccountErasureStepPartnerPreferences:
+		return deleteWhere(tx, &model.PartnerPreference{}, "profile_id IN (?)", profileIDs)
+
+	case model.AccountErasureStepPrivacySettings:
+		return deleteWhere(tx, &model.ProfilePrivacySettings{}, "profile_id IN (?)", profileIDs)
+
+	case model.AccountErasureStepShareLinks:
+		// The links' snapshots are copies of the profiles
+		return deleteWhere(tx, &model.ProfileShareLink{}, "profile_id IN (?)", profileIDs)
+
+	case model.AccountErasureStepSavedSearches:
+		// Alerts of other members about the profiles
+		return deleteWhere(tx, &model.SearchAlert{}, "profile_id IN (?)", profileIDs)
+
+	case model.AccountErasureStepInterestMessages:
+		// Interests are kept for statistics and for the other members, without the messages written
+		result := tx.Model(&model.Interest{}).
+			Where("sender_profile_id IN (?) AND message <> ''", profileIDs).
+			UpdateColumn("message", "")
+		return result.RowsAffected, result.Error
+
+	case model.AccountErasureStepRecommendations:
+		erased, err := deleteWhere(tx, &model.Recommendation{}, "profile_id IN (?) OR candidate_id IN (?)", profileIDs, profileIDs)
+		if err != nil {
+			return erased, err
+		}
+		n, err := deleteWhere(tx, &model.RecommendationRun{}, "profile_id IN (?)", profileIDs)
+		return erased + n, err
+
+	case model.AccountErasureStepProfileManagers:
+		return deleteWhere(tx, &model.ProfileManager{}, "profile_id IN (?)", profileIDs)
+
+	case model.AccountErasureStepDataExports:
+		// The archives of the profiles' owners are copies of everything above
+		owners := tx.Unscoped().Model(&model.UserProfile{}).Select("user_id").Where("id IN (?)", profileIDs)
+		return deleteWhere(tx, &model.DataExport{}, "user_id IN (?)", owners)
+	}
+
+	// Drafts are of profiles not created yet, and consent records are the account's
+	return 0, nil
+}
+
+// eraseAccountRecords erases the records of a step that belong to the account as a
+// whole rather than to one of its profiles
+func eraseAccountRecords(tx *gorm.DB, step model.AccountErasureStep, userID uuid.UUID) (int64, error) {
+	switch step {
+	case model.AccountErasureStepProfileDrafts:
+		return deleteWhere(tx, &model.ProfileDraft{}, "user_id = ?", userID)
+
+	case model.AccountErasureStepSavedSearches:
+		erased, err := deleteWhere(tx, &model.SearchAlert{}, "user_id = ?", userID)
+		if err != nil {
+			return erased, err
+		}
+		n, err := deleteWhere(tx, &model.SavedSearch{}, "user_id = ?", userID)
+		return erased + n, err
+
+	case model.AccountErasureStepProfileManagers:
+		// Invitations to manage other members' profiles
+		return deleteWhere(tx, &model.ProfileManager{}, "manager_user_id = ?", userID)
+
+	case model.AccountErasureStepDataExports:
+		return deleteWhere(tx, &model.DataExport{}, "user_id = ?", userID)
+
+	case model.AccountErasureStepConsentRecords:
+		// The ledger is append-only and kept as proof of what was agreed, without where from
+		result := tx.Model(&model.ConsentRecord{}).
+			Where("user_id = ? AND source_ip IS NOT NULL", userID).
+			UpdateColumn("source_ip", nil)
+		return result.RowsAffected, result.Error
+	}
+
+	return 0, nil
+}
+
+// Pseudonymize replaces the account's ID with a random one in the records kept. The
+// random ID isn't stored anywhere, so the records can't be traced back to the account.
+func (r *AccountErasureRepository) Pseudonymize(ctx context.Context, userID uuid.UUID) (int64, error) {
+	const op = "Pseudonymize"
+
+	pseudonym := uuid.New()
+	var changed int64
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		for _, c := range accountColumns {
+			result := tx.Exec(fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", c.table, c.column, c.column), pseudonym, userID)
+			if result.Error != nil {
+				return result.Error
+			}
+			changed += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, repository.NewError(err, op, entityAccountErasure, "")
+	}
+
+	return changed, nil
+}
+
+// LatestReceipt locks the receipt chain and retrieves the last completed erasure
+func (r *AccountErasureRepository) LatestReceipt(ctx context.Context) (*model.AccountErasure, error) {
+	const op = "LatestReceipt"
+
+	db := conn(ctx, r.db)
+	if err := db.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", erasureChainLock).Error; err != nil {
+		return nil, repository.NewError(err, op, entityAccountErasure, "")
+	}
+
+	var erasures []*model.AccountErasure
+	err := db.Where("status = ?", model.AccountErasureStatusCompleted).
+		Order("sequence DESC").
+		Limit(1).
+		Find(&erasures).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityAccountErasure, "")
+	}
+
+	if len(erasures) == 0 {
+		return nil, nil
+	}
+	return erasures[0], nil
+}
+
+// Complete seals a running erasure with its receipt and clears its account ID
+func (r *AccountErasureRepository) Complete(ctx context.Context, erasure *model.AccountErasure) error {
+	const op = "Complete"
+
+	result := conn(ctx, r.db).Model(&model.AccountErasure{}).
+		Where("id = ? AND status = ?", erasure.ID, model.AccountErasureStatusRunning).
+		Updates(map[string]interface{}{
+			"status":          model.AccountErasureStatusCompleted,
+			"user_id":         nil,
+			"completed_steps": erasure.CompletedSteps,
+			"summary":         erasure.Summary,
+			"sequence":        erasure.Sequence,
+			"previous_hash":   erasure.PreviousHash,
+			"receipt_hash":    erasure.ReceiptHash,
+			"receipt":         erasure.Receipt,
+			"completed_at":    erasure.CompletedAt,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityAccountErasure, fmt.Sprintf("id: %s", erasure.ID))
+	}
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityAccountErasure, fmt.Sprintf("id: %s", erasure.ID))
+	}
+
+	erasure.Status = model.AccountErasureStatusCompleted
+	erasure.UserID = nil
+	return nil
+}
+
+// ListReceipts retrieves completed erasures after the given sequence number, in order
+func (r *AccountErasureRepository) ListReceipts(ctx context.Context, afterSequence int64, limit int) ([]*model.AccountErasure, error) {
+	const op = "ListReceipts"
+
+	var erasures []*model.AccountErasure
+	err := conn(ctx, r.db).
+		Where("status = ? AND sequence > ?", model.AccountErasureStatusCompleted, afterSequence).
+		Order("sequence").
+		Limit(limit).
+		Find(&erasures).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityAccountErasure, "")
+	}
+
+	return erasures, nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityConsentRecord = "ConsentRecord"
+)
+
+// ConsentRecordRepository implements repository.ConsentRecordRepository for PostgreSQL
+type ConsentRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewConsentRecordRepository creates a new ConsentRecordRepository
+func NewConsentRecordRepository(db *gorm.DB) repository.ConsentRecordRepository {
+	return &ConsentRecordRepository{
+		db: db,
+	}
+}
+
+// Append adds a grant or withdrawal to the ledger
+func (r *ConsentRecordRepository) Append(ctx context.Context, record *model.ConsentRecord) error {
+	const op = "Append"
+
+	if record.UserID == uuid.Nil || record.Policy == "" || record.PolicyVersion == "" || record.Action == "" {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityConsentRecord, "user_id, policy, policy_version and action are required")
+	}
+
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	if err := conn(ctx, r.db).Create(record).Error; err != nil {
+		return repository.NewError(err, op, entityConsentRecord, fmt.Sprintf("user_id: %s", record.UserID))
+	}
+
+	return nil
+}
+
+// ListLatestByUserID retrieves the latest record of each policy of an account
+func (r *ConsentRecordRepository) ListLatestByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ConsentRecord, error) {
+	const op = "ListLatestByUserID"
+
+	var records []*model.ConsentRecord
+	err := conn(ctx, r.db).Raw(`
+		SELECT DISTINCT ON (policy) * FROM consent_records
+		WHERE user_id = ?
+		ORDER BY policy, created_at DESC`,
+		userID,
+	).Scan(&records).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityConsentRecord, fmt.Sprintf("user_id: %s", userID))
+	}
+
+	return records, nil
+}
+
+// ListByUserID retrieves every record of an account, newest first
+func (r *ConsentRecordRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ConsentRecord, error) {
+	const op = "ListByUserID"
+
+	var records []*model.ConsentRecord
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&records).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityConsentRecord, fmt.Sprintf("user_id: %s", userID))
+	}
+
+	return records, nil
+}
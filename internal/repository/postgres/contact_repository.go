@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityContactDetail = "ContactDetail"
+	entityContactReveal = "ContactReveal"
+)
+
+// ContactDetailRepository implements repository.ContactDetailRepository for PostgreSQL
+type ContactDetailRepository struct {
+	db *gorm.DB
+}
+
+// NewContactDetailRepository creates a new ContactDetailRepository
+func NewContactDetailRepository(db *gorm.DB) repository.ContactDetailRepository {
+	return &ContactDetailRepository{
+		db: db,
+	}
+}
+
+// Upsert creates or replaces the contact details of a profile
+func (r *ContactDetailRepository) Upsert(ctx context.Context, detail *model.ContactDetail) error {
+	const op = "Upsert"
+
+	if detail.ProfileID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityContactDetail, "profile_id is required")
+	}
+
+	detail.UpdatedAt = time.Now()
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "profile_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"details_encrypted", "updated_at"}),
+		}).
+		Create(detail).Error
+	if err != nil {
+		return repository.NewError(err, op, entityContactDetail, fmt.Sprintf("profile_id: %s", detail.ProfileID))
+	}
+
+	return nil
+}
+
+// GetByProfileID retrieves the contact details of a profile
+func (r *ContactDetailRepository) GetByProfileID(ctx context.Context, profileID uuid.UUID) (*model.ContactDetail, error) {
+	const op = "GetByProfileID"
+
+	var detail model.ContactDetail
+	err := r.db.WithContext(ctx).Where("profile_id = ?", profileID).First(&detail).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityContactDetail, fmt.Sprintf("profile_id: %s", profileID))
+		}
+		return nil, repository.NewError(err, op, entityContactDetail, "")
+	}
+
+	return &detail, nil
+}
+
+// ContactRevealRepository implements repository.ContactRevealRepository for PostgreSQL
+type ContactRevealRepository struct {
+	db *gorm.DB
+}
+
+// NewContactRevealRepository creates a new ContactRevealRepository
+func NewContactRevealRepository(db *gorm.DB) repository.ContactRevealRepository {
+	return &ContactRevealRepository{
+		db: db,
+	}
+}
+
+// Record adds an audit record of a reveal request
+func (r *ContactRevealRepository) Record(ctx context.Context, reveal *model.ContactReveal) error {
+	const op = "Record"
+
+	if err := r.db.WithContext(ctx).Create(reveal).Error; err != nil {
+		return revealError(err, op)
+	}
+
+	return nil
+}
+
+// RecordWithinQuota records a successful reveal charged to the viewing account's quota unless
+// the quota is used up. A transaction-scoped advisory lock on the account serializes
+// concurrent reveals so that the quota can't be exceeded.
+func (r *ContactRevealRepository) RecordWithinQuota(
+	ctx context.Context,
+	reveal *model.ContactReveal,
+	since time.Time,
+	quota int,
+) (bool, error) {
+	const op = "RecordWithinQuota"
+
+	recorded := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", reveal.ViewerUserID.String()).Error; err != nil {
+			return err
+		}
+
+		used, err := countQuotaReveals(tx, reveal.ViewerUserID, since)
+		if err != nil {
+			return err
+		}
+		if used >= int64(quota) {
+			return nil
+		}
+
+		if err := tx.Create(reveal).Error; err != nil {
+			return err
+		}
+		recorded = true
+		return nil
+	})
+	if err != nil {
+		return false, revealError(err, op)
+	}
+
+	return recorded, nil
+}
+
+// GetRevealed retrieves the successful reveal of a profile's details to a viewer
+func (r *ContactRevealRepository) GetRevealed(ctx context.Context, viewerProfileID, profileID uuid.UUID) (*model.ContactReveal, error) {
+	const op = "GetRevealed"
+
+	var reveal model.ContactReveal
+	err := r.db.WithContext(ctx).
+		Where("viewer_profile_id = ? AND profile_id = ? AND outcome = ?", viewerProfileID, profileID, model.ContactRevealOutcomeRevealed).
+		First(&reveal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityContactReveal,
+				fmt.Sprintf("viewer_profile_id: %s, profile_id: %s", viewerProfileID, profileID))
+		}
+		return nil, repository.NewError(err, op, entityContactReveal, "")
+	}
+
+	return &reveal, nil
+}
+
+// CountQuotaReveals counts the successful reveals charged to an account's quota since the given time
+func (r *ContactRevealRepository) CountQuotaReveals(ctx context.Context, viewerUserID uuid.UUID, since time.Time) (int64, error) {
+	const op = "CountQuotaReveals"
+
+	count, err := countQuotaReveals(r.db.WithContext(ctx), viewerUserID, since)
+	if err != nil {
+		return 0, repository.NewError(err, op, entityContactReveal, "")
+	}
+
+	return count, nil
+}
+
+// countQuotaReveals counts successful quota reveals of an account's profiles since the given time
+func countQuotaReveals(db *gorm.DB, viewerUserID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := db.Model(&model.ContactReveal{}).
+		Where("viewer_user_id = ? AND outcome = ? AND basis = ? AND created_at >= ?",
+			viewerUserID, model.ContactRevealOutcomeRevealed, model.ContactRevealBasisQuota, since).
+		Count(&count).Error
+	return count, err
+}
+
+// revealError wraps an error from recording a reveal, mapping a repeated reveal to ErrDuplicateKey
+func revealError(err error, op string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_contact_reveal" {
+		return repository.NewError(repository.ErrDuplicateKey, op, entityContactReveal, "contact details already revealed")
+	}
+	return repository.NewError(err, op, entityContactReveal, "")
+}
@@ -0,0 +1,262 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityDataExport = "DataExport"
+)
+
+// dataExportColumns are the columns of an export other than its archive
+var dataExportColumns = []string{
+	"id", "user_id", "status", "archive_size", "error", "request_id",
+	"created_at", "started_at", "completed_at", "expires_at",
+}
+
+// DataExportRepository implements repository.DataExportRepository for PostgreSQL
+type DataExportRepository struct {
+	db *gorm.DB
+}
+
+// NewDataExportRepository creates a new DataExportRepository
+func NewDataExportRepository(db *gorm.DB) repository.DataExportRepository {
+	return &DataExportRepository{
+		db: db,
+	}
+}
+
+// Create queues an export
+func (r *DataExportRepository) Create(ctx context.Context, export *model.DataExport) error {
+	const op = "Create"
+
+	if export.UserID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityDataExport, "user_id is required")
+	}
+
+	if export.CreatedAt.IsZero() {
+		export.CreatedAt = time.Now()
+	}
+
+	if err := conn(ctx, r.db).Create(export).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_active_data_export" {
+			return repository.NewError(repository.ErrDuplicateKey, op, entityDataExport, "account already has an export in progress")
+		}
+		return repository.NewError(err, op, entityDataExport, fmt.Sprintf("user_id: %s", export.UserID))
+	}
+
+	return nil
+}
+
+// GetByID retrieves an export of an account, without its archive
+func (r *DataExportRepository) GetByID(ctx context.Context, userID, id uuid.UUID) (*model.DataExport, error) {
+	const op = "GetByID"
+
+	var export model.DataExport
+	err := conn(ctx, r.db).Select(dataExportColumns).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityDataExport, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityDataExport, "")
+	}
+
+	return &export, nil
+}
+
+// ListByUserID retrieves the exports of an account without their archives, newest first
+func (r *DataExportRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.DataExport, error) {
+	const op = "ListByUserID"
+
+	var exports []*model.DataExport
+	err := conn(ctx, r.db).Select(dataExportColumns).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&exports).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityDataExport, fmt.Sprintf("user_id: %s", userID))
+	}
+
+	return exports, nil
+}
+
+// GetArchive retrieves a completed export with its archive, if it hasn't expired at the given time
+func (r *DataExportRepository) GetArchive(ctx context.Context, id uuid.UUID, at time.Time) (*model.DataExport, error) {
+	const op = "GetArchive"
+
+	var export model.DataExport
+	err := conn(ctx, r.db).
+		Where("id = ? AND status = ? AND expires_at > ?", id, model.DataExportStatusCompleted, at).
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityDataExport, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityDataExport, "")
+	}
+
+	return &export, nil
+}
+
+// ClaimNext marks the oldest queued export running and returns it. Concurrent workers
+// skip the rows locked by each other, so an export is assembled by one worker at a time.
+func (r *DataExportRepository) ClaimNext(ctx context.Context, at, staleBefore time.Time) (*model.DataExport, error) {
+	const op = "ClaimNext"
+
+	var exports []*model.DataExport
+	err := conn(ctx, r.db).Raw(`
+		UPDATE data_exports SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM data_exports
+			WHERE status = ? OR (status = ? AND started_at < ?)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, status, archive_size, error, request_id,
+			created_at, started_at, completed_at, expires_at`,
+		model.DataExportStatusRunning, at,
+		model.DataExportStatusPending, model.DataExportStatusRunning, staleBefore,
+	).Scan(&exports).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityDataExport, "")
+	}
+
+	if len(exports) == 0 {
+		return nil, nil
+	}
+	return exports[0], nil
+}
+
+// Complete stores the archive of a running export
+func (r *DataExportRepository) Complete(
+	ctx context.Context,
+	id uuid.UUID,
+	archive []byte,
+	completedAt, expiresAt time.Time,
+) error {
+	const op = "Complete"
+
+	result := conn(ctx, r.db).Model(&model.DataExport{}).
+		Where("id = ? AND status = ?", id, model.DataExportStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       model.DataExportStatusCompleted,
+			"archive":      archive,
+			"archive_size": len(archive),
+			"completed_at": completedAt,
+			"expires_at":   expiresAt,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityDataExport, fmt.Sprintf("id: %s", id))
+	}
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityDataExport, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
+
+// Fail marks a running export failed with the given reason
+func (r *DataExportRepository) Fail(ctx context.Context, id uuid.UUID, reason string, at time.Time) error {
+	const op = "Fail"
+
+	result := conn(ctx, r.db).Model(&model.DataExport{}).
+		Where("id = ? AND status = ?", id, model.DataExportStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       model.DataExportStatusFailed,
+			"error":        reason,
+			"completed_at": at,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityDataExport, fmt.Sprintf("id: %s", id))
+	}
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityDataExport, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
+
+// ExpireArchives drops the archives of the exports expired at the given time
+func (r *DataExportRepository) ExpireArchives(ctx context.Context, at time.Time) (int64, error) {
+	const op = "ExpireArchives"
+
+	result := conn(ctx, r.db).Model(&model.DataExport{}).
+		Where("status = ? AND expires_at <= ?", model.DataExportStatusCompleted, at).
+		Updates(map[string]interface{}{
+			"status":  model.DataExportStatusExpired,
+			"archive": nil,
+		})
+	if result.Error != nil {
+		return 0, repository.NewError(result.Error, op, entityDataExport, "")
+	}
+
+	return result.RowsAffected, nil
+}
+
+// CollectUserData retrieves everything held about an account. The records are read
+// from a single snapshot of the database, so they are consistent with each other.
+func (r *DataExportRepository) CollectUserData(ctx context.Context, userID uuid.UUID) (*repository.UserData, error) {
+	const op = "CollectUserData"
+
+	data := &repository.UserData{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Order("created_at").Find(&data.Profiles).Error; err != nil {
+			return err
+		}
+
+		profileIDs := make([]uuid.UUID, len(data.Profiles))
+		for i, profile := range data.Profiles {
+			profileIDs[i] = profile.ID
+		}
+
+		queries := []struct {
+			dest  interface{}
+			where string
+			args  []interface{}
+			order string
+		}{
+			{&data.ContactDetails, "profile_id IN ?", []interface{}{profileIDs}, "created_at"},
+			{&data.Revisions, "profile_id IN ?", []interface{}{profileIDs}, "created_at"},
+			{&data.Preferences, "profile_id IN ?", []interface{}{profileIDs}, "created_at"},
+			{&data.Privacy, "profile_id IN ?", []interface{}{profileIDs}, "profile_id"},
+			{&data.Drafts, "user_id = ?", []interface{}{userID}, "created_at"},
+			{&data.Interests, "sender_profile_id IN ? OR receiver_profile_id IN ?", []interface{}{profileIDs, profileIDs}, "created_at"},
+			{&data.ViewsMade, "viewer_profile_id IN ?", []interface{}{profileIDs}, "created_at"},
+			{&data.ViewsReceived, "profile_id IN ?", []interface{}{profileIDs}, "created_at"},
+			{&data.ContactReveals, "viewer_user_id = ?", []interface{}{userID}, "created_at"},
+			{&data.Blocks, "profile_id IN ?", []interface{}{profileIDs}, "created_at"},
+			{&data.SavedSearches, "user_id = ?", []interface{}{userID}, "created_at"},
+			{&data.Managers, "profile_id IN ? OR manager_user_id = ?", []interface{}{profileIDs, userID}, "created_at"},
+			{&data.Transfers, "from_user_id = ? OR to_user_id = ?", []interface{}{userID, userID}, "created_at"},
+			{&data.ShareLinks, "profile_id IN ?", []interface{}{profileIDs}, "created_at"},
+			{&data.Boosts, "user_id = ?", []interface{}{userID}, "created_at"},
+			{&data.Subscriptions, "user_id = ?", []interface{}{userID}, "created_at"},
+			{&data.Consents, "user_id = ?", []interface{}{userID}, "created_at"},
+		}
+		for _, q := range queries {
+			if err := tx.Where(q.where, q.args...).Order(q.order).Find(q.dest).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, repository.NewError(err, op, entityDataExport, fmt.Sprintf("user_id: %s", userID))
+	}
+
+	return data, nil
+}
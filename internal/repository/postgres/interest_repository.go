@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityInterest = "Interest"
+)
+
+// InterestRepository implements repository.InterestRepository for PostgreSQL
+type InterestRepository struct {
+	db *gorm.DB
+}
+
+// NewInterestRepository creates a new InterestRepository
+func NewInterestRepository(db *gorm.DB) repository.InterestRepository {
+	return &InterestRepository{
+		db: db,
+	}
+}
+
+// Create adds a new interest to the database
+func (r *InterestRepository) Create(ctx context.Context, interest *model.Interest) error {
+	const op = "Create"
+
+	if interest.SenderProfileID == uuid.Nil || interest.ReceiverProfileID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityInterest, "sender and receiver are required")
+	}
+
+	err := r.db.WithContext(ctx).Create(interest).Error
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_interest" {
+			return repository.NewError(repository.ErrDuplicateKey, op, entityInterest, "interest already sent")
+		}
+		return repository.NewError(err, op, entityInterest, "")
+	}
+
+	return nil
+}
+
+// GetByID retrieves an interest by ID
+func (r *InterestRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Interest, error) {
+	const op = "GetByID"
+
+	var interest model.Interest
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&interest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityInterest, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityInterest, "")
+	}
+
+	return &interest, nil
+}
+
+// GetBetween retrieves the interest between two profiles, sent in either direction.
+// If both sent one, the accepted or most recent one is returned.
+func (r *InterestRepository) GetBetween(ctx context.Context, profileID, otherProfileID uuid.UUID) (*model.Interest, error) {
+	const op = "GetBetween"
+
+	var interest model.Interest
+	err := r.db.WithContext(ctx).
+		Where("(sender_profile_id = ? AND receiver_profile_id = ?) OR (sender_profile_id = ? AND receiver_profile_id = ?)",
+			profileID, otherProfileID, otherProfileID, profileID).
+		Order(fmt.Sprintf("status = '%s' DESC, created_at DESC", model.InterestStatusAccepted)).
+		First(&interest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityInterest,
+				fmt.Sprintf("profile_id: %s, other_profile_id: %s", profileID, otherProfileID))
+		}
+		return nil, repository.NewError(err, op, entityInterest, "")
+	}
+
+	return &interest, nil
+}
+
+// ListReceived retrieves interests received by a profile with pagination, newest first
+func (r *InterestRepository) ListReceived(
+	ctx context.Context,
+	profileID uuid.UUID,
+	status model.InterestStatus,
+	page,
+	limit int,
+) ([]*model.Interest, int64, error) {
+	return r.list(ctx, "ListReceived", "receiver_profile_id", profileID, status, page, limit)
+}
+
+// ListSent retrieves interests sent by a profile with pagination, newest first
+func (r *InterestRepository) ListSent(
+	ctx context.Context,
+	profileID uuid.UUID,
+	status model.InterestStatus,
+	page,
+	limit int,
+) ([]*model.Interest, int64, error) {
+	return r.list(ctx, "ListSent", "sender_profile_id", profileID, status, page, limit)
+}
+
+// list retrieves a page of interests where column matches the profile
+func (r *InterestRepository) list(
+	ctx context.Context,
+	op string,
+	column string,
+	profileID uuid.UUID,
+	status model.InterestStatus,
+	page,
+	limit int,
+) ([]*model.Interest, int64, error) {
+	var interests []*model.Interest
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&model.Interest{}).Where(column+" = ?", profileID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, repository.NewError(err, op, entityInterest, "count failed")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	err := query.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&interests).Error
+	if err != nil {
+		return nil, 0, repository.NewError(err, op, entityInterest, "")
+	}
+
+	return interests, total, nil
+}
+
+// Respond records the receiver's response to a pending interest
+func (r *InterestRepository) Respond(ctx context.Context, id uuid.UUID, status model.InterestStatus, respondedAt time.Time) error {
+	const op = "Respond"
+
+	result := r.db.WithContext(ctx).Model(&model.Interest{}).
+		Where("id = ? AND status = ?", id, model.InterestStatusPending).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": respondedAt,
+			"updated_at":   respondedAt,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityInterest, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityInterest, "interest is not pending")
+	}
+
+	return nil
+}
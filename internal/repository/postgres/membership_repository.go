@@ -0,0 +1,253 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityMembershipPlan = "MembershipPlan"
+	entitySubscription   = "Subscription"
+)
+
+// MembershipPlanRepository implements repository.MembershipPlanRepository for PostgreSQL
+type MembershipPlanRepository struct {
+	db *gorm.DB
+}
+
+// NewMembershipPlanRepository creates a new MembershipPlanRepository
+func NewMembershipPlanRepository(db *gorm.DB) repository.MembershipPlanRepository {
+	return &MembershipPlanRepository{
+		db: db,
+	}
+}
+
+// List retrieves the plans that can be subscribed to, cheapest first
+func (r *MembershipPlanRepository) List(ctx context.Context) ([]*model.MembershipPlan, error) {
+	const op = "List"
+
+	var plans []*model.MembershipPlan
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("price_paise, code").
+		Find(&plans).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityMembershipPlan, "")
+	}
+
+	return plans, nil
+}
+
+// GetByCode retrieves a plan by its code
+func (r *MembershipPlanRepository) GetByCode(ctx context.Context, code string) (*model.MembershipPlan, error) {
+	const op = "GetByCode"
+
+	var plan model.MembershipPlan
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&plan).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityMembershipPlan, fmt.Sprintf("code: %s", code))
+		}
+		return nil, repository.NewError(err, op, entityMembershipPlan, "")
+	}
+
+	return &plan, nil
+}
+
+// SubscriptionRepository implements repository.SubscriptionRepository for PostgreSQL
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewSubscriptionRepository creates a new SubscriptionRepository
+func NewSubscriptionRepository(db *gorm.DB) repository.SubscriptionRepository {
+	return &SubscriptionRepository{
+		db: db,
+	}
+}
+
+// Create adds a new subscription to the database
+func (r *SubscriptionRepository) Create(ctx context.Context, subscription *model.Subscription) error {
+	const op = "Create"
+
+	if subscription.UserID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entitySubscription, "user_id is required")
+	}
+
+	if err := r.db.WithContext(ctx).Create(subscription).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return repository.NewError(repository.ErrDuplicateKey, op, entitySubscription,
+				fmt.Sprintf("provider reference %s already used", subscription.ProviderReference))
+		}
+		return repository.NewError(err, op, entitySubscription, "")
+	}
+
+	return nil
+}
+
+// GetByReference retrieves a subscription by its payment provider reference
+func (r *SubscriptionRepository) GetByReference(ctx context.Context, provider, reference string) (*model.Subscription, error) {
+	const op = "GetByReference"
+
+	var subscription model.Subscription
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND provider_reference = ?", provider, reference).
+		First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entitySubscription,
+				fmt.Sprintf("provider: %s, reference: %s", provider, reference))
+		}
+		return nil, repository.NewError(err, op, entitySubscription, "")
+	}
+
+	return &subscription, nil
+}
+
+// GetCurrent retrieves the active subscription covering the given time, with its plan
+func (r *SubscriptionRepository) GetCurrent(ctx context.Context, userID uuid.UUID, at time.Time) (*model.Subscription, error) {
+	const op = "GetCurrent"
+
+	var subscription model.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Plan").
+		Where("user_id = ? AND status = ? AND starts_at <= ? AND expires_at > ?",
+			userID, model.SubscriptionStatusActive, at, at).
+		Order("expires_at DESC").
+		First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entitySubscription, fmt.Sprintf("user_id: %s", userID))
+		}
+		return nil, repository.NewError(err, op, entitySubscription, "")
+	}
+
+	return &subscription, nil
+}
+
+// Activate records a payment event and activates a pending subscription. Activations of
+// the same user are serialized so that a renewal bought before the current subscription
+// expires starts when it ends.
+func (r *SubscriptionRepository) Activate(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	event *model.PaymentEvent,
+) (*model.Subscription, error) {
+	const op = "Activate"
+
+	var subscription model.Subscription
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+
+		err := tx.Preload("Plan").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", subscriptionID).
+			First(&subscription).Error
+		if err != nil {
+			return err
+		}
+		if subscription.Status != model.SubscriptionStatusPending {
+			return repository.ErrInvalidOperation
+		}
+
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", subscription.UserID.String()).Error; err != nil {
+			return err
+		}
+
+		var lastExpiry *time.Time
+		err = tx.Model(&model.Subscription{}).
+			Select("MAX(expires_at)").
+			Where("user_id = ? AND status = ?", subscription.UserID, model.SubscriptionStatusActive).
+			Scan(&lastExpiry).Error
+		if err != nil {
+			return err
+		}
+
+		startsAt := time.Now().UTC()
+		if lastExpiry != nil && lastExpiry.After(startsAt) {
+			startsAt = *lastExpiry
+		}
+		expiresAt := startsAt.AddDate(0, 0, subscription.Plan.DurationDays)
+
+		subscription.Status = model.SubscriptionStatusActive
+		subscription.StartsAt = &startsAt
+		subscription.ExpiresAt = &expiresAt
+
+		return tx.Model(&model.Subscription{}).
+			Where("id = ?", subscription.ID).
+			Updates(map[string]interface{}{
+				"status":     subscription.Status,
+				"starts_at":  startsAt,
+				"expires_at": expiresAt,
+				"updated_at": time.Now(),
+			}).Error
+	})
+	if err != nil {
+		return nil, subscriptionError(err, op, subscriptionID)
+	}
+
+	return &subscription, nil
+}
+
+// SetStatus records a payment event and changes the status of a subscription
+func (r *SubscriptionRepository) SetStatus(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	status model.SubscriptionStatus,
+	event *model.PaymentEvent,
+) error {
+	const op = "SetStatus"
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.Subscription{}).
+			Where("id = ?", subscriptionID).
+			Updates(map[string]interface{}{
+				"status":     status,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return subscriptionError(err, op, subscriptionID)
+	}
+
+	return nil
+}
+
+// subscriptionError wraps an error from applying a payment event to a subscription,
+// mapping a redelivered event to ErrDuplicateKey
+func subscriptionError(err error, op string, subscriptionID uuid.UUID) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_payment_event":
+		return repository.NewError(repository.ErrDuplicateKey, op, entitySubscription, "payment event already processed")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return repository.NewError(repository.ErrNotFound, op, entitySubscription, fmt.Sprintf("id: %s", subscriptionID))
+	case errors.Is(err, repository.ErrInvalidOperation):
+		return repository.NewError(repository.ErrInvalidOperation, op, entitySubscription, "subscription is not pending")
+	}
+	return repository.NewError(err, op, entitySubscription, "")
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityPartnerPreference = "PartnerPreference"
+)
+
+// PartnerPreferenceRepository implements repository.PartnerPreferenceRepository for PostgreSQL
+type PartnerPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewPartnerPreferenceRepository creates a new PartnerPreferenceRepository
+func NewPartnerPreferenceRepository(db *gorm.DB) repository.PartnerPreferenceRepository {
+	return &PartnerPreferenceRepository{
+		db: db,
+	}
+}
+
+// Upsert creates or replaces the preferences of a profile
+func (r *PartnerPreferenceRepository) Upsert(ctx context.Context, preference *model.PartnerPreference) error {
+	const op = "Upsert"
+
+	if preference.ProfileID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityPartnerPreference, "profile_id is required")
+	}
+
+	preference.UpdatedAt = time.Now()
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "profile_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"criteria", "updated_at"}),
+		}).
+		Create(preference).Error
+	if err != nil {
+		return repository.NewError(err, op, entityPartnerPreference, fmt.Sprintf("profile_id: %s", preference.ProfileID))
+	}
+
+	return nil
+}
+
+// GetByProfileID retrieves the preferences of a profile
+func (r *PartnerPreferenceRepository) GetByProfileID(ctx context.Context, profileID uuid.UUID) (*model.PartnerPreference, error) {
+	const op = "GetByProfileID"
+
+	var preference model.PartnerPreference
+	err := r.db.WithContext(ctx).Where("profile_id = ?", profileID).First(&preference).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityPartnerPreference, fmt.Sprintf("profile_id: %s", profileID))
+		}
+		return nil, repository.NewError(err, op, entityPartnerPreference, "")
+	}
+
+	return &preference, nil
+}
+
+// ListByProfileIDs retrieves the preferences of several profiles
+func (r *PartnerPreferenceRepository) ListByProfileIDs(ctx context.Context, profileIDs []uuid.UUID) ([]*model.PartnerPreference, error) {
+	const op = "ListByProfileIDs"
+
+	if len(profileIDs) == 0 {
+		return nil, nil
+	}
+
+	var preferences []*model.PartnerPreference
+	err := r.db.WithContext(ctx).Where("profile_id IN ?", profileIDs).Find(&preferences).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityPartnerPreference, "")
+	}
+
+	return preferences, nil
+}
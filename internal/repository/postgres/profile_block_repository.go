@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityProfileBlock = "ProfileBlock"
+)
+
+// ProfileBlockRepository implements repository.ProfileBlockRepository for PostgreSQL
+type ProfileBlockRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileBlockRepository creates a new ProfileBlockRepository
+func NewProfileBlockRepository(db *gorm.DB) repository.ProfileBlockRepository {
+	return &ProfileBlockRepository{
+		db: db,
+	}
+}
+
+// Create blocks a profile; blocking an already blocked profile is not an error
+func (r *ProfileBlockRepository) Create(ctx context.Context, block *model.ProfileBlock) error {
+	const op = "Create"
+
+	if block.ProfileID == uuid.Nil || block.BlockedProfileID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileBlock, "profile_id and blocked_profile_id are required")
+	}
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(block).Error
+	if err != nil {
+		return repository.NewError(err, op, entityProfileBlock, "")
+	}
+
+	return nil
+}
+
+// Delete removes a block
+func (r *ProfileBlockRepository) Delete(ctx context.Context, profileID, blockedProfileID uuid.UUID) error {
+	const op = "Delete"
+
+	result := r.db.WithContext(ctx).
+		Where("profile_id = ? AND blocked_profile_id = ?", profileID, blockedProfileID).
+		Delete(&model.ProfileBlock{})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileBlock, "")
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityProfileBlock,
+			fmt.Sprintf("profile_id: %s, blocked_profile_id: %s", profileID, blockedProfileID))
+	}
+
+	return nil
+}
+
+// ListByProfileID retrieves the blocks made by a profile, newest first
+func (r *ProfileBlockRepository) ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileBlock, error) {
+	const op = "ListByProfileID"
+
+	var blocks []*model.ProfileBlock
+	err := r.db.WithContext(ctx).
+		Where("profile_id = ?", profileID).
+		Order("created_at DESC").
+		Find(&blocks).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileBlock, "")
+	}
+
+	return blocks, nil
+}
+
+// ListRelatedProfileIDs retrieves the IDs of profiles blocked by, or blocking, a profile
+func (r *ProfileBlockRepository) ListRelatedProfileIDs(ctx context.Context, profileID uuid.UUID) ([]uuid.UUID, error) {
+	const op = "ListRelatedProfileIDs"
+
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT blocked_profile_id FROM profile_blocks WHERE profile_id = ? "+
+			"UNION SELECT profile_id FROM profile_blocks WHERE blocked_profile_id = ?",
+		profileID, profileID,
+	).Scan(&ids).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileBlock, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return ids, nil
+}
+
+// IsBlockedForAccount reports whether a profile has blocked, or was blocked by, any profile of an account
+func (r *ProfileBlockRepository) IsBlockedForAccount(ctx context.Context, userID, profileID uuid.UUID) (bool, error) {
+	const op = "IsBlockedForAccount"
+
+	var blocked bool
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT EXISTS (SELECT 1 FROM profile_blocks pb "+
+			"JOIN user_profiles own ON own.id IN (pb.profile_id, pb.blocked_profile_id) "+
+			"WHERE own.user_id = ? AND own.id <> ? AND ? IN (pb.profile_id, pb.blocked_profile_id))",
+		userID, profileID, profileID,
+	).Scan(&blocked).Error
+	if err != nil {
+		return false, repository.NewError(err, op, entityProfileBlock, fmt.Sprintf("user_id: %s, profile_id: %s", userID, profileID))
+	}
+
+	return blocked, nil
+}
+
+// excludeBlocked removes profiles from the query that have blocked, or were
+// blocked by, the given profile. column is the profile ID column of the query.
+func excludeBlocked(query *gorm.DB, column string, profileID uuid.UUID) *gorm.DB {
+	return query.Where(fmt.Sprintf(
+		"NOT EXISTS (SELECT 1 FROM profile_blocks pb WHERE "+
+			"(pb.profile_id = ? AND pb.blocked_profile_id = %[1]s) OR (pb.profile_id = %[1]s AND pb.blocked_profile_id = ?))",
+		column), profileID, profileID)
+}
+
+// excludeBlockedForAccount removes profiles from the query that have blocked, or were
+// blocked by, any profile of the given account. column is the qualified profile ID
+// column of the query.
+func excludeBlockedForAccount(query *gorm.DB, column string, userID uuid.UUID) *gorm.DB {
+	return query.Where(fmt.Sprintf(
+		"NOT EXISTS (SELECT 1 FROM profile_blocks pb "+
+			"JOIN user_profiles own ON own.id IN (pb.profile_id, pb.blocked_profile_id) "+
+			"WHERE own.user_id = ? AND own.id <> %[1]s AND %[1]s IN (pb.profile_id, pb.blocked_profile_id))",
+		column), userID)
+}
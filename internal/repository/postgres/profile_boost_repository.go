@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityProfileBoost = "ProfileBoost"
+)
+
+// ProfileBoostRepository implements repository.ProfileBoostRepository for PostgreSQL
+type ProfileBoostRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileBoostRepository creates a new ProfileBoostRepository
+func NewProfileBoostRepository(db *gorm.DB) repository.ProfileBoostRepository {
+	return &ProfileBoostRepository{
+		db: db,
+	}
+}
+
+// boostedSearchRow is a search row of a boosted profile with the boost that placed it
+type boostedSearchRow struct {
+	profileSearchRow
+	BoostID uuid.UUID
+}
+
+// CreateWithinQuota adds a boost unless the user's quota is used up. A transaction-scoped
+// advisory lock on the user serializes concurrent boosts so that the quota can't be exceeded.
+func (r *ProfileBoostRepository) CreateWithinQuota(
+	ctx context.Context,
+	boost *model.ProfileBoost,
+	since time.Time,
+	quota int,
+) (bool, error) {
+	const op = "CreateWithinQuota"
+
+	if boost.ProfileID == uuid.Nil || boost.UserID == uuid.Nil {
+		return false, repository.NewError(repository.ErrInvalidOperation, op, entityProfileBoost, "profile_id and user_id are required")
+	}
+
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", boost.UserID.String()).Error; err != nil {
+			return err
+		}
+
+		var overlapping int64
+		err := tx.Model(&model.ProfileBoost{}).
+			Where("profile_id = ? AND starts_at < ? AND ends_at > ?", boost.ProfileID, boost.EndsAt, boost.StartsAt).
+			Count(&overlapping).Error
+		if err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return repository.ErrDuplicateKey
+		}
+
+		var used int64
+		err = tx.Model(&model.ProfileBoost{}).
+			Where("user_id = ? AND created_at >= ?", boost.UserID, since).
+			Count(&used).Error
+		if err != nil {
+			return err
+		}
+		if used >= int64(quota) {
+			return nil
+		}
+
+		if err := tx.Create(boost).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return false, repository.NewError(repository.ErrDuplicateKey, op, entityProfileBoost, "profile already has a boost for this period")
+		}
+		return false, repository.NewError(err, op, entityProfileBoost, "")
+	}
+
+	return created, nil
+}
+
+// ListByProfileID retrieves the boosts of a profile, newest first
+func (r *ProfileBoostRepository) ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileBoost, error) {
+	const op = "ListByProfileID"
+
+	var boosts []*model.ProfileBoost
+	err := r.db.WithContext(ctx).
+		Where("profile_id = ?", profileID).
+		Order("starts_at DESC").
+		Find(&boosts).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileBoost, "")
+	}
+
+	return boosts, nil
+}
+
+// SearchBoosted retrieves boosted profiles matching the filter. The filtered search is
+// run as a subquery so that its unqualified columns don't clash with profile_boosts.
+func (r *ProfileBoostRepository) SearchBoosted(
+	ctx context.Context,
+	filter repository.ProfileFilter,
+	at time.Time,
+	limit int,
+) ([]*repository.ProfileSearchHit, error) {
+	const op = "SearchBoosted"
+
+	matching := selectSearchColumns(applyProfileFilter(r.db.Model(&model.UserProfile{}), filter), filter)
+
+	var rows []*boostedSearchRow
+	err := r.db.WithContext(ctx).
+		Table("(?) AS p", matching).
+		Select("p.*, b.id AS boost_id").
+		Joins("JOIN profile_boosts b ON b.profile_id = p.id AND b.starts_at <= ? AND b.ends_at > ?", at, at).
+		Order("b.impressions, random()").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileBoost, "")
+	}
+
+	hits := make([]*repository.ProfileSearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = &repository.ProfileSearchHit{
+			Profile:    &row.UserProfile,
+			Rank:       row.SearchRank,
+			Highlights: splitHeadline(row.SearchHeadline),
+			DistanceKm: row.DistanceKm,
+			BoostID:    &row.BoostID,
+		}
+	}
+
+	return hits, nil
+}
+
+// CountBoosted counts the boosted profiles matching the filter. A profile has at most one
+// boost at a time, so each joined row is a distinct profile.
+func (r *ProfileBoostRepository) CountBoosted(ctx context.Context, filter repository.ProfileFilter, at time.Time) (int64, error) {
+	const op = "CountBoosted"
+
+	matching := applyProfileFilter(r.db.Model(&model.UserProfile{}), filter).Select("user_profiles.id")
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("(?) AS p", matching).
+		Joins("JOIN profile_boosts b ON b.profile_id = p.id AND b.starts_at <= ? AND b.ends_at > ?", at, at).
+		Count(&count).Error
+	if err != nil {
+		return 0, repository.NewError(err, op, entityProfileBoost, "")
+	}
+
+	return count, nil
+}
+
+// RecordImpressions counts one impression for each of the boosts
+func (r *ProfileBoostRepository) RecordImpressions(ctx context.Context, boostIDs []uuid.UUID) error {
+	const op = "RecordImpressions"
+
+	if len(boostIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&model.ProfileBoost{}).
+		Where("id IN ?", boostIDs).
+		UpdateColumn("impressions", gorm.Expr("impressions + 1")).Error
+	if err != nil {
+		return repository.NewError(err, op, entityProfileBoost, "")
+	}
+
+	return nil
+}
+
+// RecordClick counts a click for a boost of the profile active at the given time, once
+// per viewer. The viewer's click is recorded first and the counter is only bumped if it
+// is new, in the same transaction.
+func (r *ProfileBoostRepository) RecordClick(ctx context.Context, boostID, profileID, viewerUserID uuid.UUID, at time.Time) error {
+	const op = "RecordClick"
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`INSERT INTO profile_boost_clicks (boost_id, viewer_user_id, created_at)
+			SELECT id, ?, ? FROM profile_boosts
+			WHERE id = ? AND profile_id = ? AND starts_at <= ? AND ends_at > ?
+			ON CONFLICT DO NOTHING`,
+			viewerUserID, at, boostID, profileID, at, at)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Model(&model.ProfileBoost{}).
+			Where("id = ?", boostID).
+			UpdateColumn("clicks", gorm.Expr("clicks + 1")).Error
+	})
+	if err != nil {
+		return repository.NewError(err, op, entityProfileBoost, "")
+	}
+
+	return nil
+}
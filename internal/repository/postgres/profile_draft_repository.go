@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityProfileDraft = "ProfileDraft"
+)
+
+// ProfileDraftRepository implements repository.ProfileDraftRepository for PostgreSQL
+type ProfileDraftRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileDraftRepository creates a new ProfileDraftRepository
+func NewProfileDraftRepository(db *gorm.DB) repository.ProfileDraftRepository {
+	return &ProfileDraftRepository{
+		db: db,
+	}
+}
+
+// SaveStep merges the fields of a step into the account's draft. The draft's Data holds
+// only the step's fields; on return the draft is the merged row.
+func (r *ProfileDraftRepository) SaveStep(ctx context.Context, draft *model.ProfileDraft) error {
+	const op = "SaveStep"
+
+	if draft.UserID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileDraft, "user_id is required")
+	}
+
+	now := time.Now()
+	draft.CreatedAt = now
+	draft.UpdatedAt = now
+
+	// jsonb || merges the top-level keys, keeping the fields of the other steps
+	err := conn(ctx, r.db).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"data":       gorm.Expr("profile_drafts.data || EXCLUDED.data"),
+					"last_step":  gorm.Expr("EXCLUDED.last_step"),
+					"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+				}),
+			},
+			clause.Returning{},
+		).
+		Create(draft).Error
+	if err != nil {
+		return repository.NewError(err, op, entityProfileDraft, fmt.Sprintf("user_id: %s", draft.UserID))
+	}
+
+	return nil
+}
+
+// GetByUserID retrieves the draft of an account
+func (r *ProfileDraftRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*model.ProfileDraft, error) {
+	const op = "GetByUserID"
+
+	var draft model.ProfileDraft
+	err := conn(ctx, r.db).Where("user_id = ?", userID).First(&draft).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityProfileDraft, fmt.Sprintf("user_id: %s", userID))
+		}
+		return nil, repository.NewError(err, op, entityProfileDraft, "")
+	}
+
+	return &draft, nil
+}
+
+// Delete removes a draft
+func (r *ProfileDraftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "Delete"
+
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&model.ProfileDraft{})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileDraft, fmt.Sprintf("id: %s", id))
+	}
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityProfileDraft, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
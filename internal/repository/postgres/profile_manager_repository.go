@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityProfileManager = "ProfileManager"
+)
+
+// ProfileManagerRepository implements repository.ProfileManagerRepository for PostgreSQL
+type ProfileManagerRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileManagerRepository creates a new ProfileManagerRepository
+func NewProfileManagerRepository(db *gorm.DB) repository.ProfileManagerRepository {
+	return &ProfileManagerRepository{
+		db: db,
+	}
+}
+
+// Create adds a new invitation to manage a profile
+func (r *ProfileManagerRepository) Create(ctx context.Context, manager *model.ProfileManager) error {
+	const op = "Create"
+
+	if manager.ProfileID == uuid.Nil || manager.ManagerUserID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileManager, "profile_id and manager_user_id are required")
+	}
+
+	err := conn(ctx, r.db).Create(manager).Error
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_profile_manager" {
+			return repository.NewError(repository.ErrDuplicateKey, op, entityProfileManager, "user already manages or is invited to manage the profile")
+		}
+		return repository.NewError(err, op, entityProfileManager, "")
+	}
+
+	return nil
+}
+
+// GetByID retrieves a delegation by its ID
+func (r *ProfileManagerRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.ProfileManager, error) {
+	const op = "GetByID"
+
+	var manager model.ProfileManager
+	err := conn(ctx, r.db).Where("id = ?", id).First(&manager).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityProfileManager, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityProfileManager, "")
+	}
+
+	return &manager, nil
+}
+
+// GetActive retrieves the accepted delegation of a user for a profile
+func (r *ProfileManagerRepository) GetActive(ctx context.Context, profileID, managerUserID uuid.UUID) (*model.ProfileManager, error) {
+	const op = "GetActive"
+
+	var manager model.ProfileManager
+	err := conn(ctx, r.db).
+		Where("profile_id = ? AND manager_user_id = ? AND status = ?", profileID, managerUserID, model.ProfileManagerStatusActive).
+		First(&manager).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityProfileManager,
+				fmt.Sprintf("profile_id: %s, manager_user_id: %s", profileID, managerUserID))
+		}
+		return nil, repository.NewError(err, op, entityProfileManager, "")
+	}
+
+	return &manager, nil
+}
+
+// ListByProfileID retrieves the pending and active delegations of a profile, oldest first
+func (r *ProfileManagerRepository) ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileManager, error) {
+	const op = "ListByProfileID"
+
+	var managers []*model.ProfileManager
+	err := conn(ctx, r.db).
+		Where("profile_id = ? AND status <> ?", profileID, model.ProfileManagerStatusRevoked).
+		Order("created_at").
+		Find(&managers).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileManager, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return managers, nil
+}
+
+// ListByManagerUserID retrieves the pending and active delegations of a user with their profiles
+func (r *ProfileManagerRepository) ListByManagerUserID(ctx context.Context, managerUserID uuid.UUID) ([]*model.ProfileManager, error) {
+	const op = "ListByManagerUserID"
+
+	var managers []*model.ProfileManager
+	err := conn(ctx, r.db).
+		Joins("Profile").
+		Where("profile_managers.manager_user_id = ? AND profile_managers.status <> ?", managerUserID, model.ProfileManagerStatusRevoked).
+		Order("profile_managers.created_at").
+		Find(&managers).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileManager, fmt.Sprintf("manager_user_id: %s", managerUserID))
+	}
+
+	return managers, nil
+}
+
+// UpdateScopes replaces the scopes of a pending or active delegation
+func (r *ProfileManagerRepository) UpdateScopes(ctx context.Context, manager *model.ProfileManager) error {
+	const op = "UpdateScopes"
+
+	result := conn(ctx, r.db).Model(&model.ProfileManager{}).
+		Where("id = ? AND status <> ?", manager.ID, model.ProfileManagerStatusRevoked).
+		Updates(map[string]interface{}{
+			"can_edit":              manager.CanEdit,
+			"can_respond_interests": manager.CanRespondInterests,
+			"can_view_contacts":     manager.CanViewContacts,
+			"can_delete":            manager.CanDelete,
+			"updated_at":            time.Now(),
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileManager, fmt.Sprintf("id: %s", manager.ID))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityProfileManager, fmt.Sprintf("id: %s", manager.ID))
+	}
+
+	return nil
+}
+
+// Accept activates a pending invitation
+func (r *ProfileManagerRepository) Accept(ctx context.Context, id uuid.UUID, at time.Time) error {
+	const op = "Accept"
+
+	result := conn(ctx, r.db).Model(&model.ProfileManager{}).
+		Where("id = ? AND status = ?", id, model.ProfileManagerStatusInvited).
+		Updates(map[string]interface{}{
+			"status":      model.ProfileManagerStatusActive,
+			"accepted_at": at,
+			"updated_at":  at,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileManager, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileManager, "invitation is not pending")
+	}
+
+	return nil
+}
+
+// Revoke ends a pending or active delegation
+func (r *ProfileManagerRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	const op = "Revoke"
+
+	result := conn(ctx, r.db).Model(&model.ProfileManager{}).
+		Where("id = ? AND status <> ?", id, model.ProfileManagerStatusRevoked).
+		Updates(map[string]interface{}{
+			"status":     model.ProfileManagerStatusRevoked,
+			"revoked_at": at,
+			"updated_at": at,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileManager, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileManager, "delegation is already revoked")
+	}
+
+	return nil
+}
+
+// RevokeAllForProfile ends every pending or active delegation of a profile
+func (r *ProfileManagerRepository) RevokeAllForProfile(ctx context.Context, profileID uuid.UUID, at time.Time) (int64, error) {
+	const op = "RevokeAllForProfile"
+
+	result := conn(ctx, r.db).Model(&model.ProfileManager{}).
+		Where("profile_id = ? AND status <> ?", profileID, model.ProfileManagerStatusRevoked).
+		Updates(map[string]interface{}{
+			"status":     model.ProfileManagerStatusRevoked,
+			"revoked_at": at,
+			"updated_at": at,
+		})
+	if result.Error != nil {
+		return 0, repository.NewError(result.Error, op, entityProfileManager, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return result.RowsAffected, nil
+}
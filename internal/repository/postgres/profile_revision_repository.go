@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityProfileRevision = "ProfileRevision"
+)
+
+// ProfileRevisionRepository implements repository.ProfileRevisionRepository for PostgreSQL
+type ProfileRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileRevisionRepository creates a new ProfileRevisionRepository
+func NewProfileRevisionRepository(db *gorm.DB) repository.ProfileRevisionRepository {
+	return &ProfileRevisionRepository{
+		db: db,
+	}
+}
+
+// Create records a revision
+func (r *ProfileRevisionRepository) Create(ctx context.Context, revision *model.ProfileRevision) error {
+	const op = "Create"
+
+	if revision.ProfileID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileRevision, "profile_id is required")
+	}
+
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	if err := conn(ctx, r.db).Create(revision).Error; err != nil {
+		return repository.NewError(err, op, entityProfileRevision, fmt.Sprintf("profile_id: %s", revision.ProfileID))
+	}
+
+	return nil
+}
+
+// GetByID retrieves a revision of a profile
+func (r *ProfileRevisionRepository) GetByID(ctx context.Context, profileID, id uuid.UUID) (*model.ProfileRevision, error) {
+	const op = "GetByID"
+
+	var revision model.ProfileRevision
+	err := conn(ctx, r.db).Where("id = ? AND profile_id = ?", id, profileID).First(&revision).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityProfileRevision, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityProfileRevision, "")
+	}
+
+	return &revision, nil
+}
+
+// ListByProfileID retrieves the revisions of a profile, most recent first
+func (r *ProfileRevisionRepository) ListByProfileID(
+	ctx context.Context,
+	profileID uuid.UUID,
+	page, limit int,
+) ([]*model.ProfileRevision, int64, error) {
+	const op = "ListByProfileID"
+
+	query := conn(ctx, r.db).Model(&model.ProfileRevision{}).Where("profile_id = ?", profileID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, repository.NewError(err, op, entityProfileRevision, "")
+	}
+
+	var revisions []*model.ProfileRevision
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&revisions).Error
+	if err != nil {
+		return nil, 0, repository.NewError(err, op, entityProfileRevision, "")
+	}
+
+	return revisions, total, nil
+}
+
+// ListAfter retrieves the revisions of a profile made after the given one, most recent first
+func (r *ProfileRevisionRepository) ListAfter(ctx context.Context, revision *model.ProfileRevision) ([]*model.ProfileRevision, error) {
+	const op = "ListAfter"
+
+	var revisions []*model.ProfileRevision
+	err := conn(ctx, r.db).
+		Where("profile_id = ? AND created_at > ?", revision.ProfileID, revision.CreatedAt).
+		Order("created_at DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileRevision, fmt.Sprintf("id: %s", revision.ID))
+	}
+
+	return revisions, nil
+}
+
+// ListFlagged retrieves flagged revisions of all profiles, most recent first
+func (r *ProfileRevisionRepository) ListFlagged(ctx context.Context, page, limit int) ([]*model.ProfileRevision, int64, error) {
+	const op = "ListFlagged"
+
+	query := conn(ctx, r.db).Model(&model.ProfileRevision{}).Where("flagged")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, repository.NewError(err, op, entityProfileRevision, "")
+	}
+
+	var revisions []*model.ProfileRevision
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&revisions).Error
+	if err != nil {
+		return nil, 0, repository.NewError(err, op, entityProfileRevision, "")
+	}
+
+	return revisions, total, nil
+}
+
+// CountFieldUpdates counts the updates of a profile since the given time that changed a field
+func (r *ProfileRevisionRepository) CountFieldUpdates(
+	ctx context.Context,
+	profileID uuid.UUID,
+	field string,
+	since time.Time,
+) (int64, error) {
+	const op = "CountFieldUpdates"
+
+	var count int64
+	err := conn(ctx, r.db).Model(&model.ProfileRevision{}).
+		Where("profile_id = ? AND action = ? AND created_at >= ?", profileID, model.ProfileRevisionActionUpdate, since).
+		Where("changes -> ? IS NOT NULL", field).
+		Count(&count).Error
+	if err != nil {
+		return 0, repository.NewError(err, op, entityProfileRevision, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return count, nil
+}
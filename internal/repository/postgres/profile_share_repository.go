@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityProfilePrivacy   = "ProfilePrivacySettings"
+	entityProfileShareLink = "ProfileShareLink"
+)
+
+// ProfilePrivacyRepository implements repository.ProfilePrivacyRepository for PostgreSQL
+type ProfilePrivacyRepository struct {
+	db *gorm.DB
+}
+
+// NewProfilePrivacyRepository creates a new ProfilePrivacyRepository
+func NewProfilePrivacyRepository(db *gorm.DB) repository.ProfilePrivacyRepository {
+	return &ProfilePrivacyRepository{
+		db: db,
+	}
+}
+
+// GetByProfileID retrieves the privacy settings of a profile, or the defaults if it has none
+func (r *ProfilePrivacyRepository) GetByProfileID(ctx context.Context, profileID uuid.UUID) (*model.ProfilePrivacySettings, error) {
+	const op = "GetByProfileID"
+
+	var settings model.ProfilePrivacySettings
+	err := conn(ctx, r.db).Where("profile_id = ?", profileID).First(&settings).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.DefaultProfilePrivacySettings(profileID), nil
+		}
+		return nil, repository.NewError(err, op, entityProfilePrivacy, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return &settings, nil
+}
+
+// Upsert creates or replaces the privacy settings of a profile
+func (r *ProfilePrivacyRepository) Upsert(ctx context.Context, settings *model.ProfilePrivacySettings) error {
+	const op = "Upsert"
+
+	if settings.ProfileID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfilePrivacy, "profile_id is required")
+	}
+
+	settings.UpdatedAt = time.Now()
+
+	err := conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "profile_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"show_photo", "show_date_of_birth", "show_residence", "show_education",
+				"show_occupation", "show_about_me", "updated_by", "updated_at",
+			}),
+		}).
+		Create(settings).Error
+	if err != nil {
+		return repository.NewError(err, op, entityProfilePrivacy, fmt.Sprintf("profile_id: %s", settings.ProfileID))
+	}
+
+	return nil
+}
+
+// ProfileShareLinkRepository implements repository.ProfileShareLinkRepository for PostgreSQL
+type ProfileShareLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileShareLinkRepository creates a new ProfileShareLinkRepository
+func NewProfileShareLinkRepository(db *gorm.DB) repository.ProfileShareLinkRepository {
+	return &ProfileShareLinkRepository{
+		db: db,
+	}
+}
+
+// Create records a new share link
+func (r *ProfileShareLinkRepository) Create(ctx context.Context, link *model.ProfileShareLink) error {
+	const op = "Create"
+
+	if link.ProfileID == uuid.Nil || link.CreatedBy == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileShareLink, "profile_id and created_by are required")
+	}
+
+	if err := conn(ctx, r.db).Create(link).Error; err != nil {
+		return repository.NewError(err, op, entityProfileShareLink, fmt.Sprintf("profile_id: %s", link.ProfileID))
+	}
+
+	return nil
+}
+
+// GetByID retrieves a share link by its ID
+func (r *ProfileShareLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.ProfileShareLink, error) {
+	const op = "GetByID"
+
+	var link model.ProfileShareLink
+	err := conn(ctx, r.db).Where("id = ?", id).First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityProfileShareLink, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityProfileShareLink, "")
+	}
+
+	return &link, nil
+}
+
+// ListByProfileID retrieves the share links of a profile, newest first
+func (r *ProfileShareLinkRepository) ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileShareLink, error) {
+	const op = "ListByProfileID"
+
+	var links []*model.ProfileShareLink
+	err := conn(ctx, r.db).
+		Where("profile_id = ?", profileID).
+		Order("created_at DESC").
+		Find(&links).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileShareLink, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return links, nil
+}
+
+// Revoke disables a share link that isn't revoked yet
+func (r *ProfileShareLinkRepository) Revoke(ctx context.Context, id uuid.UUID, revokedBy uuid.UUID, at time.Time) error {
+	const op = "Revoke"
+
+	result := conn(ctx, r.db).Model(&model.ProfileShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": at,
+			"revoked_by": revokedBy,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileShareLink, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileShareLink, "share link is already revoked")
+	}
+
+	return nil
+}
+
+// RecordView counts a view of an active share link in the same statement that checks
+// it is active, so a link revoked concurrently is never shown. Links of deleted profiles
+// are inactive, and work again if the profile is restored before they expire.
+func (r *ProfileShareLinkRepository) RecordView(ctx context.Context, id uuid.UUID, at time.Time) (*model.ProfileShareLink, error) {
+	const op = "RecordView"
+
+	var link model.ProfileShareLink
+	result := conn(ctx, r.db).Model(&link).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ?", id, at).
+		Where("EXISTS (SELECT 1 FROM user_profiles p WHERE p.id = profile_share_links.profile_id AND p.deleted_at IS NULL)").
+		Updates(map[string]interface{}{
+			"view_count":     gorm.Expr("view_count + 1"),
+			"last_viewed_at": at,
+		})
+	if result.Error != nil {
+		return nil, repository.NewError(result.Error, op, entityProfileShareLink, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, repository.NewError(repository.ErrNotFound, op, entityProfileShareLink, "share link is not active")
+	}
+
+	return &link, nil
+}
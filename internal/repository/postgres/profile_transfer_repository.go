@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	entityProfileTransfer = "ProfileTransfer"
+)
+
+// ProfileTransferRepository implements repository.ProfileTransferRepository for PostgreSQL
+type ProfileTransferRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileTransferRepository creates a new ProfileTransferRepository
+func NewProfileTransferRepository(db *gorm.DB) repository.ProfileTransferRepository {
+	return &ProfileTransferRepository{
+		db: db,
+	}
+}
+
+// Create records a new pending transfer after expiring the profile's lapsed one, if any
+func (r *ProfileTransferRepository) Create(ctx context.Context, transfer *model.ProfileTransfer) error {
+	const op = "Create"
+
+	if transfer.ProfileID == uuid.Nil || transfer.FromUserID == uuid.Nil || transfer.ToUserID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileTransfer, "profile_id, from_user_id and to_user_id are required")
+	}
+
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		err := tx.Model(&model.ProfileTransfer{}).
+			Where("profile_id = ? AND status = ? AND expires_at <= ?", transfer.ProfileID, model.ProfileTransferStatusPending, transfer.CreatedAt).
+			Updates(map[string]interface{}{
+				"status":     model.ProfileTransferStatusExpired,
+				"updated_at": transfer.CreatedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(transfer).Error
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_pending_profile_transfer" {
+			return repository.NewError(repository.ErrDuplicateKey, op, entityProfileTransfer, "profile already has a pending transfer")
+		}
+		return repository.NewError(err, op, entityProfileTransfer, "")
+	}
+
+	return nil
+}
+
+// GetByID retrieves a transfer by its ID
+func (r *ProfileTransferRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.ProfileTransfer, error) {
+	const op = "GetByID"
+
+	var transfer model.ProfileTransfer
+	err := conn(ctx, r.db).Where("id = ?", id).First(&transfer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityProfileTransfer, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityProfileTransfer, "")
+	}
+
+	return &transfer, nil
+}
+
+// GetByTokenHash retrieves a transfer by the hash of its one-time token
+func (r *ProfileTransferRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.ProfileTransfer, error) {
+	const op = "GetByTokenHash"
+
+	var transfer model.ProfileTransfer
+	err := conn(ctx, r.db).Where("token_hash = ?", tokenHash).First(&transfer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityProfileTransfer, "token")
+		}
+		return nil, repository.NewError(err, op, entityProfileTransfer, "")
+	}
+
+	return &transfer, nil
+}
+
+// ListPendingByUserID retrieves the open transfers a user has offered or been offered, newest first
+func (r *ProfileTransferRepository) ListPendingByUserID(ctx context.Context, userID uuid.UUID, at time.Time) ([]*model.ProfileTransfer, error) {
+	const op = "ListPendingByUserID"
+
+	var transfers []*model.ProfileTransfer
+	err := conn(ctx, r.db).
+		Where("(from_user_id = ? OR to_user_id = ?) AND status = ? AND expires_at > ?",
+			userID, userID, model.ProfileTransferStatusPending, at).
+		Order("created_at DESC").
+		Find(&transfers).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityProfileTransfer, fmt.Sprintf("user_id: %s", userID))
+	}
+
+	return transfers, nil
+}
+
+// Accept marks a pending, unexpired transfer accepted
+func (r *ProfileTransferRepository) Accept(ctx context.Context, id uuid.UUID, at time.Time, requestID string) error {
+	const op = "Accept"
+
+	result := conn(ctx, r.db).Model(&model.ProfileTransfer{}).
+		Where("id = ? AND status = ? AND expires_at > ?", id, model.ProfileTransferStatusPending, at).
+		Updates(map[string]interface{}{
+			"status":      model.ProfileTransferStatusAccepted,
+			"accepted_at": at,
+			"request_id":  requestID,
+			"updated_at":  at,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileTransfer, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileTransfer, "transfer is not pending")
+	}
+
+	return nil
+}
+
+// Cancel withdraws or declines a pending transfer
+func (r *ProfileTransferRepository) Cancel(ctx context.Context, id uuid.UUID, cancelledBy uuid.UUID, at time.Time) error {
+	const op = "Cancel"
+
+	result := conn(ctx, r.db).Model(&model.ProfileTransfer{}).
+		Where("id = ? AND status = ?", id, model.ProfileTransferStatusPending).
+		Updates(map[string]interface{}{
+			"status":       model.ProfileTransferStatusCancelled,
+			"cancelled_at": at,
+			"cancelled_by": cancelledBy,
+			"updated_at":   at,
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityProfileTransfer, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrInvalidOperation, op, entityProfileTransfer, "transfer is not pending")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityProfileView = "ProfileView"
+)
+
+// ProfileViewRepository implements repository.ProfileViewRepository for PostgreSQL
+type ProfileViewRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileViewRepository creates a new ProfileViewRepository
+func NewProfileViewRepository(db *gorm.DB) repository.ProfileViewRepository {
+	return &ProfileViewRepository{
+		db: db,
+	}
+}
+
+// Record counts a view of a profile by another profile, keeping one row per viewer
+func (r *ProfileViewRepository) Record(ctx context.Context, profileID, viewerProfileID uuid.UUID, viewedAt time.Time) error {
+	const op = "Record"
+
+	view := &model.ProfileView{
+		ProfileID:       profileID,
+		ViewerProfileID: viewerProfileID,
+		ViewCount:       1,
+		LastViewedAt:    viewedAt,
+	}
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "profile_id"}, {Name: "viewer_profile_id"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "view_count"}, Value: gorm.Expr("profile_views.view_count + 1")},
+				{Column: clause.Column{Name: "last_viewed_at"}, Value: viewedAt},
+			},
+		}).
+		Create(view).Error
+	if err != nil {
+		return repository.NewError(err, op, entityProfileView, "")
+	}
+
+	return nil
+}
+
+// ListViewers retrieves the views of a profile with the viewer's profile, most recent first
+func (r *ProfileViewRepository) ListViewers(ctx context.Context, profileID uuid.UUID, page, limit int) ([]*model.ProfileView, int64, error) {
+	const op = "ListViewers"
+
+	query := r.db.WithContext(ctx).
+		Model(&model.ProfileView{}).
+		Joins("JOIN user_profiles ON user_profiles.id = profile_views.viewer_profile_id AND user_profiles.deleted_at IS NULL").
+		Where("profile_views.profile_id = ?", profileID)
+	query = excludeBlocked(query, "profile_views.viewer_profile_id", profileID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, repository.NewError(err, op, entityProfileView, "")
+	}
+
+	var views []*model.ProfileView
+	err := query.
+		Preload("Viewer").
+		Order("profile_views.last_viewed_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&views).Error
+	if err != nil {
+		return nil, 0, repository.NewError(err, op, entityProfileView, "")
+	}
+
+	return views, total, nil
+}
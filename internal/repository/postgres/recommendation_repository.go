@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entityRecommendation = "Recommendation"
+)
+
+// RecommendationRepository implements repository.RecommendationRepository for PostgreSQL
+type RecommendationRepository struct {
+	db *gorm.DB
+}
+
+// NewRecommendationRepository creates a new RecommendationRepository
+func NewRecommendationRepository(db *gorm.DB) repository.RecommendationRepository {
+	return &RecommendationRepository{
+		db: db,
+	}
+}
+
+// ListProfilesDue retrieves profiles that have no recommendation run for the given day
+func (r *RecommendationRepository) ListProfilesDue(
+	ctx context.Context,
+	day time.Time,
+	afterID uuid.UUID,
+	limit int,
+) ([]*model.UserProfile, error) {
+	const op = "ListProfilesDue"
+
+	var profiles []*model.UserProfile
+	err := conn(ctx, r.db).
+		Where("id > ?", afterID).
+		Where("NOT EXISTS (SELECT 1 FROM recommendation_runs rr WHERE rr.profile_id = user_profiles.id AND rr.generated_on = ?)", day).
+		Order("id").
+		Limit(limit).
+		Find(&profiles).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityRecommendation, "")
+	}
+
+	return profiles, nil
+}
+
+// FindCandidates retrieves candidate profiles matching the filter, newest first. Profiles
+// declined as not interested are never returned again, skipped ones and other previous
+// recommendations only once their cooldown has passed.
+func (r *RecommendationRepository) FindCandidates(
+	ctx context.Context,
+	profileID uuid.UUID,
+	filter repository.ProfileFilter,
+	opts repository.CandidateOptions,
+) ([]*model.UserProfile, error) {
+	const op = "FindCandidates"
+
+	query := applyProfileFilter(conn(ctx, r.db).Model(&model.UserProfile{}), filter).
+		Where("id <> ?", profileID)
+	query = excludeBlocked(query, "user_profiles.id", profileID)
+	query = query.Where("NOT EXISTS (SELECT 1 FROM recommendations rec "+
+		"WHERE rec.profile_id = ? AND rec.candidate_id = user_profiles.id AND ("+
+		"rec.status = ? OR "+
+		"(rec.status = ? AND rec.generated_on >= ?) OR "+
+		"(rec.status <> ? AND rec.generated_on >= ?)))",
+		profileID,
+		model.RecommendationStatusNotInterested,
+		model.RecommendationStatusSkipped, opts.SkippedSince,
+		model.RecommendationStatusSkipped, opts.RepeatSince)
+
+	var candidates []*model.UserProfile
+	err := query.Order("created_at DESC").Limit(opts.Limit).Find(&candidates).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityRecommendation, fmt.Sprintf("profile_id: %s", profileID))
+	}
+
+	return candidates, nil
+}
+
+// SaveRun stores a recommendation run with its recommendations in a single transaction
+func (r *RecommendationRepository) SaveRun(
+	ctx context.Context,
+	run *model.RecommendationRun,
+	recommendations []*model.Recommendation,
+) (bool, error) {
+	const op = "SaveRun"
+
+	saved := false
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(run)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// Another run already generated this day's recommendations
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		saved = true
+		if len(recommendations) == 0 {
+			return nil
+		}
+		return tx.Create(&recommendations).Error
+	})
+	if err != nil {
+		return false, repository.NewError(err, op, entityRecommendation, fmt.Sprintf("profile_id: %s", run.ProfileID))
+	}
+
+	return saved, nil
+}
+
+// ListLatest retrieves the open recommendations of a profile's most recent run in rank
+// order. Candidates deleted or blocked since the run are left out.
+func (r *RecommendationRepository) ListLatest(
+	ctx context.Context,
+	profileID uuid.UUID,
+	page,
+	limit int,
+) ([]*model.Recommendation, int64, error) {
+	const op = "ListLatest"
+
+	var recommendations []*model.Recommendation
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.Recommendation{}).
+		Joins("JOIN user_profiles c ON c.id = recommendations.candidate_id AND c.deleted_at IS NULL").
+		Where("recommendations.profile_id = ?", profileID).
+		Where("recommendations.generated_on = (SELECT MAX(generated_on) FROM recommendation_runs WHERE profile_id = ?)", profileID).
+		Where("recommendations.status = ?", model.RecommendationStatusNew)
+	query = excludeBlocked(query, "recommendations.candidate_id", profileID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, repository.NewError(err, op, entityRecommendation, "count failed")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	err := query.Preload("Candidate").
+		Order("recommendations.rank").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&recommendations).Error
+	if err != nil {
+		return nil, 0, repository.NewError(err, op, entityRecommendation, "")
+	}
+
+	return recommendations, total, nil
+}
+
+// GetByID retrieves a recommendation by ID
+func (r *RecommendationRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Recommendation, error) {
+	const op = "GetByID"
+
+	var recommendation model.Recommendation
+	err := conn(ctx, r.db).Where("id = ?", id).First(&recommendation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityRecommendation, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityRecommendation, "")
+	}
+
+	return &recommendation, nil
+}
+
+// UpdateStatus records feedback on a recommendation
+func (r *RecommendationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status model.RecommendationStatus) error {
+	const op = "UpdateStatus"
+
+	result := conn(ctx, r.db).Model(&model.Recommendation{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityRecommendation, fmt.Sprintf("id: %s", id))
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityRecommendation, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	entitySavedSearch = "SavedSearch"
+	entitySearchAlert = "SearchAlert"
+)
+
+// SavedSearchRepository implements repository.SavedSearchRepository for PostgreSQL
+type SavedSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedSearchRepository creates a new SavedSearchRepository
+func NewSavedSearchRepository(db *gorm.DB) repository.SavedSearchRepository {
+	return &SavedSearchRepository{
+		db: db,
+	}
+}
+
+// Create adds a new saved search to the database
+func (r *SavedSearchRepository) Create(ctx context.Context, search *model.SavedSearch) error {
+	const op = "Create"
+
+	if search.UserID == uuid.Nil {
+		return repository.NewError(repository.ErrInvalidOperation, op, entitySavedSearch, "user_id is required")
+	}
+
+	if err := conn(ctx, r.db).Create(search).Error; err != nil {
+		return repository.NewError(err, op, entitySavedSearch, "")
+	}
+
+	return nil
+}
+
+// GetByID retrieves a saved search by ID
+func (r *SavedSearchRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.SavedSearch, error) {
+	const op = "GetByID"
+
+	var search model.SavedSearch
+	err := conn(ctx, r.db).Where("id = ?", id).First(&search).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entitySavedSearch, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entitySavedSearch, "")
+	}
+
+	return &search, nil
+}
+
+// ListByUserID retrieves all saved searches of a user, newest first
+func (r *SavedSearchRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.SavedSearch, error) {
+	const op = "ListByUserID"
+
+	var searches []*model.SavedSearch
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&searches).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entitySavedSearch, "")
+	}
+
+	return searches, nil
+}
+
+// Delete soft-deletes a saved search
+func (r *SavedSearchRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "Delete"
+
+	result := conn(ctx, r.db).Delete(&model.SavedSearch{}, id)
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entitySavedSearch, "")
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entitySavedSearch, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
+
+// ListDue retrieves saved searches that are due for an alert run: instant searches
+// are always due, daily and weekly ones once their period has passed since the last run
+func (r *SavedSearchRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*model.SavedSearch, error) {
+	const op = "ListDue"
+
+	var searches []*model.SavedSearch
+	err := dueAt(conn(ctx, r.db), now).
+		Order("last_run_at").
+		Limit(limit).
+		Find(&searches).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entitySavedSearch, "")
+	}
+
+	return searches, nil
+}
+
+// Claim locks a saved search for an alert run and returns it, or nil if another worker
+// holds it or it has been run since it was listed and is no longer due. The lock is held
+// until the caller's transaction ends, so the search is alerted on by one worker at a time.
+func (r *SavedSearchRepository) Claim(ctx context.Context, id uuid.UUID, now time.Time) (*model.SavedSearch, error) {
+	const op = "Claim"
+
+	var searches []*model.SavedSearch
+	err := dueAt(conn(ctx, r.db), now).
+		Where("id = ?", id).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Find(&searches).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entitySavedSearch, fmt.Sprintf("id: %s", id))
+	}
+
+	if len(searches) == 0 {
+		return nil, nil
+	}
+	return searches[0], nil
+}
+
+// dueAt restricts a query to the saved searches due for an alert run at the given time
+func dueAt(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("frequency = ? OR (frequency = ? AND last_run_at <= ?) OR (frequency = ? AND last_run_at <= ?)",
+		model.AlertFrequencyInstant,
+		model.AlertFrequencyDaily, now.Add(-24*time.Hour),
+		model.AlertFrequencyWeekly, now.Add(-7*24*time.Hour))
+}
+
+// MarkRun records the time up to which a saved search has been checked
+func (r *SavedSearchRepository) MarkRun(ctx context.Context, id uuid.UUID, runAt time.Time) error {
+	const op = "MarkRun"
+
+	err := conn(ctx, r.db).Model(&model.SavedSearch{}).
+		Where("id = ?", id).
+		UpdateColumn("last_run_at", runAt).Error
+	if err != nil {
+		return repository.NewError(err, op, entitySavedSearch, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
+
+// SearchAlertRepository implements repository.SearchAlertRepository for PostgreSQL
+type SearchAlertRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchAlertRepository creates a new SearchAlertRepository
+func NewSearchAlertRepository(db *gorm.DB) repository.SearchAlertRepository {
+	return &SearchAlertRepository{
+		db: db,
+	}
+}
+
+// CreateIfNew adds an alert unless one already exists for the same saved search and profile
+func (r *SearchAlertRepository) CreateIfNew(ctx context.Context, alert *model.SearchAlert) (bool, error) {
+	const op = "CreateIfNew"
+
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(alert)
+	if result.Error != nil {
+		return false, repository.NewError(result.Error, op, entitySearchAlert, "")
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ListByUserID retrieves a user's alerts with pagination, newest first
+func (r *SearchAlertRepository) ListByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	page,
+	limit int,
+) ([]*model.SearchAlert, int64, error) {
+	const op = "ListByUserID"
+
+	var alerts []*model.SearchAlert
+	var total int64
+
+	query := conn(ctx, r.db).Model(&model.SearchAlert{}).Where("user_id = ?", userID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, repository.NewError(err, op, entitySearchAlert, "count failed")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	err := query.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&alerts).Error
+	if err != nil {
+		return nil, 0, repository.NewError(err, op, entitySearchAlert, "")
+	}
+
+	return alerts, total, nil
+}
+
+// ListUnnotifiedIDs retrieves the IDs of a saved search's alerts the owner hasn't been notified about
+func (r *SearchAlertRepository) ListUnnotifiedIDs(ctx context.Context, savedSearchID uuid.UUID) ([]uuid.UUID, error) {
+	const op = "ListUnnotifiedIDs"
+
+	var ids []uuid.UUID
+	err := conn(ctx, r.db).Model(&model.SearchAlert{}).
+		Where("saved_search_id = ? AND notified_at IS NULL", savedSearchID).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entitySearchAlert, fmt.Sprintf("saved_search_id: %s", savedSearchID))
+	}
+
+	return ids, nil
+}
+
+// MarkNotified records that the owner has been notified about the alerts
+func (r *SearchAlertRepository) MarkNotified(ctx context.Context, ids []uuid.UUID, notifiedAt time.Time) error {
+	const op = "MarkNotified"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := conn(ctx, r.db).Model(&model.SearchAlert{}).
+		Where("id IN ?", ids).
+		UpdateColumn("notified_at", notifiedAt).Error
+	if err != nil {
+		return repository.NewError(err, op, entitySearchAlert, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key under which WithTransaction stores the transaction
+const txKey = "tx"
+
+// conn returns the transaction started by WithTransaction if ctx carries one, so that
+// repositories called inside the callback share it, and db otherwise
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// inTransaction runs fn in the transaction carried by ctx, or in a new one
+func inTransaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if tx, ok := ctx.Value(txKey).(*gorm.DB); ok {
+		return fn(tx.WithContext(ctx))
+	}
+	return db.WithContext(ctx).Transaction(fn)
+}
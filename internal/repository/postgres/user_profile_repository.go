@@ -2,15 +2,23 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/age"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/geo"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/phonetic"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -37,7 +45,7 @@ func (r *UserProfileRepository) Create(ctx context.Context, profile *model.UserP
 		return repository.NewError(repository.ErrInvalidOperation, op, entityUserProfile, "user_id is required")
 	}
 
-	err := r.db.WithContext(ctx).Create(profile).Error
+	err := conn(ctx, r.db).Create(profile).Error
 	if err != nil {
 		// Check for duplicate key violation
 		var pgErr *pgconn.PgError
@@ -52,12 +60,69 @@ func (r *UserProfileRepository) Create(ctx context.Context, profile *model.UserP
 	return nil
 }
 
+// CreateWithinLimit adds a new profile to its account unless the account already owns limit
+// profiles. A transaction-scoped advisory lock on the account serializes concurrent creation.
+func (r *UserProfileRepository) CreateWithinLimit(ctx context.Context, profile *model.UserProfile, limit int) (bool, error) {
+	const op = "CreateWithinLimit"
+
+	if profile.UserID == uuid.Nil {
+		return false, repository.NewError(repository.ErrInvalidOperation, op, entityUserProfile, "user_id is required")
+	}
+
+	created := false
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", profile.UserID.String()).Error; err != nil {
+			return err
+		}
+
+		var owned int64
+		if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", profile.UserID).Count(&owned).Error; err != nil {
+			return err
+		}
+		if owned >= int64(limit) {
+			return nil
+		}
+
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+
+		// The account's first profile becomes its active profile
+		active := &model.ActiveProfile{
+			UserID:    profile.UserID,
+			ProfileID: profile.ID,
+			UpdatedAt: profile.CreatedAt,
+		}
+		if owned == 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"profile_id", "updated_at"}),
+			}).Create(active).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		created = true
+		return nil
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_user_profile" {
+			return false, repository.NewError(repository.ErrDuplicateKey, op, entityUserProfile, "account already has a profile of its own")
+		}
+		return false, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	return created, nil
+}
+
 // GetByID retrieves a user profile by ID
 func (r *UserProfileRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.UserProfile, error) {
 	const op = "GetByID"
 
 	var profile model.UserProfile
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&profile).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&profile).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -69,13 +134,63 @@ func (r *UserProfileRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 	return &profile, nil
 }
 
-// GetByUserID retrieves a user profile by user ID
-func (r *UserProfileRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*model.UserProfile, error) {
-	const op = "GetByUserID"
+// GetByCode retrieves a user profile by its public code
+func (r *UserProfileRepository) GetByCode(ctx context.Context, code string) (*model.UserProfile, error) {
+	const op = "GetByCode"
 
 	var profile model.UserProfile
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&profile).Error
+	err := conn(ctx, r.db).Where("profile_code = ?", code).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityUserProfile, fmt.Sprintf("profile_code: %s", code))
+		}
+		return nil, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	return &profile, nil
+}
+
+// ListByIDs retrieves the profiles with the given IDs that exist and aren't deleted
+func (r *UserProfileRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error) {
+	const op = "ListByIDs"
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var profiles []*model.UserProfile
+	if err := conn(ctx, r.db).Where("id IN ?", ids).Find(&profiles).Error; err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	return profiles, nil
+}
+
+// ListByUserID retrieves the profiles owned by an account, oldest first
+func (r *UserProfileRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserProfile, error) {
+	const op = "ListByUserID"
 
+	var profiles []*model.UserProfile
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at, id").
+		Find(&profiles).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, fmt.Sprintf("user_id: %s", userID))
+	}
+
+	return profiles, nil
+}
+
+// GetActiveByUserID retrieves the profile an account last switched to
+func (r *UserProfileRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*model.UserProfile, error) {
+	const op = "GetActiveByUserID"
+
+	var profile model.UserProfile
+	err := conn(ctx, r.db).
+		Joins("JOIN active_profiles ap ON ap.profile_id = user_profiles.id AND ap.user_id = user_profiles.user_id").
+		Where("ap.user_id = ?", userID).
+		First(&profile).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, repository.NewError(repository.ErrNotFound, op, entityUserProfile, fmt.Sprintf("user_id: %s", userID))
@@ -86,6 +201,28 @@ func (r *UserProfileRepository) GetByUserID(ctx context.Context, userID uuid.UUI
 	return &profile, nil
 }
 
+// SetActive makes one of an account's profiles its active profile
+func (r *UserProfileRepository) SetActive(ctx context.Context, userID, profileID uuid.UUID) error {
+	const op = "SetActive"
+
+	active := &model.ActiveProfile{
+		UserID:    userID,
+		ProfileID: profileID,
+		UpdatedAt: time.Now(),
+	}
+	err := conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"profile_id", "updated_at"}),
+		}).
+		Create(active).Error
+	if err != nil {
+		return repository.NewError(err, op, entityUserProfile, fmt.Sprintf("user_id: %s, profile_id: %s", userID, profileID))
+	}
+
+	return nil
+}
+
 // Update updates an existing user profile
 func (r *UserProfileRepository) Update(ctx context.Context, profile *model.UserProfile) error {
 	const op = "Update"
@@ -97,7 +234,7 @@ func (r *UserProfileRepository) Update(ctx context.Context, profile *model.UserP
 	// Set updated_at to current time
 	profile.UpdatedAt = time.Now()
 
-	err := r.db.WithContext(ctx).Save(profile).Error
+	err := conn(ctx, r.db).Save(profile).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return repository.NewError(repository.ErrNotFound, op, entityUserProfile, fmt.Sprintf("id: %s", profile.ID))
@@ -108,11 +245,34 @@ func (r *UserProfileRepository) Update(ctx context.Context, profile *model.UserP
 	return nil
 }
 
-// Delete soft-deletes a user profile
-func (r *UserProfileRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// UpdateCompletenessScore stores the completeness score of a profile. The score is
+// derived from the profile, so the change is not recorded as an update.
+func (r *UserProfileRepository) UpdateCompletenessScore(ctx context.Context, id uuid.UUID, score int) error {
+	const op = "UpdateCompletenessScore"
+
+	result := conn(ctx, r.db).Model(&model.UserProfile{}).
+		Where("id = ?", id).
+		UpdateColumn("completeness_score", score)
+	if result.Error != nil {
+		return repository.NewError(result.Error, op, entityUserProfile, fmt.Sprintf("id: %s", id))
+	}
+	if result.RowsAffected == 0 {
+		return repository.NewError(repository.ErrNotFound, op, entityUserProfile, fmt.Sprintf("id: %s", id))
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a user profile, recording the user who deleted it
+func (r *UserProfileRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	const op = "Delete"
 
-	result := r.db.WithContext(ctx).Delete(&model.UserProfile{}, id)
+	result := conn(ctx, r.db).Model(&model.UserProfile{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+			"deleted_by": deletedBy,
+		})
 	if result.Error != nil {
 		return repository.NewError(result.Error, op, entityUserProfile, "")
 	}
@@ -124,21 +284,624 @@ func (r *UserProfileRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-// SearchProfiles searches for profiles with pagination based on filter criteria
+// Transfer re-points a profile from one account to another unless the receiving account
+// already owns limit profiles. The receiving account's advisory lock serializes it with
+// CreateWithinLimit, and the profile becomes that account's active one if it has none.
+func (r *UserProfileRepository) Transfer(ctx context.Context, profileID, fromUserID, toUserID uuid.UUID, limit int) (bool, error) {
+	const op = "Transfer"
+
+	transferred := false
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", toUserID.String()).Error; err != nil {
+			return err
+		}
+
+		var owned int64
+		if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", toUserID).Count(&owned).Error; err != nil {
+			return err
+		}
+		if owned >= int64(limit) {
+			return nil
+		}
+
+		now := time.Now()
+		result := tx.Model(&model.UserProfile{}).
+			Where("id = ? AND user_id = ?", profileID, fromUserID).
+			Updates(map[string]interface{}{
+				"user_id":    toUserID,
+				"updated_by": toUserID,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrInvalidOperation
+		}
+
+		err := tx.Where("user_id = ? AND profile_id = ?", fromUserID, profileID).
+			Delete(&model.ActiveProfile{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ActiveProfile{
+			UserID:    toUserID,
+			ProfileID: profileID,
+			UpdatedAt: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		transferred = true
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidOperation) {
+			return false, repository.NewError(repository.ErrInvalidOperation, op, entityUserProfile, "profile is not owned by the transferring account")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_user_profile" {
+			return false, repository.NewError(repository.ErrDuplicateKey, op, entityUserProfile, "account already has a profile of its own")
+		}
+		return false, repository.NewError(err, op, entityUserProfile, fmt.Sprintf("id: %s", profileID))
+	}
+
+	return transferred, nil
+}
+
+// ListDeletedByUserID retrieves the profiles of an account deleted after the given time
+// and not yet purged, most recently deleted first
+func (r *UserProfileRepository) ListDeletedByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	deletedAfter time.Time,
+) ([]*model.UserProfile, error) {
+	const op = "ListDeletedByUserID"
+
+	var profiles []*model.UserProfile
+	err := conn(ctx, r.db).Unscoped().
+		Where("user_id = ? AND deleted_at > ? AND purged_at IS NULL", userID, deletedAfter).
+		Order("deleted_at DESC, id").
+		Find(&profiles).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, fmt.Sprintf("user_id: %s", userID))
+	}
+
+	return profiles, nil
+}
+
+// GetDeletedByID retrieves a deleted profile that has not been purged
+func (r *UserProfileRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*model.UserProfile, error) {
+	const op = "GetDeletedByID"
+
+	var profile model.UserProfile
+	err := conn(ctx, r.db).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL AND purged_at IS NULL", id).
+		First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.NewError(repository.ErrNotFound, op, entityUserProfile, fmt.Sprintf("id: %s", id))
+		}
+		return nil, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	return &profile, nil
+}
+
+// RestoreWithinLimit undeletes a profile deleted after the given time unless its account
+// already owns limit profiles. The account's advisory lock serializes it with
+// CreateWithinLimit, and the profile becomes the account's active one if it has none.
+func (r *UserProfileRepository) RestoreWithinLimit(
+	ctx context.Context,
+	profile *model.UserProfile,
+	deletedAfter time.Time,
+	limit int,
+) (bool, error) {
+	const op = "RestoreWithinLimit"
+
+	restored := false
+	err := inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", profile.UserID.String()).Error; err != nil {
+			return err
+		}
+
+		var owned int64
+		if err := tx.Model(&model.UserProfile{}).Where("user_id = ?", profile.UserID).Count(&owned).Error; err != nil {
+			return err
+		}
+		if owned >= int64(limit) {
+			return nil
+		}
+
+		now := time.Now()
+		result := tx.Unscoped().Model(&model.UserProfile{}).
+			Where("id = ? AND user_id = ? AND deleted_at > ? AND purged_at IS NULL", profile.ID, profile.UserID, deletedAfter).
+			Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"deleted_by": nil,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrNotFound
+		}
+
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ActiveProfile{
+			UserID:    profile.UserID,
+			ProfileID: profile.ID,
+			UpdatedAt: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		profile.DeletedAt = gorm.DeletedAt{}
+		profile.DeletedBy = nil
+		profile.UpdatedAt = now
+		restored = true
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, repository.NewError(repository.ErrNotFound, op, entityUserProfile, "profile is not restorable")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "unique_user_profile" {
+			return false, repository.NewError(repository.ErrDuplicateKey, op, entityUserProfile, "account already has a profile of its own")
+		}
+		return false, repository.NewError(err, op, entityUserProfile, fmt.Sprintf("id: %s", profile.ID))
+	}
+
+	return restored, nil
+}
+
+// ListPurgeable retrieves the IDs of profiles deleted before the given time and not yet
+// purged, longest deleted first
+func (r *UserProfileRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]uuid.UUID, error) {
+	const op = "ListPurgeable"
+
+	var ids []uuid.UUID
+	err := conn(ctx, r.db).Unscoped().Model(&model.UserProfile{}).
+		Where("deleted_at < ? AND purged_at IS NULL", deletedBefore).
+		Order("deleted_at").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	return ids, nil
+}
+
+// Purge anonymizes a profile deleted before the given time. Everything that identifies
+// the member is cleared; what remains, such as gender, community, district and birth
+// year, is kept for statistics. It returns ErrNotFound if the profile was restored or
+// purged meanwhile.
+func (r *UserProfileRepository) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	const op = "Purge"
+
+	return inTransaction(ctx, r.db, func(tx *gorm.DB) error {
+		// UpdateColumns skips the hook that would recompute the phonetic key from the name
+		result := tx.Unscoped().Model(&model.UserProfile{}).
+			Where("id = ? AND deleted_at < ? AND purged_at IS NULL", id, deletedBefore).
+			UpdateColumns(anonymizedProfileColumns(time.Now()))
+		if result.Error != nil {
+			return repository.NewError(result.Error, op, entityUserProfile, fmt.Sprintf("id: %s", id))
+		}
+		if result.RowsAffected == 0 {
+			return repository.NewError(repository.ErrNotFound, op, entityUserProfile, fmt.Sprintf("id: %s", id))
+		}
+
+		if err := tx.Where("profile_id = ?", id).Delete(&model.ActiveProfile{}).Error; err != nil {
+			return repository.NewError(err, op, entityUserProfile, fmt.Sprintf("id: %s", id))
+		}
+
+		return nil
+	})
+}
+
+// anonymizedProfileColumns returns the column updates that clear everything identifying
+// the member from a profile, leaving what is kept for statistics
+func anonymizedProfileColumns(at time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"name":              "",
+		"name_phonetic":     "",
+		"date_of_birth":     gorm.Expr("date_trunc('year', date_of_birth)"),
+		"residence_city":    "",
+		"latitude":          nil,
+		"longitude":         nil,
+		"education":         "",
+		"occupation":        "",
+		"about_me":          "",
+		"father_occupation": "",
+		"mother_occupation": "",
+		"siblings":          "",
+		"purged_at":         at,
+	}
+}
+
+// profileSearchRow is a profile row along with the computed search columns
+type profileSearchRow struct {
+	model.UserProfile
+	SearchRank     float64
+	SearchHeadline string
+	DistanceKm     *float64
+}
+
+// SearchProfiles searches for profiles based on filter criteria. Pages are addressed
+// either by a keyset cursor or, for backward compatibility, by page number.
 func (r *UserProfileRepository) SearchProfiles(
 	ctx context.Context,
 	filter repository.ProfileFilter,
-	page,
-	limit int,
-) ([]*model.UserProfile, int64, error) {
+	page repository.PageRequest,
+) (*repository.ProfileSearchPage, error) {
 	const op = "SearchProfiles"
 
+	if page.Limit <= 0 {
+		page.Limit = 10
+	}
+
+	sortBy := searchSort(filter)
+	if page.After != nil && page.After.Sort != sortBy {
+		return nil, repository.NewError(repository.ErrInvalidOperation, op, entityUserProfile, "cursor does not match the search order")
+	}
+
+	result := &repository.ProfileSearchPage{}
+
+	switch page.Count {
+	case repository.CountExact:
+		var total int64
+		err := applyProfileFilter(conn(ctx, r.db).Model(&model.UserProfile{}), filter).Count(&total).Error
+		if err != nil {
+			return nil, repository.NewError(err, op, entityUserProfile, "count failed")
+		}
+		result.Total = &total
+	case repository.CountEstimate:
+		total, err := r.estimateCount(ctx, filter)
+		if err != nil {
+			return nil, repository.NewError(err, op, entityUserProfile, "count estimate failed")
+		}
+		result.Total = &total
+		result.TotalIsEstimate = true
+	}
+
+	query := selectSearchColumns(applyProfileFilter(conn(ctx, r.db).Model(&model.UserProfile{}), filter), filter)
+
+	limit := page.Limit
+	var exclude []uuid.UUID
+	switch {
+	case page.After != nil:
+		exclude = page.After.Exclude
+		query = applyKeyset(query, filter, page.After)
+	case page.Page > 1:
+		query = query.Offset((page.Page-1)*page.Limit - page.Reserved)
+	default:
+		limit -= page.Reserved
+	}
+	if len(exclude) > 0 {
+		query = query.Where("id NOT IN ?", exclude)
+	}
+
+	// Fetch one extra row to know whether there is a next page
+	var rows []*profileSearchRow
+	err := query.Clauses(profileOrder(filter)).Limit(limit + 1).Find(&rows).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		result.Next = &repository.Cursor{
+			Sort:      sortBy,
+			Rank:      last.SearchRank,
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+			Exclude:   exclude,
+		}
+		if last.DistanceKm != nil {
+			result.Next.Distance = *last.DistanceKm
+		}
+	}
+
+	result.Hits = make([]*repository.ProfileSearchHit, len(rows))
+	for i, row := range rows {
+		result.Hits[i] = &repository.ProfileSearchHit{
+			Profile:    &row.UserProfile,
+			Rank:       row.SearchRank,
+			Highlights: splitHeadline(row.SearchHeadline),
+			DistanceKm: row.DistanceKm,
+		}
+	}
+
+	return result, nil
+}
+
+// estimateCount returns the planner's row estimate for the filtered search, which
+// avoids a full count on broad filters at the cost of accuracy
+func (r *UserProfileRepository) estimateCount(ctx context.Context, filter repository.ProfileFilter) (int64, error) {
+	var plan string
+	query := applyProfileFilter(r.db.Model(&model.UserProfile{}), filter).Select("id")
+	err := conn(ctx, r.db).Raw("EXPLAIN (FORMAT JSON) ?", query).Row().Scan(&plan)
+	if err != nil {
+		return 0, err
+	}
+
+	var explain []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explain); err != nil {
+		return 0, err
+	}
+	if len(explain) == 0 {
+		return 0, nil
+	}
+
+	return int64(explain[0].Plan.PlanRows), nil
+}
+
+// Facet names as returned by the facet query
+const (
+	facetCommunity     = "community"
+	facetNationality   = "nationality"
+	facetMaritalStatus = "marital_status"
+	facetHomeDistrict  = "home_district"
+	facetAgeRange      = "age_range"
+)
+
+// ageRanges are the age ranges shown on the search page, each up to but not including
+// the next one's lower bound. The last range is open ended.
+var ageRanges = []struct {
+	Label string
+	Below int
+}{
+	{"18-24", 25},
+	{"25-29", 30},
+	{"30-34", 35},
+	{"35-39", 40},
+}
+
+// ageRangeSQL buckets date of birth into the age ranges as of today. The bounds are
+// birth dates computed by the age package rather than PostgreSQL's age(), so that
+// the buckets agree with the age filters and with ages shown on profiles.
+func ageRangeSQL(ages *age.Calculator) string {
+	today := ages.Today()
+
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, r := range ageRanges {
+		fmt.Fprintf(&b, " WHEN date_of_birth > '%s'::date THEN '%s'",
+			age.LatestBirthDate(today, r.Below).Format("2006-01-02"), r.Label)
+	}
+	b.WriteString(" ELSE '40+' END")
+
+	return b.String()
+}
+
+// facetRow is a single row of the facet query
+type facetRow struct {
+	Facet string
+	Value string
+	Count int64
+}
+
+// CountFacets counts matching profiles per facet value. Each facet ignores its own
+// filter so that the counts show what selecting another value would yield. All
+// facets are computed in a single query.
+func (r *UserProfileRepository) CountFacets(ctx context.Context, filter repository.ProfileFilter) (*repository.ProfileFacets, error) {
+	const op = "CountFacets"
+
+	facetQuery := func(facet, valueSQL string, f repository.ProfileFilter) *gorm.DB {
+		return applyProfileFilter(r.db.Model(&model.UserProfile{}), f).
+			Select(fmt.Sprintf("'%s' AS facet, %s AS value, COUNT(*) AS count", facet, valueSQL)).
+			Group("value")
+	}
+
+	withoutCommunity := filter
+	withoutCommunity.Community = nil
+	withoutNationality := filter
+	withoutNationality.Nationality = nil
+	withoutMaritalStatus := filter
+	withoutMaritalStatus.MaritalStatus = nil
+	withoutHomeDistrict := filter
+	withoutHomeDistrict.HomeDistrict = nil
+	withoutAge := filter
+	withoutAge.MinAge, withoutAge.MaxAge = nil, nil
+
+	var rows []facetRow
+	err := conn(ctx, r.db).Raw("? UNION ALL ? UNION ALL ? UNION ALL ? UNION ALL ?",
+		facetQuery(facetCommunity, "community::text", withoutCommunity),
+		facetQuery(facetNationality, "nationality::text", withoutNationality),
+		facetQuery(facetMaritalStatus, "marital_status::text", withoutMaritalStatus),
+		facetQuery(facetHomeDistrict, "home_district::text", withoutHomeDistrict),
+		facetQuery(facetAgeRange, ageRangeSQL(age.Default), withoutAge),
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	facets := &repository.ProfileFacets{}
+	for _, row := range rows {
+		count := repository.FacetCount{Value: row.Value, Count: row.Count}
+		switch row.Facet {
+		case facetCommunity:
+			facets.Community = append(facets.Community, count)
+		case facetNationality:
+			facets.Nationality = append(facets.Nationality, count)
+		case facetMaritalStatus:
+			facets.MaritalStatus = append(facets.MaritalStatus, count)
+		case facetHomeDistrict:
+			facets.HomeDistrict = append(facets.HomeDistrict, count)
+		case facetAgeRange:
+			facets.AgeRange = append(facets.AgeRange, count)
+		}
+	}
+
+	for _, counts := range [][]repository.FacetCount{
+		facets.Community, facets.Nationality, facets.MaritalStatus, facets.HomeDistrict,
+	} {
+		sort.Slice(counts, func(i, j int) bool {
+			if counts[i].Count != counts[j].Count {
+				return counts[i].Count > counts[j].Count
+			}
+			return counts[i].Value < counts[j].Value
+		})
+	}
+	// Age ranges keep their natural order
+	sort.Slice(facets.AgeRange, func(i, j int) bool {
+		return facets.AgeRange[i].Value < facets.AgeRange[j].Value
+	})
+
+	return facets, nil
+}
+
+// FindSimilar retrieves profiles of the same gender as the given profile whose age
+// is within the window, closest in age first
+func (r *UserProfileRepository) FindSimilar(
+	ctx context.Context,
+	profile *model.UserProfile,
+	opts repository.SimilarOptions,
+) ([]*model.UserProfile, error) {
+	const op = "FindSimilar"
+
+	dob := profile.DateOfBirth
+	var candidates []*model.UserProfile
+	err := conn(ctx, r.db).
+		Where("is_groom = ?", profile.IsGroom).
+		Where("id <> ?", profile.ID).
+		Where("date_of_birth BETWEEN ? AND ?", age.AddYears(dob, -opts.AgeWindowYears), age.AddYears(dob, opts.AgeWindowYears)).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: "abs(date_of_birth - ?::date), id", Vars: []interface{}{dob}}}).
+		Limit(opts.Limit).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, fmt.Sprintf("id: %s", profile.ID))
+	}
+
+	return candidates, nil
+}
+
+// BackfillNamePhonetics computes phonetic name keys for profiles that don't have one yet.
+// Profiles are walked in ID order so that names without letters, which never get a key,
+// are read only once.
+func (r *UserProfileRepository) BackfillNamePhonetics(ctx context.Context) (int64, error) {
+	const op = "BackfillNamePhonetics"
+	const batchSize = 500
+
+	var updated int64
+	lastID := uuid.Nil
+	for {
+		var profiles []*model.UserProfile
+		err := conn(ctx, r.db).
+			Select("id", "name").
+			Where("name_phonetic = '' AND id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Find(&profiles).Error
+		if err != nil {
+			return updated, repository.NewError(err, op, entityUserProfile, "")
+		}
+
+		for _, profile := range profiles {
+			key := phonetic.Key(profile.Name)
+			if key == "" {
+				continue
+			}
+
+			err = conn(ctx, r.db).Model(&model.UserProfile{}).
+				Where("id = ?", profile.ID).
+				UpdateColumn("name_phonetic", key).Error
+			if err != nil {
+				return updated, repository.NewError(err, op, entityUserProfile, fmt.Sprintf("id: %s", profile.ID))
+			}
+			updated++
+		}
+
+		if len(profiles) < batchSize {
+			return updated, nil
+		}
+		lastID = profiles[len(profiles)-1].ID
+	}
+}
+
+// ListUnscored retrieves profiles with a completeness score of 0, walking them by ID
+func (r *UserProfileRepository) ListUnscored(ctx context.Context, afterID uuid.UUID, limit int) ([]*model.UserProfile, error) {
+	const op = "ListUnscored"
+
 	var profiles []*model.UserProfile
-	var total int64
+	err := conn(ctx, r.db).
+		Where("completeness_score = 0 AND id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&profiles).Error
+	if err != nil {
+		return nil, repository.NewError(err, op, entityUserProfile, "")
+	}
+
+	return profiles, nil
+}
 
-	query := r.db.WithContext(ctx).Model(&model.UserProfile{})
+// BackfillResidenceLocations sets the residence coordinates of profiles that don't have
+// them yet, as profiles saved now get them. Deleted profiles that may still be restored
+// are included; profiles whose places aren't in the gazetteer are left without.
+func (r *UserProfileRepository) BackfillResidenceLocations(ctx context.Context) (int64, error) {
+	const op = "BackfillResidenceLocations"
+	const batchSize = 500
+
+	var updated int64
+	lastID := uuid.Nil
+	for {
+		var profiles []*model.UserProfile
+		err := conn(ctx, r.db).Unscoped().
+			Select("id", "residence_city", "home_district").
+			Where("latitude IS NULL AND purged_at IS NULL AND id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Find(&profiles).Error
+		if err != nil {
+			return updated, repository.NewError(err, op, entityUserProfile, "")
+		}
+
+		for _, profile := range profiles {
+			place, ok := geo.LookupResidence(profile.ResidenceCity, string(profile.HomeDistrict))
+			if !ok {
+				continue
+			}
+
+			err = conn(ctx, r.db).Unscoped().Model(&model.UserProfile{}).
+				Where("id = ?", profile.ID).
+				UpdateColumns(map[string]interface{}{
+					"latitude":  place.Lat,
+					"longitude": place.Lng,
+				}).Error
+			if err != nil {
+				return updated, repository.NewError(err, op, entityUserProfile, fmt.Sprintf("id: %s", profile.ID))
+			}
+			updated++
+		}
+
+		if len(profiles) < batchSize {
+			return updated, nil
+		}
+		lastID = profiles[len(profiles)-1].ID
+	}
+}
+
+// applyProfileFilter adds the WHERE conditions for the given filter to the query
+func applyProfileFilter(query *gorm.DB, filter repository.ProfileFilter) *gorm.DB {
+	if filter.Name != nil {
+		query = applyNameFilter(query, *filter.Name)
+	}
+
+	if keywords := searchKeywords(filter); keywords != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('english', ?)", keywords)
+	}
 
-	// Apply filters
 	if filter.IsGroom != nil {
 		query = query.Where("is_groom = ?", *filter.IsGroom)
 	}
@@ -160,17 +923,21 @@ func (r *UserProfileRepository) SearchProfiles(
 	}
 
 	if filter.MinAge != nil || filter.MaxAge != nil {
-		now := time.Now()
-		if filter.MinAge != nil {
-			maxDOB := now.AddDate(-*filter.MinAge, 0, 0)
-			query = query.Where("date_of_birth <= ?", maxDOB)
+		// Exact to the day: an age bound matches everyone whose birthday makes them
+		// that age today, not just those born a whole number of years ago
+		earliest, latest := age.Default.BirthDateRange(filter.MinAge, filter.MaxAge)
+		if latest != nil {
+			query = query.Where("date_of_birth <= ?", latest.Format("2006-01-02"))
 		}
-		if filter.MaxAge != nil {
-			minDOB := now.AddDate(-*filter.MaxAge, 0, 0)
-			query = query.Where("date_of_birth >= ?", minDOB)
+		if earliest != nil {
+			query = query.Where("date_of_birth >= ?", earliest.Format("2006-01-02"))
 		}
 	}
 
+	if filter.MinCompleteness != nil {
+		query = query.Where("completeness_score >= ?", *filter.MinCompleteness)
+	}
+
 	if filter.MinHeight != nil {
 		query = query.Where("height >= ?", *filter.MinHeight)
 	}
@@ -191,37 +958,232 @@ func (r *UserProfileRepository) SearchProfiles(
 		query = query.Where("created_at <= ?", *filter.CreatedBefore)
 	}
 
-	// Count total matches before applying pagination
-	err := query.Count(&total).Error
-	if err != nil {
-		return nil, 0, repository.NewError(err, op, entityUserProfile, "count failed")
+	if filter.Near != nil && filter.RadiusKm != nil {
+		query = applyDistanceFilter(query, *filter.Near, *filter.RadiusKm)
 	}
 
-	// Apply pagination
-	if page <= 0 {
-		page = 1
+	if filter.ViewerUserID != nil {
+		query = excludeBlockedForAccount(query, "user_profiles.id", *filter.ViewerUserID)
 	}
-	if limit <= 0 {
-		limit = 10
+
+	return query
+}
+
+// applyDistanceFilter restricts the query to profiles residing within radiusKm of
+// center. The bounding box uses the location index; the haversine check is exact.
+func applyDistanceFilter(query *gorm.DB, center geo.Point, radiusKm float64) *gorm.DB {
+	box := geo.BoundingBoxAround(center, radiusKm)
+
+	query = query.Where("latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if !box.WrapsLng {
+		query = query.Where("longitude BETWEEN ? AND ?", box.MinLng, box.MaxLng)
 	}
-	offset := (page - 1) * limit
 
-	// Execute query with pagination
-	err = query.Offset(offset).Limit(limit).Find(&profiles).Error
-	if err != nil {
-		return nil, 0, repository.NewError(err, op, entityUserProfile, "")
+	distance := distanceExpr(center)
+	return query.Where(distance.SQL+" <= ?", append(distance.Vars, radiusKm)...)
+}
+
+// distanceExpr returns the haversine distance in km between a profile's residence and the point
+func distanceExpr(p geo.Point) clause.Expr {
+	return clause.Expr{
+		SQL: "(12742 * asin(sqrt(power(sin(radians(latitude::float8 - ?) / 2), 2) + " +
+			"cos(radians(?)) * cos(radians(latitude::float8)) * power(sin(radians(longitude::float8 - ?) / 2), 2))))",
+		Vars: []interface{}{p.Lat, p.Lat, p.Lng},
+	}
+}
+
+// applyNameFilter matches profiles whose name shares every phonetic word prefix
+// with the search term, or is close enough by trigram similarity
+func applyNameFilter(query *gorm.DB, name string) *gorm.DB {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return query
+	}
+
+	tokens := phonetic.Tokens(name)
+	if len(tokens) == 0 {
+		return query.Where("name % ?", name)
+	}
+
+	conds := make([]string, len(tokens))
+	args := make([]interface{}, 0, len(tokens)+1)
+	for i, token := range tokens {
+		// Prefix match per word so that "Fathima" also finds "Fathimath". The expression
+		// must stay as it is written in the idx_user_profiles_name_phonetic_words_trgm index.
+		conds[i] = "(' ' || name_phonetic) LIKE ?"
+		args = append(args, "% "+token+"%")
+	}
+	args = append(args, name)
+
+	return query.Where("(("+strings.Join(conds, " AND ")+") OR name % ?)", args...)
+}
+
+// Highlight markers used by ts_headline. Control characters are used so that the
+// surrounding text can be HTML-escaped before the markers become <mark> tags.
+const (
+	headlineStartSel  = "\x02"
+	headlineStopSel   = "\x03"
+	headlineDelimiter = "\x1f"
+)
+
+// searchKeywords returns the trimmed keyword search text of the filter
+func searchKeywords(filter repository.ProfileFilter) string {
+	if filter.Keywords == nil {
+		return ""
+	}
+	return strings.TrimSpace(*filter.Keywords)
+}
+
+// searchName returns the trimmed name search text of the filter
+func searchName(filter repository.ProfileFilter) string {
+	if filter.Name == nil {
+		return ""
+	}
+	return strings.TrimSpace(*filter.Name)
+}
+
+// searchSort returns the sort order used for the filter: nearest first for
+// location searches, by relevance for text searches, otherwise newest first
+func searchSort(filter repository.ProfileFilter) string {
+	if filter.Near != nil && filter.RadiusKm != nil {
+		return repository.SortDistance
+	}
+	if searchName(filter) != "" || searchKeywords(filter) != "" {
+		return repository.SortRelevance
+	}
+	return repository.SortNewest
+}
+
+// completenessRankFloor is the share of its relevance an empty profile keeps. Complete
+// profiles keep all of it, so among equally relevant matches complete ones come first
+// without burying a strong match behind a weak one.
+const completenessRankFloor = 0.75
+
+// rankExpr returns the relevance score expression of a name or keyword search,
+// weighted by the completeness of the profile
+func rankExpr(filter repository.ProfileFilter) clause.Expr {
+	var parts []string
+	var vars []interface{}
+
+	if name := searchName(filter); name != "" {
+		parts = append(parts, "GREATEST(similarity(name, ?), similarity(name_phonetic, ?))")
+		vars = append(vars, name, phonetic.Key(name))
+	}
+
+	if keywords := searchKeywords(filter); keywords != "" {
+		parts = append(parts, "ts_rank_cd(search_vector, websearch_to_tsquery('english', ?))")
+		vars = append(vars, keywords)
+	}
+
+	if len(parts) == 0 {
+		return clause.Expr{SQL: "0::float8"}
+	}
+
+	return clause.Expr{
+		SQL: fmt.Sprintf("((%s) * (%g + %g * completeness_score / 100.0))::float8",
+			strings.Join(parts, " + "), completenessRankFloor, 1-completenessRankFloor),
+		Vars: vars,
+	}
+}
+
+// selectSearchColumns adds the relevance rank and keyword headline columns to the query
+func selectSearchColumns(query *gorm.DB, filter repository.ProfileFilter) *gorm.DB {
+	rank := rankExpr(filter)
+	vars := append([]interface{}{}, rank.Vars...)
+
+	headlineSQL := "''"
+	if keywords := searchKeywords(filter); keywords != "" {
+		headlineSQL = "ts_headline('english', concat_ws(' ', occupation, education, about_me), " +
+			"websearch_to_tsquery('english', ?), ?)"
+		vars = append(vars, keywords, fmt.Sprintf(
+			`MaxFragments=3, MaxWords=20, MinWords=5, StartSel="%s", StopSel="%s", FragmentDelimiter="%s"`,
+			headlineStartSel, headlineStopSel, headlineDelimiter))
+	}
+
+	distanceSQL := "NULL::float8"
+	if filter.Near != nil {
+		distance := distanceExpr(*filter.Near)
+		distanceSQL = distance.SQL
+		vars = append(vars, distance.Vars...)
+	}
+
+	return query.Select(
+		"user_profiles.*, "+rank.SQL+" AS search_rank, "+headlineSQL+" AS search_headline, "+
+			distanceSQL+" AS distance_km",
+		vars...,
+	)
+}
+
+// applyKeyset restricts the query to rows that sort after the cursor
+func applyKeyset(query *gorm.DB, filter repository.ProfileFilter, after *repository.Cursor) *gorm.DB {
+	switch after.Sort {
+	case repository.SortDistance:
+		distance := distanceExpr(*filter.Near)
+		vars := append(append(append([]interface{}{}, distance.Vars...), after.Distance), distance.Vars...)
+		vars = append(vars, after.Distance, after.ID)
+		return query.Where(fmt.Sprintf("(%s > ? OR (%s = ? AND id > ?))", distance.SQL, distance.SQL), vars...)
+	case repository.SortRelevance:
+		rank := rankExpr(filter)
+		vars := append(append(append([]interface{}{}, rank.Vars...), after.Rank), rank.Vars...)
+		vars = append(vars, after.Rank, after.ID)
+		return query.Where(fmt.Sprintf("(%s < ? OR (%s = ? AND id > ?))", rank.SQL, rank.SQL), vars...)
+	default:
+		return query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+}
+
+// splitHeadline turns a ts_headline result into HTML-safe fragments with matches wrapped in <mark>
+func splitHeadline(headline string) []string {
+	// Without a match ts_headline returns the start of the document unmarked
+	if !strings.Contains(headline, headlineStartSel) {
+		return nil
+	}
+
+	var fragments []string
+	for _, fragment := range strings.Split(headline, headlineDelimiter) {
+		fragment = strings.TrimSpace(fragment)
+		if fragment == "" {
+			continue
+		}
+		fragment = html.EscapeString(fragment)
+		fragment = strings.ReplaceAll(fragment, headlineStartSel, "<mark>")
+		fragment = strings.ReplaceAll(fragment, headlineStopSel, "</mark>")
+		fragments = append(fragments, fragment)
+	}
+
+	return fragments
+}
+
+// profileOrder returns the ORDER BY clause for a search: by relevance when
+// searching by name or keywords, otherwise newest profiles first
+func profileOrder(filter repository.ProfileFilter) clause.OrderBy {
+	switch searchSort(filter) {
+	case repository.SortDistance:
+		return clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: "distance_km", Raw: true}},
+			{Column: clause.Column{Name: "id"}},
+		}}
+	case repository.SortRelevance:
+		return clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: "search_rank", Raw: true}, Desc: true},
+			{Column: clause.Column{Name: "id"}},
+		}}
 	}
 
-	return profiles, total, nil
+	return clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: "created_at"}, Desc: true},
+		{Column: clause.Column{Name: "id"}, Desc: true},
+	}}
 }
 
-// WithTransaction executes operations within a database transaction
+// WithTransaction executes operations within a database transaction, joining the one
+// ctx carries so that services calling each other inside a transaction share it
 func (r *UserProfileRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
 	const op = "WithTransaction"
 
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return inTransaction(ctx, r.db, func(tx *gorm.DB) error {
 		// Create a new context with the transaction
-		txCtx := context.WithValue(ctx, "tx", tx)
+		txCtx := context.WithValue(ctx, txKey, tx)
 
 		// Execute the function with the transaction context
 		err := fn(txCtx)
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileBlockRepository defines operations for working with profile blocks
+type ProfileBlockRepository interface {
+	// Create blocks a profile; blocking an already blocked profile is not an error
+	Create(ctx context.Context, block *model.ProfileBlock) error
+
+	// Delete removes a block
+	Delete(ctx context.Context, profileID, blockedProfileID uuid.UUID) error
+
+	// ListByProfileID retrieves the blocks made by a profile, newest first
+	ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileBlock, error)
+
+	// ListRelatedProfileIDs retrieves the IDs of profiles blocked by, or blocking, a profile
+	ListRelatedProfileIDs(ctx context.Context, profileID uuid.UUID) ([]uuid.UUID, error)
+
+	// IsBlockedForAccount reports whether a profile has blocked, or was blocked by, any profile of an account
+	IsBlockedForAccount(ctx context.Context, userID, profileID uuid.UUID) (bool, error)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileBoostRepository defines operations for working with profile boosts
+type ProfileBoostRepository interface {
+	// CreateWithinQuota adds a boost unless the user already started the given number of
+	// boosts since the given time, and reports whether it was added. A boost overlapping
+	// another boost of the same profile fails with ErrDuplicateKey.
+	CreateWithinQuota(ctx context.Context, boost *model.ProfileBoost, since time.Time, quota int) (bool, error)
+
+	// ListByProfileID retrieves the boosts of a profile, newest first
+	ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileBoost, error)
+
+	// SearchBoosted retrieves up to limit profiles matching the filter that are boosted at
+	// the given time, least shown first so that boosts share the top placements fairly
+	SearchBoosted(ctx context.Context, filter ProfileFilter, at time.Time, limit int) ([]*ProfileSearchHit, error)
+
+	// CountBoosted counts the profiles matching the filter that are boosted at the given time
+	CountBoosted(ctx context.Context, filter ProfileFilter, at time.Time) (int64, error)
+
+	// RecordImpressions counts one impression for each of the boosts
+	RecordImpressions(ctx context.Context, boostIDs []uuid.UUID) error
+
+	// RecordClick counts a click by the viewer for a boost that placed the profile in search
+	// results, if it is the profile's boost and still active at the given time. Each viewer
+	// counts at most one click per boost.
+	RecordClick(ctx context.Context, boostID, profileID, viewerUserID uuid.UUID, at time.Time) error
+}
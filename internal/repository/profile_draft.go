@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileDraftRepository defines operations for working with profile drafts.
+// All methods take part in a transaction started by UserProfileRepository.WithTransaction.
+type ProfileDraftRepository interface {
+	// SaveStep merges the fields of a step into the account's draft, creating the draft
+	// if the account has none. Fields of other steps are kept; the merge is atomic, so
+	// steps saved concurrently don't overwrite each other.
+	SaveStep(ctx context.Context, draft *model.ProfileDraft) error
+
+	// GetByUserID retrieves the draft of an account
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*model.ProfileDraft, error)
+
+	// Delete removes a draft. It returns ErrNotFound if the draft was already removed.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
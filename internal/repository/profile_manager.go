@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileManagerRepository defines operations for working with delegated profile managers
+type ProfileManagerRepository interface {
+	// Create adds a new invitation to manage a profile. It returns ErrDuplicateKey
+	// if the user already has a pending or active delegation for the profile.
+	Create(ctx context.Context, manager *model.ProfileManager) error
+
+	// GetByID retrieves a delegation by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*model.ProfileManager, error)
+
+	// GetActive retrieves the accepted delegation of a user for a profile
+	GetActive(ctx context.Context, profileID, managerUserID uuid.UUID) (*model.ProfileManager, error)
+
+	// ListByProfileID retrieves the pending and active delegations of a profile, oldest first
+	ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileManager, error)
+
+	// ListByManagerUserID retrieves the pending and active delegations of a user
+	// along with the profiles they are for, oldest first
+	ListByManagerUserID(ctx context.Context, managerUserID uuid.UUID) ([]*model.ProfileManager, error)
+
+	// UpdateScopes replaces the scopes of a pending or active delegation
+	UpdateScopes(ctx context.Context, manager *model.ProfileManager) error
+
+	// Accept activates a pending invitation. It returns ErrInvalidOperation if the
+	// delegation is not pending.
+	Accept(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// Revoke ends a pending or active delegation. It returns ErrInvalidOperation if
+	// the delegation was already revoked.
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// RevokeAllForProfile ends every pending or active delegation of a profile, such as
+	// when it changes owner, and returns how many were revoked
+	RevokeAllForProfile(ctx context.Context, profileID uuid.UUID, at time.Time) (int64, error)
+}
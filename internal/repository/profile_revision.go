@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileRevisionRepository defines operations for working with profile revisions.
+// All methods take part in a transaction started by UserProfileRepository.WithTransaction.
+type ProfileRevisionRepository interface {
+	// Create records a revision
+	Create(ctx context.Context, revision *model.ProfileRevision) error
+
+	// GetByID retrieves a revision of a profile
+	GetByID(ctx context.Context, profileID, id uuid.UUID) (*model.ProfileRevision, error)
+
+	// ListByProfileID retrieves the revisions of a profile, most recent first
+	ListByProfileID(ctx context.Context, profileID uuid.UUID, page, limit int) ([]*model.ProfileRevision, int64, error)
+
+	// ListAfter retrieves the revisions of a profile made after the given one, most recent first
+	ListAfter(ctx context.Context, revision *model.ProfileRevision) ([]*model.ProfileRevision, error)
+
+	// ListFlagged retrieves flagged revisions of all profiles, most recent first
+	ListFlagged(ctx context.Context, page, limit int) ([]*model.ProfileRevision, int64, error)
+
+	// CountFieldUpdates counts the updates of a profile since the given time that changed a field
+	CountFieldUpdates(ctx context.Context, profileID uuid.UUID, field string, since time.Time) (int64, error)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfilePrivacyRepository defines operations for working with profile privacy settings
+type ProfilePrivacyRepository interface {
+	// GetByProfileID retrieves the privacy settings of a profile, or the defaults
+	// if its owner never changed them
+	GetByProfileID(ctx context.Context, profileID uuid.UUID) (*model.ProfilePrivacySettings, error)
+
+	// Upsert creates or replaces the privacy settings of a profile
+	Upsert(ctx context.Context, settings *model.ProfilePrivacySettings) error
+}
+
+// ProfileShareLinkRepository defines operations for working with public profile share links
+type ProfileShareLinkRepository interface {
+	// Create records a new share link
+	Create(ctx context.Context, link *model.ProfileShareLink) error
+
+	// GetByID retrieves a share link by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*model.ProfileShareLink, error)
+
+	// ListByProfileID retrieves the share links of a profile, newest first
+	ListByProfileID(ctx context.Context, profileID uuid.UUID) ([]*model.ProfileShareLink, error)
+
+	// Revoke disables a share link. It returns ErrInvalidOperation if the link is already revoked.
+	Revoke(ctx context.Context, id uuid.UUID, revokedBy uuid.UUID, at time.Time) error
+
+	// RecordView counts a view of a share link and returns the link. It returns
+	// ErrNotFound if the link doesn't exist, is revoked, has expired at the given time or
+	// belongs to a deleted profile.
+	RecordView(ctx context.Context, id uuid.UUID, at time.Time) (*model.ProfileShareLink, error)
+}
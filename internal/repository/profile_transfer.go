@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileTransferRepository defines operations for working with profile ownership transfers.
+// All methods take part in a transaction started by UserProfileRepository.WithTransaction.
+type ProfileTransferRepository interface {
+	// Create records a new pending transfer, first expiring any pending transfer of the
+	// profile whose token has lapsed. It returns ErrDuplicateKey if the profile already
+	// has a pending transfer.
+	Create(ctx context.Context, transfer *model.ProfileTransfer) error
+
+	// GetByID retrieves a transfer by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*model.ProfileTransfer, error)
+
+	// GetByTokenHash retrieves a transfer by the hash of its one-time token
+	GetByTokenHash(ctx context.Context, tokenHash string) (*model.ProfileTransfer, error)
+
+	// ListPendingByUserID retrieves the transfers a user has offered or been offered
+	// that can still be accepted at the given time, newest first
+	ListPendingByUserID(ctx context.Context, userID uuid.UUID, at time.Time) ([]*model.ProfileTransfer, error)
+
+	// Accept marks a transfer accepted by the request with the given ID. It returns
+	// ErrInvalidOperation if the transfer is no longer pending or has expired.
+	Accept(ctx context.Context, id uuid.UUID, at time.Time, requestID string) error
+
+	// Cancel withdraws or declines a pending transfer. It returns ErrInvalidOperation
+	// if the transfer is no longer pending.
+	Cancel(ctx context.Context, id uuid.UUID, cancelledBy uuid.UUID, at time.Time) error
+}
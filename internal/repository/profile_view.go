@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// ProfileViewRepository defines operations for working with profile views
+type ProfileViewRepository interface {
+	// Record counts a view of a profile by another profile
+	Record(ctx context.Context, profileID, viewerProfileID uuid.UUID, viewedAt time.Time) error
+
+	// ListViewers retrieves the views of a profile with the viewer's profile, most recent first,
+	// leaving out deleted viewers and viewers blocked either way
+	ListViewers(ctx context.Context, profileID uuid.UUID, page, limit int) ([]*model.ProfileView, int64, error)
+}
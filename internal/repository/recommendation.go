@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// RecommendationRepository defines operations for working with match recommendations
+type RecommendationRepository interface {
+	// ListProfilesDue retrieves profiles with an ID greater than afterID that have
+	// no recommendation run for the given day, ordered by ID
+	ListProfilesDue(ctx context.Context, day time.Time, afterID uuid.UUID, limit int) ([]*model.UserProfile, error)
+
+	// FindCandidates retrieves candidate profiles for a profile that match the filter,
+	// excluding the profile itself, blocked profiles and previously recommended ones
+	FindCandidates(ctx context.Context, profileID uuid.UUID, filter ProfileFilter, opts CandidateOptions) ([]*model.UserProfile, error)
+
+	// SaveRun stores a recommendation run with its recommendations. It reports false
+	// without storing anything when the profile already has a run for that day.
+	SaveRun(ctx context.Context, run *model.RecommendationRun, recommendations []*model.Recommendation) (bool, error)
+
+	// ListLatest retrieves the open recommendations of a profile's most recent run
+	// in rank order, with their candidate profiles loaded
+	ListLatest(ctx context.Context, profileID uuid.UUID, page, limit int) ([]*model.Recommendation, int64, error)
+
+	// GetByID retrieves a recommendation by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Recommendation, error)
+
+	// UpdateStatus records feedback on a recommendation
+	UpdateStatus(ctx context.Context, id uuid.UUID, status model.RecommendationStatus) error
+}
+
+// CandidateOptions controls which previously recommended profiles are excluded from candidates
+type CandidateOptions struct {
+	// RepeatSince excludes candidates recommended on or after this day
+	RepeatSince time.Time
+	// SkippedSince excludes skipped candidates recommended on or after this day
+	SkippedSince time.Time
+	// Limit caps the number of candidates returned
+	Limit int
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/geo"
 )
 
 // UserProfileRepository defines operations for working with user profiles
@@ -13,37 +14,199 @@ type UserProfileRepository interface {
 	// Create adds a new user profile
 	Create(ctx context.Context, profile *model.UserProfile) error
 
+	// CreateWithinLimit adds a new profile to its account unless the account already owns
+	// limit profiles, and makes it the account's active profile if it has none. It reports
+	// whether the profile was created; the check and the insert are atomic per account.
+	CreateWithinLimit(ctx context.Context, profile *model.UserProfile, limit int) (bool, error)
+
 	// GetByID retrieves a profile by its ID
 	GetByID(ctx context.Context, id uuid.UUID) (*model.UserProfile, error)
 
-	// GetByUserID retrieves a profile by the associated user ID
-	GetByUserID(ctx context.Context, userID uuid.UUID) (*model.UserProfile, error)
+	// GetByCode retrieves a profile by its public code, e.g. QK-104523
+	GetByCode(ctx context.Context, code string) (*model.UserProfile, error)
+
+	// ListByIDs retrieves the profiles with the given IDs that exist and aren't deleted, in no particular order
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*model.UserProfile, error)
+
+	// ListByUserID retrieves the profiles owned by an account, oldest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserProfile, error)
+
+	// GetActiveByUserID retrieves the profile an account last switched to
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*model.UserProfile, error)
+
+	// SetActive makes one of an account's profiles its active profile
+	SetActive(ctx context.Context, userID, profileID uuid.UUID) error
 
 	// Update updates an existing profile
 	Update(ctx context.Context, profile *model.UserProfile) error
 
-	// Delete soft-deletes a profile
-	Delete(ctx context.Context, id uuid.UUID) error
+	// UpdateCompletenessScore stores the completeness score of a profile without touching its update time
+	UpdateCompletenessScore(ctx context.Context, id uuid.UUID, score int) error
+
+	// Delete soft-deletes a profile, recording the user who deleted it
+	Delete(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+
+	// Transfer re-points a profile from one account to another unless the receiving
+	// account already owns limit profiles, and reports whether it did. It returns
+	// ErrInvalidOperation if fromUserID no longer owns the profile and ErrDuplicateKey
+	// if the receiving account would then own two profiles of its own.
+	Transfer(ctx context.Context, profileID, fromUserID, toUserID uuid.UUID, limit int) (bool, error)
+
+	// ListDeletedByUserID retrieves the profiles of an account deleted after the given time
+	// and not yet purged, most recently deleted first
+	ListDeletedByUserID(ctx context.Context, userID uuid.UUID, deletedAfter time.Time) ([]*model.UserProfile, error)
+
+	// GetDeletedByID retrieves a deleted profile that has not been purged
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*model.UserProfile, error)
 
-	// SearchProfiles searches for profiles with pagination based on filter criteria
-	SearchProfiles(ctx context.Context, filter ProfileFilter, page, limit int) ([]*model.UserProfile, int64, error)
+	// RestoreWithinLimit undeletes a profile deleted after the given time unless its account
+	// already owns limit profiles, and reports whether it did. It returns ErrNotFound if the
+	// profile is no longer restorable and ErrDuplicateKey if the account would then own two
+	// profiles of its own.
+	RestoreWithinLimit(ctx context.Context, profile *model.UserProfile, deletedAfter time.Time, limit int) (bool, error)
+
+	// ListPurgeable retrieves the IDs of profiles deleted before the given time and not yet purged
+	ListPurgeable(ctx context.Context, deletedBefore time.Time, limit int) ([]uuid.UUID, error)
+
+	// Purge anonymizes a profile deleted before the given time, keeping only what is not
+	// identifying. It returns ErrNotFound if the profile was restored or purged meanwhile.
+	Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error
+
+	// SearchProfiles searches for profiles based on filter criteria, using offset or keyset pagination
+	SearchProfiles(ctx context.Context, filter ProfileFilter, page PageRequest) (*ProfileSearchPage, error)
+
+	// CountFacets counts matching profiles per facet value, each facet computed under the other active filters
+	CountFacets(ctx context.Context, filter ProfileFilter) (*ProfileFacets, error)
+
+	// FindSimilar retrieves profiles of the same gender as the given profile whose age is
+	// within the window, closest in age first. It is the candidate pool for similarity ranking.
+	FindSimilar(ctx context.Context, profile *model.UserProfile, opts SimilarOptions) ([]*model.UserProfile, error)
+
+	// ListUnscored retrieves up to limit profiles with a completeness score of 0 and an ID
+	// after the given one, ordered by ID
+	ListUnscored(ctx context.Context, afterID uuid.UUID, limit int) ([]*model.UserProfile, error)
+
+	// BackfillNamePhonetics computes phonetic name keys for profiles that don't have one yet
+	BackfillNamePhonetics(ctx context.Context) (int64, error)
+
+	// BackfillResidenceLocations sets the residence coordinates of profiles that don't have
+	// them yet from their residence city or home district
+	BackfillResidenceLocations(ctx context.Context) (int64, error)
 
 	// WithTransaction executes operations within a database transaction
 	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
 }
 
-// ProfileFilter defines criteria for filtering profiles
+// ProfileFilter defines criteria for filtering profiles.
+// It is serialized as JSON when stored with a saved search.
 type ProfileFilter struct {
-	IsGroom                *bool
-	Community              []model.Community
-	Nationality            []model.Nationality
-	MaritalStatus          []model.MaritalStatus
-	HomeDistrict           []model.HomeDistrict
-	MinAge                 *int
-	MaxAge                 *int
-	MinHeight              *float64
-	MaxHeight              *float64
-	IsPhysicallyChallenged *bool
-	CreatedAfter           *time.Time
-	CreatedBefore          *time.Time
+	Name                   *string               `json:"name,omitempty"`     // matches spelling variants of the name, ranked by similarity
+	Keywords               *string               `json:"keywords,omitempty"` // free text over name, occupation, about me and location, ranked by relevance
+	IsGroom                *bool                 `json:"is_groom,omitempty"`
+	Community              []model.Community     `json:"community,omitempty"`
+	Nationality            []model.Nationality   `json:"nationality,omitempty"`
+	MaritalStatus          []model.MaritalStatus `json:"marital_status,omitempty"`
+	HomeDistrict           []model.HomeDistrict  `json:"home_district,omitempty"`
+	MinAge                 *int                  `json:"min_age,omitempty"`
+	MaxAge                 *int                  `json:"max_age,omitempty"`
+	MinHeight              *float64              `json:"min_height,omitempty"`
+	MaxHeight              *float64              `json:"max_height,omitempty"`
+	IsPhysicallyChallenged *bool                 `json:"is_physically_challenged,omitempty"`
+	MinCompleteness        *int                  `json:"min_completeness,omitempty"` // percentage, see the completeness package
+	CreatedAfter           *time.Time            `json:"created_after,omitempty"`
+	CreatedBefore          *time.Time            `json:"created_before,omitempty"`
+	Near                   *geo.Point            `json:"near,omitempty"`      // residence within RadiusKm of this point, nearest first
+	RadiusKm               *float64              `json:"radius_km,omitempty"` // required with Near
+	// ViewerUserID is the account the results are shown to. Profiles blocked by, or
+	// blocking, any of its profiles are left out. It is never stored with the filter.
+	ViewerUserID *uuid.UUID `json:"-"`
+}
+
+// SimilarOptions limits the candidate pool of a similar-profile lookup
+type SimilarOptions struct {
+	AgeWindowYears int
+	Limit          int
+}
+
+// ProfileSearchHit is a profile matched by a search along with its relevance details
+type ProfileSearchHit struct {
+	Profile *model.UserProfile
+	// Rank is the relevance score of a name or keyword search, zero otherwise
+	Rank float64
+	// Highlights are text fragments matching the keywords, with matches wrapped in <mark> tags
+	Highlights []string
+	// DistanceKm is the distance from the search location, set for distance searches
+	DistanceKm *float64
+	// BoostID is the active boost that placed the profile, set for boosted placements
+	BoostID *uuid.UUID
+}
+
+// FacetCount is the number of matching profiles for a single facet value
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// ProfileFacets holds the facet counts of a search, sorted by descending count
+type ProfileFacets struct {
+	Community     []FacetCount
+	Nationality   []FacetCount
+	MaritalStatus []FacetCount
+	HomeDistrict  []FacetCount
+	AgeRange      []FacetCount
+}
+
+// Sort orders used by profile search
+const (
+	SortNewest    = "newest"    // created_at DESC, id DESC
+	SortRelevance = "relevance" // rank DESC, id ASC; used for name and keyword searches, rank weighted by completeness
+	SortDistance  = "distance"  // distance ASC, id ASC; used for searches near a location
+)
+
+// CountMode selects how the total number of search matches is computed
+type CountMode string
+
+const (
+	// CountNone skips counting
+	CountNone CountMode = "none"
+	// CountExact runs a COUNT(*) over all matches
+	CountExact CountMode = "exact"
+	// CountEstimate uses the query planner's row estimate
+	CountEstimate CountMode = "estimate"
+)
+
+// Cursor is the sort key of the last profile of a page, used for keyset pagination
+type Cursor struct {
+	Sort      string    `json:"s"`
+	Rank      float64   `json:"r,omitempty"`
+	Distance  float64   `json:"d,omitempty"`
+	CreatedAt time.Time `json:"c,omitempty"`
+	ID        uuid.UUID `json:"i"`
+	// Exclude leaves profiles already featured on the first page by other means out of
+	// the following ones
+	Exclude []uuid.UUID `json:"x,omitempty"`
+}
+
+// PageRequest describes which page of search results to fetch.
+// When After is set the page continues after that cursor and Page is ignored.
+type PageRequest struct {
+	Page  int
+	Limit int
+	After *Cursor
+	Count CountMode
+	// Reserved is the number of first page slots taken by other placements, such as
+	// boosted profiles. The first page holds Limit-Reserved hits and the following
+	// numbered pages start that many rows earlier, so that no profile is skipped.
+	Reserved int
+}
+
+// ProfileSearchPage is a page of profile search results
+type ProfileSearchPage struct {
+	Hits []*ProfileSearchHit
+	// Total is nil when counting was skipped
+	Total *int64
+	// TotalIsEstimate is set when Total comes from planner statistics
+	TotalIsEstimate bool
+	// Next points after the last hit, nil on the last page
+	Next *Cursor
 }
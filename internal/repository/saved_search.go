@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// SavedSearchRepository defines operations for working with saved searches
+type SavedSearchRepository interface {
+	// Create adds a new saved search
+	Create(ctx context.Context, search *model.SavedSearch) error
+
+	// GetByID retrieves a saved search by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*model.SavedSearch, error)
+
+	// ListByUserID retrieves all saved searches of a user, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.SavedSearch, error)
+
+	// Delete soft-deletes a saved search
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ListDue retrieves saved searches whose alert frequency makes them due at the given time
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*model.SavedSearch, error)
+
+	// Claim locks a saved search for an alert run within the caller's transaction and returns
+	// it, or nil if another worker holds it or it is no longer due
+	Claim(ctx context.Context, id uuid.UUID, now time.Time) (*model.SavedSearch, error)
+
+	// MarkRun records the time up to which a saved search has been checked for new matches
+	MarkRun(ctx context.Context, id uuid.UUID, runAt time.Time) error
+}
+
+// SearchAlertRepository defines operations for working with saved search alerts
+type SearchAlertRepository interface {
+	// CreateIfNew adds an alert unless one already exists for the same saved search and profile.
+	// It reports whether the alert was created.
+	CreateIfNew(ctx context.Context, alert *model.SearchAlert) (bool, error)
+
+	// ListByUserID retrieves a user's alerts with pagination, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID, page, limit int) ([]*model.SearchAlert, int64, error)
+
+	// ListUnnotifiedIDs retrieves the IDs of a saved search's alerts the owner hasn't been notified about
+	ListUnnotifiedIDs(ctx context.Context, savedSearchID uuid.UUID) ([]uuid.UUID, error)
+
+	// MarkNotified records that the owner has been notified about the alerts
+	MarkNotified(ctx context.Context, ids []uuid.UUID, notifiedAt time.Time) error
+}
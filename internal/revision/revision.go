@@ -0,0 +1,113 @@
+package revision
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// Fields lists the JSON names of the profile fields whose changes are recorded: those
+// a profile update sets. Coordinates follow from the residence city and the score from
+// the rest of the profile, so neither is recorded.
+var Fields = []string{
+	"is_groom",
+	"profile_created_by",
+	"name",
+	"date_of_birth",
+	"date_of_birth_calendar",
+	"community",
+	"nationality",
+	"height",
+	"weight",
+	"marital_status",
+	"is_physically_challenged",
+	"home_district",
+	"residence_city",
+	"education",
+	"occupation",
+	"about_me",
+	"father_occupation",
+	"mother_occupation",
+	"siblings",
+}
+
+// SensitiveFields lists the fields that matches rely on most, so that changing them
+// often is suspicious
+var SensitiveFields = []string{
+	"date_of_birth",
+	"marital_status",
+}
+
+// Change is the value of a field before and after a change, as JSON
+type Change struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// Changes are the changed fields of a profile, keyed by their JSON name
+type Changes map[string]Change
+
+// Diff returns the recorded fields whose values differ between two versions of a
+// profile. A nil before diffs against an empty profile, as for a new one.
+func Diff(before, after *model.UserProfile) (Changes, error) {
+	if before == nil {
+		before = &model.UserProfile{}
+	}
+
+	old, err := fieldValues(before)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := fieldValues(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(Changes)
+	for _, field := range Fields {
+		if !bytes.Equal(old[field], updated[field]) {
+			changes[field] = Change{Old: old[field], New: updated[field]}
+		}
+	}
+	return changes, nil
+}
+
+// Undo sets the changed fields of a profile back to their old values
+func Undo(profile *model.UserProfile, changes Changes) error {
+	old := make(map[string]json.RawMessage, len(changes))
+	for field, change := range changes {
+		old[field] = change.Old
+	}
+
+	data, err := json.Marshal(old)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshaling sets only the fields present, leaving the rest of the profile as it is
+	return json.Unmarshal(data, profile)
+}
+
+// Parse decodes the changes stored with a revision
+func Parse(data []byte) (Changes, error) {
+	var changes Changes
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// fieldValues returns the JSON value of each field of a profile
+func fieldValues(profile *model.UserProfile) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/erasure"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	accountErasureServiceName = "AccountErasureService"
+
+	// erasureStaleAfter is how long an erasure may stay running before it is taken to
+	// be abandoned by a stopped worker and resumed
+	erasureStaleAfter = 15 * time.Minute
+
+	// receiptBatchSize is how many receipts are read at a time when verifying the chain
+	receiptBatchSize = 500
+
+	// pseudonymizedSummaryKey counts, in a receipt, the kept records whose account ID was replaced
+	pseudonymizedSummaryKey = "pseudonymized_records"
+)
+
+// accountErasureService implements AccountErasureService
+type accountErasureService struct {
+	erasureRepo repository.AccountErasureRepository
+	profileRepo repository.UserProfileRepository
+	logger      *logger.Logger
+}
+
+// NewAccountErasureService creates a new account erasure service
+func NewAccountErasureService(
+	erasureRepo repository.AccountErasureRepository,
+	profileRepo repository.UserProfileRepository,
+	logger *logger.Logger,
+) AccountErasureService {
+	return &accountErasureService{
+		erasureRepo: erasureRepo,
+		profileRepo: profileRepo,
+		logger:      logger,
+	}
+}
+
+// RequestErasure queues the erasure of the user's account. Repeating the request while
+// the erasure is in progress returns the same erasure.
+func (s *accountErasureService) RequestErasure(ctx context.Context, userID uuid.UUID) (*dto.AccountErasureResponse, error) {
+	const op = "RequestErasure"
+
+	subjectHash := erasure.SubjectHash(userID)
+	record := &model.AccountErasure{
+		UserID:      &userID,
+		SubjectHash: subjectHash,
+		Status:      model.AccountErasureStatusPending,
+		RequestID:   requestIDFromContext(ctx),
+	}
+	if err := s.erasureRepo.Create(ctx, record); err != nil {
+		if !isRepoDuplicate(err) {
+			s.logger.Error("Failed to create account erasure",
+				zap.String("user_id", userID.String()),
+				zap.Error(err))
+			return nil, NewError(ErrInternal, op, accountErasureServiceName, "failed to request account erasure")
+		}
+
+		record, err = s.erasureRepo.GetLatestBySubjectHash(ctx, subjectHash)
+		if err != nil {
+			s.logger.Error("Failed to get account erasure in progress",
+				zap.String("user_id", userID.String()),
+				zap.Error(err))
+			return nil, NewError(ErrInternal, op, accountErasureServiceName, "failed to request account erasure")
+		}
+		return dto.AccountErasureFromModel(record, len(model.AccountErasureSteps)), nil
+	}
+
+	s.logger.Info("Account erasure requested",
+		zap.String("user_id", userID.String()),
+		zap.String("erasure_id", record.ID.String()),
+		zap.String("request_id", record.RequestID))
+
+	return dto.AccountErasureFromModel(record, len(model.AccountErasureSteps)), nil
+}
+
+// GetErasure retrieves the latest erasure of the user's account, with its receipt once complete
+func (s *accountErasureService) GetErasure(ctx context.Context, userID uuid.UUID) (*dto.AccountErasureResponse, error) {
+	const op = "GetErasure"
+
+	record, err := s.erasureRepo.GetLatestBySubjectHash(ctx, erasure.SubjectHash(userID))
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, accountErasureServiceName, "no erasure was requested for your account")
+		}
+
+		s.logger.Error("Failed to get account erasure",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, accountErasureServiceName, "failed to retrieve account erasure")
+	}
+
+	return dto.AccountErasureFromModel(record, len(model.AccountErasureSteps)), nil
+}
+
+// ProcessErasures carries out the unfinished erasures one at a time until none is left.
+// An erasure that fails stays running and is resumed once it is stale.
+func (s *accountErasureService) ProcessErasures(ctx context.Context) error {
+	const op = "ProcessErasures"
+
+	for ctx.Err() == nil {
+		now := time.Now()
+		record, err := s.erasureRepo.ClaimNext(ctx, now, now.Add(-erasureStaleAfter))
+		if err != nil {
+			return NewError(ErrInternal, op, accountErasureServiceName, err.Error())
+		}
+		if record == nil {
+			break
+		}
+
+		if err := s.processErasure(ctx, record); err != nil {
+			s.logger.Error("Failed to erase account",
+				zap.String("erasure_id", record.ID.String()),
+				zap.Int("completed_steps", record.CompletedSteps),
+				zap.Error(err))
+		}
+	}
+
+	return ctx.Err()
+}
+
+// VerifyReceipts checks the whole receipt chain, reporting the first receipt that
+// doesn't match its hash or its place in the chain
+func (s *accountErasureService) VerifyReceipts(ctx context.Context) (*dto.ErasureReceiptChainResponse, error) {
+	const op = "VerifyReceipts"
+
+	var chain erasure.Chain
+	response := &dto.ErasureReceiptChainResponse{Valid: true}
+
+	var after int64
+	for {
+		records, err := s.erasureRepo.ListReceipts(ctx, after, receiptBatchSize)
+		if err != nil {
+			s.logger.Error("Failed to list erasure receipts", zap.Error(err))
+			return nil, NewError(ErrInternal, op, accountErasureServiceName, "failed to verify erasure receipts")
+		}
+
+		for _, record := range records {
+			if _, err := chain.Next(record.Receipt, record.ReceiptHash); err != nil {
+				s.logger.Warn("Erasure receipt chain is broken",
+					zap.String("erasure_id", record.ID.String()),
+					zap.Int64("sequence", *record.Sequence))
+
+				response.Valid = false
+				response.BrokenAtSequence = record.Sequence
+				response.LastHash = chain.LastHash()
+				return response, nil
+			}
+			response.Receipts++
+			after = *record.Sequence
+		}
+
+		if len(records) < receiptBatchSize {
+			break
+		}
+	}
+
+	response.LastHash = chain.LastHash()
+	return response, nil
+}
+
+// processErasure carries out the remaining steps of an erasure, each in its own
+// transaction along with the progress it makes, then seals the receipt
+func (s *accountErasureService) processErasure(ctx context.Context, record *model.AccountErasure) error {
+	if record.UserID == nil {
+		return errors.New("erasure has no account ID")
+	}
+	userID := *record.UserID
+
+	rows := make(map[string]int64)
+	if len(record.Summary) > 0 {
+		if err := json.Unmarshal(record.Summary, &rows); err != nil {
+			return err
+		}
+	}
+
+	for i := record.CompletedSteps; i < len(model.AccountErasureSteps); i++ {
+		step := model.AccountErasureSteps[i]
+
+		// The counts only advance once the step's transaction commits
+		var updated map[string]int64
+		err := s.profileRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+			erased, err := s.erasureRepo.EraseStep(txCtx, userID, step, time.Now())
+			if err != nil {
+				return err
+			}
+
+			updated = copyRows(rows)
+			updated[string(step)] += erased
+			summary, err := json.Marshal(updated)
+			if err != nil {
+				return err
+			}
+			return s.erasureRepo.SaveProgress(txCtx, record.ID, i+1, summary)
+		})
+		if err != nil {
+			return err
+		}
+
+		rows = updated
+		record.CompletedSteps = i + 1
+	}
+
+	return s.completeErasure(ctx, record, userID, rows)
+}
+
+// completeErasure sweeps the steps once more for records added while the erasure ran,
+// pseudonymizes the records kept, and appends the receipt to the chain, all in one
+// transaction, so that the account ID is only cleared once nothing refers to it
+func (s *accountErasureService) completeErasure(
+	ctx context.Context,
+	record *model.AccountErasure,
+	userID uuid.UUID,
+	rows map[string]int64,
+) error {
+	err := s.profileRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		completedAt := time.Now()
+		sealed := copyRows(rows)
+
+		for _, step := range model.AccountErasureSteps {
+			erased, err := s.erasureRepo.EraseStep(txCtx, userID, step, completedAt)
+			if err != nil {
+				return err
+			}
+			sealed[string(step)] += erased
+		}
+
+		pseudonymized, err := s.erasureRepo.Pseudonymize(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		sealed[pseudonymizedSummaryKey] = pseudonymized
+
+		last, err := s.erasureRepo.LatestReceipt(txCtx)
+		if err != nil {
+			return err
+		}
+		sequence, previousHash := int64(1), ""
+		if last != nil {
+			sequence, previousHash = *last.Sequence+1, last.ReceiptHash
+		}
+
+		receipt, receiptHash, err := erasure.Seal(&erasure.Receipt{
+			ErasureID:    record.ID,
+			Sequence:     sequence,
+			SubjectHash:  record.SubjectHash,
+			RequestID:    record.RequestID,
+			RequestedAt:  record.CreatedAt.UTC(),
+			CompletedAt:  completedAt.UTC(),
+			Rows:         sealed,
+			PreviousHash: previousHash,
+		})
+		if err != nil {
+			return err
+		}
+
+		summary, err := json.Marshal(sealed)
+		if err != nil {
+			return err
+		}
+
+		record.Summary = summary
+		record.Sequence = &sequence
+		record.PreviousHash = previousHash
+		record.ReceiptHash = receiptHash
+		record.Receipt = receipt
+		record.CompletedAt = &completedAt
+		return s.erasureRepo.Complete(txCtx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Account erased",
+		zap.String("erasure_id", record.ID.String()),
+		zap.Int64("sequence", *record.Sequence),
+		zap.String("receipt_hash", record.ReceiptHash),
+		zap.String("request_id", record.RequestID))
+
+	return nil
+}
+
+// copyRows returns a copy of row counts
+func copyRows(rows map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(rows)+1)
+	for step, n := range rows {
+		copied[step] = n
+	}
+	return copied
+}
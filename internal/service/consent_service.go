@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/requestinfo"
+	"go.uber.org/zap"
+)
+
+const (
+	consentServiceName = "ConsentService"
+)
+
+// consentService implements ConsentService
+type consentService struct {
+	consentRepo repository.ConsentRecordRepository
+	cfg         config.ConsentConfig
+	logger      *logger.Logger
+}
+
+// NewConsentService creates a new consent service
+func NewConsentService(
+	consentRepo repository.ConsentRecordRepository,
+	cfg config.ConsentConfig,
+	logger *logger.Logger,
+) ConsentService {
+	return &consentService{
+		consentRepo: consentRepo,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+// GetConsents returns where the user stands on each policy
+func (s *consentService) GetConsents(ctx context.Context, userID uuid.UUID) ([]*dto.ConsentResponse, error) {
+	const op = "GetConsents"
+
+	latest, err := s.latestConsents(ctx, userID)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, consentServiceName, "failed to retrieve consents")
+	}
+
+	consents := make([]*dto.ConsentResponse, len(model.ConsentPolicies))
+	for i, policy := range model.ConsentPolicies {
+		consents[i] = dto.ConsentFromModel(policy, s.currentVersion(policy), latest[policy])
+	}
+	return consents, nil
+}
+
+// GrantConsent records the user agreeing to the current version of a policy. Agreeing
+// again to the version already agreed to records nothing.
+func (s *consentService) GrantConsent(ctx context.Context, userID uuid.UUID, req *dto.GrantConsentRequest) (*dto.ConsentResponse, error) {
+	const op = "GrantConsent"
+
+	policy := model.ConsentPolicy(req.Policy)
+	if !policy.IsValid() {
+		return nil, NewError(ErrValidation, op, consentServiceName, fmt.Sprintf("unknown policy %q", req.Policy))
+	}
+
+	// Only the version in force can be agreed to, so that the user can't have been shown an outdated one
+	currentVersion := s.currentVersion(policy)
+	if req.Version != currentVersion {
+		return nil, NewError(ErrValidation, op, consentServiceName,
+			fmt.Sprintf("version %q of %s is not the current one (%q)", req.Version, policy, currentVersion))
+	}
+
+	latest, err := s.latestConsents(ctx, userID)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, consentServiceName, "failed to record consent")
+	}
+	if last := latest[policy]; last != nil && last.Action == model.ConsentActionGranted && last.PolicyVersion == currentVersion {
+		return dto.ConsentFromModel(policy, currentVersion, last), nil
+	}
+
+	record, err := s.record(ctx, userID, policy, currentVersion, model.ConsentActionGranted)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, consentServiceName, "failed to record consent")
+	}
+
+	return dto.ConsentFromModel(policy, currentVersion, record), nil
+}
+
+// WithdrawConsent records the user withdrawing their agreement to the version of a
+// policy they last agreed to. Withdrawing a mandatory policy blocks profile operations
+// until it is accepted again.
+func (s *consentService) WithdrawConsent(ctx context.Context, userID uuid.UUID, policy model.ConsentPolicy) (*dto.ConsentResponse, error) {
+	const op = "WithdrawConsent"
+
+	if !policy.IsValid() {
+		return nil, NewError(ErrValidation, op, consentServiceName, fmt.Sprintf("unknown policy %q", policy))
+	}
+
+	latest, err := s.latestConsents(ctx, userID)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, consentServiceName, "failed to withdraw consent")
+	}
+	last := latest[policy]
+	if last == nil || last.Action != model.ConsentActionGranted {
+		return nil, NewError(ErrValidation, op, consentServiceName, fmt.Sprintf("you haven't agreed to %s", policy))
+	}
+
+	record, err := s.record(ctx, userID, policy, last.PolicyVersion, model.ConsentActionWithdrawn)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, consentServiceName, "failed to withdraw consent")
+	}
+
+	return dto.ConsentFromModel(policy, s.currentVersion(policy), record), nil
+}
+
+// ListConsentHistory lists every grant and withdrawal of the user, newest first
+func (s *consentService) ListConsentHistory(ctx context.Context, userID uuid.UUID) ([]*dto.ConsentRecordResponse, error) {
+	const op = "ListConsentHistory"
+
+	records, err := s.consentRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list consent records",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, consentServiceName, "failed to retrieve consent history")
+	}
+
+	history := make([]*dto.ConsentRecordResponse, len(records))
+	for i, record := range records {
+		history[i] = dto.ConsentRecordFromModel(record)
+	}
+	return history, nil
+}
+
+// MissingConsents returns the mandatory policies whose current version the user hasn't accepted
+func (s *consentService) MissingConsents(ctx context.Context, userID uuid.UUID) ([]model.ConsentPolicy, error) {
+	const op = "MissingConsents"
+
+	latest, err := s.latestConsents(ctx, userID)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, consentServiceName, "failed to check consents")
+	}
+
+	var missing []model.ConsentPolicy
+	for _, policy := range model.ConsentPolicies {
+		if !policy.Mandatory() {
+			continue
+		}
+		last := latest[policy]
+		if last == nil || last.Action != model.ConsentActionGranted || last.PolicyVersion != s.currentVersion(policy) {
+			missing = append(missing, policy)
+		}
+	}
+	return missing, nil
+}
+
+// latestConsents retrieves the latest record of each policy the user answered
+func (s *consentService) latestConsents(ctx context.Context, userID uuid.UUID) (map[model.ConsentPolicy]*model.ConsentRecord, error) {
+	records, err := s.consentRepo.ListLatestByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get latest consent records",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	latest := make(map[model.ConsentPolicy]*model.ConsentRecord, len(records))
+	for _, record := range records {
+		latest[record.Policy] = record
+	}
+	return latest, nil
+}
+
+// record appends a grant or withdrawal to the ledger, with the IP and ID of the request making it
+func (s *consentService) record(
+	ctx context.Context,
+	userID uuid.UUID,
+	policy model.ConsentPolicy,
+	version string,
+	action model.ConsentAction,
+) (*model.ConsentRecord, error) {
+	record := &model.ConsentRecord{
+		UserID:        userID,
+		Policy:        policy,
+		PolicyVersion: version,
+		Action:        action,
+		RequestID:     requestIDFromContext(ctx),
+	}
+	if clientIP := clientIPFromContext(ctx); clientIP != "" {
+		record.SourceIP = &clientIP
+	}
+
+	if err := s.consentRepo.Append(ctx, record); err != nil {
+		s.logger.Error("Failed to append consent record",
+			zap.String("user_id", userID.String()),
+			zap.String("policy", string(policy)),
+			zap.String("action", string(action)),
+			zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Consent recorded",
+		zap.String("user_id", userID.String()),
+		zap.String("policy", string(policy)),
+		zap.String("version", version),
+		zap.String("action", string(action)),
+		zap.String("request_id", record.RequestID))
+
+	return record, nil
+}
+
+// currentVersion returns the version of a policy in force
+func (s *consentService) currentVersion(policy model.ConsentPolicy) string {
+	switch policy {
+	case model.ConsentPolicyTerms:
+		return s.cfg.TermsVersion
+	case model.ConsentPolicyPrivacyPolicy:
+		return s.cfg.PrivacyPolicyVersion
+	case model.ConsentPolicyPhotoDisplay:
+		return s.cfg.PhotoDisplayVersion
+	case model.ConsentPolicyDataSharing:
+		return s.cfg.DataSharingVersion
+	}
+	return ""
+}
+
+// clientIPFromContext returns the IP address of the client that made the request, as
+// recorded by the request logger
+func clientIPFromContext(ctx context.Context) string {
+	return requestinfo.ClientIP(ctx)
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/requestinfo"
+	"go.uber.org/zap"
+)
+
+// fakeConsentRepo is an in-memory consent ledger
+type fakeConsentRepo struct {
+	records   []*model.ConsentRecord
+	appendErr error
+}
+
+func (r *fakeConsentRepo) Append(ctx context.Context, record *model.ConsentRecord) error {
+	if r.appendErr != nil {
+		return r.appendErr
+	}
+	record.ID = uuid.New()
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *fakeConsentRepo) ListLatestByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ConsentRecord, error) {
+	latest := make(map[model.ConsentPolicy]*model.ConsentRecord)
+	for _, record := range r.records {
+		if record.UserID == userID {
+			latest[record.Policy] = record
+		}
+	}
+
+	var records []*model.ConsentRecord
+	for _, record := range latest {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (r *fakeConsentRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ConsentRecord, error) {
+	var records []*model.ConsentRecord
+	for _, record := range slices.Backward(r.records) {
+		if record.UserID == userID {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// grant appends a grant to the ledger as if made earlier
+func (r *fakeConsentRepo) grant(userID uuid.UUID, policy model.ConsentPolicy, version string) {
+	r.records = append(r.records, &model.ConsentRecord{
+		ID: uuid.New(), UserID: userID, Policy: policy, PolicyVersion: version, Action: model.ConsentActionGranted,
+	})
+}
+
+var testConsentConfig = config.ConsentConfig{
+	TermsVersion:         "2024-06",
+	PrivacyPolicyVersion: "2024-09",
+	PhotoDisplayVersion:  "v1",
+	DataSharingVersion:   "v1",
+}
+
+func newTestConsentService(repo *fakeConsentRepo) *consentService {
+	return NewConsentService(repo, testConsentConfig, &logger.Logger{Logger: zap.NewNop()}).(*consentService)
+}
+
+func TestGrantConsent(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setup       func(r *fakeConsentRepo)
+		req         dto.GrantConsentRequest
+		wantErr     error
+		wantRecords int
+	}{
+		{
+			name:        "current version",
+			req:         dto.GrantConsentRequest{Policy: "terms", Version: "2024-06"},
+			wantRecords: 1,
+		},
+		{
+			name:    "outdated version",
+			req:     dto.GrantConsentRequest{Policy: "terms", Version: "2023-01"},
+			wantErr: ErrValidation,
+		},
+		{
+			name:    "unknown policy",
+			req:     dto.GrantConsentRequest{Policy: "newsletter", Version: "v1"},
+			wantErr: ErrValidation,
+		},
+		{
+			name:        "already granted",
+			setup:       func(r *fakeConsentRepo) { r.grant(userID, model.ConsentPolicyTerms, "2024-06") },
+			req:         dto.GrantConsentRequest{Policy: "terms", Version: "2024-06"},
+			wantRecords: 1,
+		},
+		{
+			name:        "granted an older version",
+			setup:       func(r *fakeConsentRepo) { r.grant(userID, model.ConsentPolicyTerms, "2023-01") },
+			req:         dto.GrantConsentRequest{Policy: "terms", Version: "2024-06"},
+			wantRecords: 2,
+		},
+		{
+			name:    "ledger unavailable",
+			setup:   func(r *fakeConsentRepo) { r.appendErr = errors.New("connection refused") },
+			req:     dto.GrantConsentRequest{Policy: "terms", Version: "2024-06"},
+			wantErr: ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeConsentRepo{}
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			svc := newTestConsentService(repo)
+
+			got, err := svc.GrantConsent(context.Background(), userID, &tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GrantConsent() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GrantConsent() error = %v", err)
+			}
+
+			if got.Status != string(model.ConsentActionGranted) || got.Version != tt.req.Version || !got.UpToDate {
+				t.Errorf("GrantConsent() = %+v, want an up to date grant of %s", got, tt.req.Version)
+			}
+			if len(repo.records) != tt.wantRecords {
+				t.Errorf("ledger has %d records, want %d", len(repo.records), tt.wantRecords)
+			}
+		})
+	}
+}
+
+func TestGrantConsentRecordsRequest(t *testing.T) {
+	repo := &fakeConsentRepo{}
+	svc := newTestConsentService(repo)
+
+	ctx := requestinfo.WithClientIP(requestinfo.WithRequestID(context.Background(), "req-42"), "203.0.113.7")
+	req := &dto.GrantConsentRequest{Policy: "privacy_policy", Version: "2024-09"}
+	if _, err := svc.GrantConsent(ctx, uuid.New(), req); err != nil {
+		t.Fatalf("GrantConsent() error = %v", err)
+	}
+
+	record := repo.records[0]
+	if record.RequestID != "req-42" {
+		t.Errorf("RequestID = %q, want %q", record.RequestID, "req-42")
+	}
+	if record.SourceIP == nil || *record.SourceIP != "203.0.113.7" {
+		t.Errorf("SourceIP = %v, want 203.0.113.7", record.SourceIP)
+	}
+
+	// Outside a request there is nothing to record
+	if _, err := svc.GrantConsent(context.Background(), uuid.New(), req); err != nil {
+		t.Fatalf("GrantConsent() error = %v", err)
+	}
+	if record := repo.records[1]; record.RequestID != "" || record.SourceIP != nil {
+		t.Errorf("record outside a request has RequestID %q and SourceIP %v, want none", record.RequestID, record.SourceIP)
+	}
+}
+
+func TestWithdrawConsent(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setup       func(r *fakeConsentRepo)
+		policy      model.ConsentPolicy
+		wantErr     error
+		wantVersion string
+	}{
+		{
+			name:        "granted",
+			setup:       func(r *fakeConsentRepo) { r.grant(userID, model.ConsentPolicyPhotoDisplay, "v1") },
+			policy:      model.ConsentPolicyPhotoDisplay,
+			wantVersion: "v1",
+		},
+		{
+			// The withdrawal is of the version agreed to, not the current one
+			name:        "granted an older version",
+			setup:       func(r *fakeConsentRepo) { r.grant(userID, model.ConsentPolicyTerms, "2023-01") },
+			policy:      model.ConsentPolicyTerms,
+			wantVersion: "2023-01",
+		},
+		{
+			name:    "never granted",
+			policy:  model.ConsentPolicyDataSharing,
+			wantErr: ErrValidation,
+		},
+		{
+			name: "already withdrawn",
+			setup: func(r *fakeConsentRepo) {
+				r.grant(userID, model.ConsentPolicyDataSharing, "v1")
+				r.records = append(r.records, &model.ConsentRecord{
+					UserID: userID, Policy: model.ConsentPolicyDataSharing, PolicyVersion: "v1", Action: model.ConsentActionWithdrawn,
+				})
+			},
+			policy:  model.ConsentPolicyDataSharing,
+			wantErr: ErrValidation,
+		},
+		{
+			name:    "granted by another user",
+			setup:   func(r *fakeConsentRepo) { r.grant(uuid.New(), model.ConsentPolicyPhotoDisplay, "v1") },
+			policy:  model.ConsentPolicyPhotoDisplay,
+			wantErr: ErrValidation,
+		},
+		{
+			name:    "unknown policy",
+			policy:  "newsletter",
+			wantErr: ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeConsentRepo{}
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			before := len(repo.records)
+			svc := newTestConsentService(repo)
+
+			got, err := svc.WithdrawConsent(context.Background(), userID, tt.policy)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("WithdrawConsent() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.records) != before {
+					t.Errorf("failed withdrawal appended %d records", len(repo.records)-before)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WithdrawConsent() error = %v", err)
+			}
+
+			if got.Status != string(model.ConsentActionWithdrawn) || got.Version != tt.wantVersion || got.UpToDate {
+				t.Errorf("WithdrawConsent() = %+v, want a withdrawal of %s", got, tt.wantVersion)
+			}
+			if len(repo.records) != before+1 {
+				t.Errorf("ledger has %d records, want %d", len(repo.records), before+1)
+			}
+		})
+	}
+}
+
+func TestMissingConsents(t *testing.T) {
+	userID := uuid.New()
+	mandatory := []model.ConsentPolicy{model.ConsentPolicyTerms, model.ConsentPolicyPrivacyPolicy}
+
+	tests := []struct {
+		name  string
+		setup func(r *fakeConsentRepo)
+		want  []model.ConsentPolicy
+	}{
+		{
+			name: "nothing granted",
+			want: mandatory,
+		},
+		{
+			name: "all current versions granted",
+			setup: func(r *fakeConsentRepo) {
+				r.grant(userID, model.ConsentPolicyTerms, "2024-06")
+				r.grant(userID, model.ConsentPolicyPrivacyPolicy, "2024-09")
+			},
+		},
+		{
+			name: "optional policies aren't required",
+			setup: func(r *fakeConsentRepo) {
+				r.grant(userID, model.ConsentPolicyPhotoDisplay, "v1")
+				r.grant(userID, model.ConsentPolicyDataSharing, "v1")
+			},
+			want: mandatory,
+		},
+		{
+			name: "older version granted",
+			setup: func(r *fakeConsentRepo) {
+				r.grant(userID, model.ConsentPolicyTerms, "2024-06")
+				r.grant(userID, model.ConsentPolicyPrivacyPolicy, "2024-01")
+			},
+			want: []model.ConsentPolicy{model.ConsentPolicyPrivacyPolicy},
+		},
+		{
+			name: "withdrawn",
+			setup: func(r *fakeConsentRepo) {
+				r.grant(userID, model.ConsentPolicyTerms, "2024-06")
+				r.grant(userID, model.ConsentPolicyPrivacyPolicy, "2024-09")
+				r.records = append(r.records, &model.ConsentRecord{
+					UserID: userID, Policy: model.ConsentPolicyTerms, PolicyVersion: "2024-06", Action: model.ConsentActionWithdrawn,
+				})
+			},
+			want: []model.ConsentPolicy{model.ConsentPolicyTerms},
+		},
+		{
+			name: "granted again after withdrawing",
+			setup: func(r *fakeConsentRepo) {
+				r.grant(userID, model.ConsentPolicyPrivacyPolicy, "2024-09")
+				r.grant(userID, model.ConsentPolicyTerms, "2024-06")
+				r.records = append(r.records, &model.ConsentRecord{
+					UserID: userID, Policy: model.ConsentPolicyTerms, PolicyVersion: "2024-06", Action: model.ConsentActionWithdrawn,
+				})
+				r.grant(userID, model.ConsentPolicyTerms, "2024-06")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeConsentRepo{}
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			svc := newTestConsentService(repo)
+
+			got, err := svc.MissingConsents(context.Background(), userID)
+			if err != nil {
+				t.Fatalf("MissingConsents() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MissingConsents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
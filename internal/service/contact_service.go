@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/encryption"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	contactServiceName = "ContactService"
+)
+
+// contactService implements ContactService
+type contactService struct {
+	detailRepo   repository.ContactDetailRepository
+	revealRepo   repository.ContactRevealRepository
+	interestRepo repository.InterestRepository
+	profileRepo  repository.UserProfileRepository
+	blockRepo    repository.ProfileBlockRepository
+	managerRepo  repository.ProfileManagerRepository
+	cipher       *encryption.Cipher
+	entitler     Entitler
+	logger       *logger.Logger
+}
+
+// NewContactService creates a new contact service. Contact details are encrypted
+// with cipher, and the number of reveals a user may make each month without an
+// accepted interest comes from their membership plan.
+func NewContactService(
+	detailRepo repository.ContactDetailRepository,
+	revealRepo repository.ContactRevealRepository,
+	interestRepo repository.InterestRepository,
+	profileRepo repository.UserProfileRepository,
+	blockRepo repository.ProfileBlockRepository,
+	managerRepo repository.ProfileManagerRepository,
+	cipher *encryption.Cipher,
+	entitler Entitler,
+	logger *logger.Logger,
+) ContactService {
+	return &contactService{
+		detailRepo:   detailRepo,
+		revealRepo:   revealRepo,
+		interestRepo: interestRepo,
+		profileRepo:  profileRepo,
+		blockRepo:    blockRepo,
+		managerRepo:  managerRepo,
+		cipher:       cipher,
+		entitler:     entitler,
+		logger:       logger,
+	}
+}
+
+// GetContactDetails retrieves the contact details of the user's own profile
+func (s *contactService) GetContactDetails(ctx context.Context, actor Actor) (*dto.ContactDetailsResponse, error) {
+	const op = "GetContactDetails"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, contactServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.loadDetails(ctx, op, profile.ID)
+}
+
+// GetManagedContactDetails retrieves the contact details of a profile the user owns
+// or manages with the view contacts scope
+func (s *contactService) GetManagedContactDetails(
+	ctx context.Context,
+	userID uuid.UUID,
+	profileID uuid.UUID,
+) (*dto.ContactDetailsResponse, error) {
+	const op = "GetManagedContactDetails"
+
+	profile, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, contactServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+		}
+
+		s.logger.Error("Failed to get profile for contact details",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to retrieve profile")
+	}
+
+	manager, err := authorizeProfileAction(ctx, s.managerRepo, s.logger, op, contactServiceName,
+		profile, userID, model.ProfileManagerScopeViewContacts,
+		"you can only view the contact details of profiles you own or manage with view contacts permission")
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := s.loadDetails(ctx, op, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if manager != nil {
+		s.logger.UserProfileEvent(ctx, "contact_details_accessed", userID.String(), profile.ID.String(),
+			actorFields(profile, manager)...)
+	}
+
+	return details, nil
+}
+
+// UpdateContactDetails replaces the contact details of the user's own profile
+func (s *contactService) UpdateContactDetails(
+	ctx context.Context,
+	actor Actor,
+	req *dto.ContactDetailsRequest,
+) (*dto.ContactDetailsResponse, error) {
+	const op = "UpdateContactDetails"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, contactServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	details := dto.ContactDetailsResponse(*req)
+	plaintext, err := json.Marshal(&details)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to encode contact details")
+	}
+
+	// The profile ID is bound as associated data so that a ciphertext copied
+	// to another profile's row fails to decrypt
+	encrypted, err := s.cipher.Encrypt(plaintext, profile.ID[:])
+	if err != nil {
+		s.logger.Error("Failed to encrypt contact details",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to save contact details")
+	}
+
+	detail := &model.ContactDetail{
+		ProfileID:        profile.ID,
+		DetailsEncrypted: encrypted,
+	}
+	if err := s.detailRepo.Upsert(ctx, detail); err != nil {
+		s.logger.Error("Failed to save contact details",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to save contact details")
+	}
+
+	s.logger.UserProfileEvent(ctx, "contact_details_updated", actor.UserID.String(), profile.ID.String())
+
+	return &details, nil
+}
+
+// RevealContact reveals a profile's contact details to the user, once per pair of profiles.
+// Profiles that share an accepted interest can always see each other's details; otherwise
+// the reveal is charged to the user's monthly quota. Every attempt is audited.
+func (s *contactService) RevealContact(ctx context.Context, actor Actor, profileID uuid.UUID) (*dto.ContactRevealResponse, error) {
+	const op = "RevealContact"
+
+	viewer, err := getActingProfile(ctx, s.profileRepo, s.logger, op, contactServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewer.ID == profileID {
+		return nil, NewError(ErrValidation, op, contactServiceName, "use your own contact details endpoint instead")
+	}
+
+	notFound := NewError(ErrNotFound, op, contactServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+	if _, err := s.profileRepo.GetByID(ctx, profileID); err != nil {
+		if isRepoNotFound(err) {
+			return nil, notFound
+		}
+
+		s.logger.Error("Failed to get profile for contact reveal",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to retrieve profile")
+	}
+
+	blocked, err := isBlockedBetween(ctx, s.blockRepo, viewer.ID, profileID)
+	if err != nil {
+		s.logger.Error("Failed to check blocks",
+			zap.String("profile_id", viewer.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to reveal contact details")
+	}
+	if blocked {
+		return nil, notFound
+	}
+
+	detail, err := s.detailRepo.GetByProfileID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, contactServiceName, "the profile has no contact details")
+		}
+
+		s.logger.Error("Failed to get contact details",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to retrieve contact details")
+	}
+
+	reveal := &model.ContactReveal{
+		ViewerProfileID: viewer.ID,
+		ViewerUserID:    actor.UserID,
+		ProfileID:       profileID,
+		RequestID:       requestIDFromContext(ctx),
+	}
+
+	if _, err := s.revealRepo.GetRevealed(ctx, viewer.ID, profileID); err == nil {
+		return nil, s.alreadyRevealed(ctx, op, reveal)
+	} else if !isRepoNotFound(err) {
+		s.logger.Error("Failed to check previous reveal",
+			zap.String("profile_id", viewer.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to reveal contact details")
+	}
+
+	basis := model.ContactRevealBasisQuota
+	interest, err := s.interestRepo.GetBetween(ctx, viewer.ID, profileID)
+	switch {
+	case err == nil && interest.Status == model.InterestStatusAccepted:
+		basis = model.ContactRevealBasisInterest
+	case err != nil && !isRepoNotFound(err):
+		s.logger.Error("Failed to check interest",
+			zap.String("profile_id", viewer.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to reveal contact details")
+	}
+
+	entitlements, err := s.entitler.Entitlements(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	quota := entitlements.MonthlyContactReveals
+
+	// Decrypt before recording the reveal so that a failure doesn't use up quota for
+	// details the viewer never sees
+	details, err := s.decrypt(detail)
+	if err != nil {
+		s.logger.Error("Failed to decrypt contact details",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to read contact details")
+	}
+
+	reveal.Outcome = model.ContactRevealOutcomeRevealed
+	reveal.Basis = &basis
+
+	periodStart := monthStart(time.Now())
+	if basis == model.ContactRevealBasisInterest {
+		err = s.revealRepo.Record(ctx, reveal)
+	} else {
+		var recorded bool
+		recorded, err = s.revealRepo.RecordWithinQuota(ctx, reveal, periodStart, quota)
+		if err == nil && !recorded {
+			return nil, s.denied(ctx, op, reveal)
+		}
+	}
+	if err != nil {
+		if isRepoDuplicate(err) {
+			// A concurrent request revealed the details first
+			return nil, s.alreadyRevealed(ctx, op, reveal)
+		}
+
+		s.logger.Error("Failed to record contact reveal",
+			zap.String("profile_id", viewer.ID.String()),
+			zap.String("revealed_profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to reveal contact details")
+	}
+
+	used, err := s.revealRepo.CountQuotaReveals(ctx, actor.UserID, periodStart)
+	if err != nil {
+		s.logger.Error("Failed to count contact reveals",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to count contact reveals")
+	}
+
+	s.logger.UserProfileEvent(ctx, "contact_revealed", actor.UserID.String(), viewer.ID.String(),
+		zap.String("revealed_profile_id", profileID.String()),
+		zap.String("basis", string(basis)))
+
+	return &dto.ContactRevealResponse{
+		Contact:        details,
+		Basis:          string(basis),
+		RemainingQuota: max(quota-int(used), 0),
+	}, nil
+}
+
+// GetRevealQuota reports the user's contact reveal quota for the current month. The quota
+// comes with the account's plan and is shared by all of the account's profiles.
+func (s *contactService) GetRevealQuota(ctx context.Context, userID uuid.UUID) (*dto.ContactQuotaResponse, error) {
+	const op = "GetRevealQuota"
+
+	entitlements, err := s.entitler.Entitlements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.revealRepo.CountQuotaReveals(ctx, userID, monthStart(time.Now()))
+	if err != nil {
+		s.logger.Error("Failed to count contact reveals",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to count contact reveals")
+	}
+
+	return &dto.ContactQuotaResponse{
+		Quota:     entitlements.MonthlyContactReveals,
+		Used:      int(used),
+		Remaining: max(entitlements.MonthlyContactReveals-int(used), 0),
+	}, nil
+}
+
+// loadDetails retrieves and decrypts the contact details of a profile
+func (s *contactService) loadDetails(ctx context.Context, op string, profileID uuid.UUID) (*dto.ContactDetailsResponse, error) {
+	detail, err := s.detailRepo.GetByProfileID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, contactServiceName, "no contact details saved yet")
+		}
+
+		s.logger.Error("Failed to get contact details",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to retrieve contact details")
+	}
+
+	details, err := s.decrypt(detail)
+	if err != nil {
+		s.logger.Error("Failed to decrypt contact details",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, contactServiceName, "failed to read contact details")
+	}
+
+	return details, nil
+}
+
+// decrypt decrypts and decodes stored contact details
+func (s *contactService) decrypt(detail *model.ContactDetail) (*dto.ContactDetailsResponse, error) {
+	plaintext, err := s.cipher.Decrypt(detail.DetailsEncrypted, detail.ProfileID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var details dto.ContactDetailsResponse
+	if err := json.Unmarshal(plaintext, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+// alreadyRevealed audits a repeated reveal and returns the error telling the viewer
+// that the details were already shown to them
+func (s *contactService) alreadyRevealed(ctx context.Context, op string, reveal *model.ContactReveal) error {
+	s.recordAttempt(ctx, reveal, model.ContactRevealOutcomeAlreadyRevealed)
+	return NewError(ErrDuplicate, op, contactServiceName, "contact details were already revealed to you")
+}
+
+// denied audits a reveal refused for lack of quota and returns the matching error
+func (s *contactService) denied(ctx context.Context, op string, reveal *model.ContactReveal) error {
+	s.recordAttempt(ctx, reveal, model.ContactRevealOutcomeDenied)
+	return NewError(ErrQuotaExceeded, op, contactServiceName,
+		"monthly contact reveal quota exhausted; upgrade your plan or send an interest and wait for it to be accepted")
+}
+
+// recordAttempt records an unsuccessful reveal in the audit trail. Failures are
+// logged rather than returned since the request is refused either way.
+func (s *contactService) recordAttempt(ctx context.Context, reveal *model.ContactReveal, outcome model.ContactRevealOutcome) {
+	attempt := *reveal
+	attempt.ID = uuid.Nil
+	attempt.Outcome = outcome
+	attempt.Basis = nil
+
+	if err := s.revealRepo.Record(ctx, &attempt); err != nil {
+		s.logger.Error("Failed to record contact reveal attempt",
+			zap.String("profile_id", reveal.ViewerProfileID.String()),
+			zap.String("revealed_profile_id", reveal.ProfileID.String()),
+			zap.String("outcome", string(outcome)),
+			zap.Error(err))
+	}
+}
+
+// monthStart returns the start of the calendar month (UTC) that monthly quotas are counted over
+func monthStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
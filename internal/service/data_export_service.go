@@ -0,0 +1,369 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/dataexport"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/photo"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/encryption"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/sharetoken"
+	"go.uber.org/zap"
+)
+
+const (
+	dataExportServiceName = "DataExportService"
+
+	// dataExportStaleAfter is how long an export may stay running before it is taken
+	// to be abandoned by a stopped worker and assembled again
+	dataExportStaleAfter = 30 * time.Minute
+)
+
+// dataExportService implements DataExportService
+type dataExportService struct {
+	exportRepo repository.DataExportRepository
+	photos     photo.Store
+	cipher     *encryption.Cipher
+	signer     *sharetoken.Signer
+	cfg        config.ExportConfig
+	logger     *logger.Logger
+}
+
+// NewDataExportService creates a new data export service. Contact details are
+// decrypted with cipher, so that the archive holds them as the user entered them.
+func NewDataExportService(
+	exportRepo repository.DataExportRepository,
+	photos photo.Store,
+	cipher *encryption.Cipher,
+	cfg config.ExportConfig,
+	logger *logger.Logger,
+) DataExportService {
+	return &dataExportService{
+		exportRepo: exportRepo,
+		photos:     photos,
+		cipher:     cipher,
+		signer:     sharetoken.NewSigner(cfg.Secret),
+		cfg:        cfg,
+		logger:     logger,
+	}
+}
+
+// RequestExport queues an export of the user's account. An account has one export
+// being assembled at a time.
+func (s *dataExportService) RequestExport(ctx context.Context, userID uuid.UUID) (*dto.DataExportResponse, error) {
+	const op = "RequestExport"
+
+	export := &model.DataExport{
+		UserID:    userID,
+		Status:    model.DataExportStatusPending,
+		RequestID: requestIDFromContext(ctx),
+	}
+	if err := s.exportRepo.Create(ctx, export); err != nil {
+		if isRepoDuplicate(err) {
+			return nil, NewError(ErrDuplicate, op, dataExportServiceName, "an export of your account is already being prepared")
+		}
+
+		s.logger.Error("Failed to create data export",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, dataExportServiceName, "failed to request data export")
+	}
+
+	s.logger.Info("Data export requested",
+		zap.String("user_id", userID.String()),
+		zap.String("export_id", export.ID.String()),
+		zap.String("request_id", export.RequestID))
+
+	return dto.DataExportFromModel(export), nil
+}
+
+// ListExports lists the exports of the user's account, newest first
+func (s *dataExportService) ListExports(ctx context.Context, userID uuid.UUID) ([]*dto.DataExportResponse, error) {
+	const op = "ListExports"
+
+	exports, err := s.exportRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list data exports",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, dataExportServiceName, "failed to list data exports")
+	}
+
+	now := time.Now()
+	response := make([]*dto.DataExportResponse, len(exports))
+	for i, export := range exports {
+		response[i] = s.toResponse(export, now)
+	}
+
+	return response, nil
+}
+
+// GetExport retrieves an export of the user's account. A ready export comes with a
+// token to download it, valid for the download TTL or until the archive expires.
+func (s *dataExportService) GetExport(ctx context.Context, userID, exportID uuid.UUID) (*dto.DataExportResponse, error) {
+	const op = "GetExport"
+
+	export, err := s.exportRepo.GetByID(ctx, userID, exportID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, dataExportServiceName,
+				fmt.Sprintf("no data export with ID %s", exportID))
+		}
+
+		s.logger.Error("Failed to get data export",
+			zap.String("export_id", exportID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, dataExportServiceName, "failed to retrieve data export")
+	}
+
+	return s.toResponse(export, time.Now()), nil
+}
+
+// DownloadExport returns the archive behind a download token. Forged and expired
+// tokens, and exports whose archive was dropped, are all reported as not found.
+func (s *dataExportService) DownloadExport(ctx context.Context, token string) (*dto.DataExportArchive, error) {
+	const op = "DownloadExport"
+
+	notFound := NewError(ErrNotFound, op, dataExportServiceName, "the download link is invalid or expired")
+
+	now := time.Now()
+	exportID, err := s.signer.Verify(token, now)
+	if err != nil {
+		return nil, notFound
+	}
+
+	export, err := s.exportRepo.GetArchive(ctx, exportID, now)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, notFound
+		}
+
+		s.logger.Error("Failed to get data export archive",
+			zap.String("export_id", exportID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, dataExportServiceName, "failed to download data export")
+	}
+
+	s.logger.Info("Data export downloaded",
+		zap.String("user_id", export.UserID.String()),
+		zap.String("export_id", export.ID.String()),
+		zap.String("request_id", requestIDFromContext(ctx)))
+
+	return &dto.DataExportArchive{
+		Filename: fmt.Sprintf("data-export-%s.zip", export.CreatedAt.Format("2006-01-02")),
+		Content:  export.Archive,
+	}, nil
+}
+
+// ProcessExports drops the expired archives, then assembles the queued exports one at
+// a time until none is left. An export that fails is marked failed and can be requested again.
+func (s *dataExportService) ProcessExports(ctx context.Context) error {
+	const op = "ProcessExports"
+
+	expired, err := s.exportRepo.ExpireArchives(ctx, time.Now())
+	if err != nil {
+		return NewError(ErrInternal, op, dataExportServiceName, err.Error())
+	}
+	if expired > 0 {
+		s.logger.Info("Expired data export archives", zap.Int64("exports", expired))
+	}
+
+	for ctx.Err() == nil {
+		now := time.Now()
+		export, err := s.exportRepo.ClaimNext(ctx, now, now.Add(-dataExportStaleAfter))
+		if err != nil {
+			return NewError(ErrInternal, op, dataExportServiceName, err.Error())
+		}
+		if export == nil {
+			break
+		}
+
+		s.processExport(ctx, export)
+	}
+
+	return ctx.Err()
+}
+
+// processExport assembles and stores the archive of a claimed export
+func (s *dataExportService) processExport(ctx context.Context, export *model.DataExport) {
+	archive, err := s.buildArchive(ctx, export)
+	if err != nil {
+		// An export interrupted by shutdown stays running and is claimed again once stale
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.logger.Error("Failed to assemble data export",
+			zap.String("user_id", export.UserID.String()),
+			zap.String("export_id", export.ID.String()),
+			zap.Error(err))
+		if err := s.exportRepo.Fail(ctx, export.ID, "failed to assemble the archive", time.Now()); err != nil {
+			s.logger.Error("Failed to mark data export failed",
+				zap.String("export_id", export.ID.String()),
+				zap.Error(err))
+		}
+		return
+	}
+
+	completedAt := time.Now()
+	if err := s.exportRepo.Complete(ctx, export.ID, archive, completedAt, completedAt.Add(s.cfg.ArchiveTTL)); err != nil {
+		s.logger.Error("Failed to store data export",
+			zap.String("export_id", export.ID.String()),
+			zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Data export completed",
+		zap.String("user_id", export.UserID.String()),
+		zap.String("export_id", export.ID.String()),
+		zap.Int("archive_size", len(archive)),
+		zap.Duration("duration", completedAt.Sub(*export.StartedAt)),
+		zap.String("request_id", export.RequestID))
+}
+
+// buildArchive collects everything held about the export's account and writes it as a
+// ZIP archive, with a JSON document per kind of record and the primary photo of each profile
+func (s *dataExportService) buildArchive(ctx context.Context, export *model.DataExport) ([]byte, error) {
+	data, err := s.exportRepo.CollectUserData(ctx, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	contacts := make([]*dto.ExportedContactDetails, len(data.ContactDetails))
+	for i, detail := range data.ContactDetails {
+		plaintext, err := s.cipher.Decrypt(detail.DetailsEncrypted, detail.ProfileID[:])
+		if err != nil {
+			return nil, fmt.Errorf("decrypting contact details of profile %s: %w", detail.ProfileID, err)
+		}
+		contacts[i] = &dto.ExportedContactDetails{
+			ProfileID:              detail.ProfileID,
+			ContactDetailsResponse: &dto.ContactDetailsResponse{},
+			UpdatedAt:              detail.UpdatedAt,
+		}
+		if err := json.Unmarshal(plaintext, contacts[i].ContactDetailsResponse); err != nil {
+			return nil, err
+		}
+	}
+
+	revisions := make([]*dto.ProfileRevisionResponse, len(data.Revisions))
+	for i, rev := range data.Revisions {
+		if revisions[i], err = dto.ProfileRevisionFromModel(rev); err != nil {
+			return nil, err
+		}
+		// Moderation flags are internal, as in the history shown to owners
+		revisions[i].Flagged = false
+		revisions[i].FlagReason = ""
+	}
+
+	preferences := make([]*dto.ExportedPartnerPreference, len(data.Preferences))
+	for i, preference := range data.Preferences {
+		response, err := dto.PartnerPreferenceFromModel(preference)
+		if err != nil {
+			return nil, err
+		}
+		preferences[i] = &dto.ExportedPartnerPreference{ProfileID: preference.ProfileID, PartnerPreferenceResponse: response}
+	}
+
+	drafts := make([]*dto.ProfileDraftResponse, len(data.Drafts))
+	for i, draft := range data.Drafts {
+		if drafts[i], err = dto.ProfileDraftFromModel(draft); err != nil {
+			return nil, err
+		}
+	}
+
+	viewsReceived := make([]*dto.ExportedProfileView, len(data.ViewsReceived))
+	for i, view := range data.ViewsReceived {
+		viewsReceived[i] = &dto.ExportedProfileView{
+			ProfileID:    view.ProfileID,
+			ViewCount:    view.ViewCount,
+			LastViewedAt: view.LastViewedAt,
+		}
+	}
+
+	savedSearches := make([]*dto.SavedSearchResponse, len(data.SavedSearches))
+	for i, search := range data.SavedSearches {
+		savedSearches[i] = dto.SavedSearchFromModel(search)
+	}
+
+	shareLinks := make([]*dto.ShareLinkResponse, len(data.ShareLinks))
+	for i, link := range data.ShareLinks {
+		shareLinks[i] = dto.ShareLinkFromModel(link, now)
+	}
+
+	consents := make([]*dto.ConsentRecordResponse, len(data.Consents))
+	for i, record := range data.Consents {
+		consents[i] = dto.ConsentRecordFromModel(record)
+	}
+
+	var photos []dataexport.File
+	for _, profile := range data.Profiles {
+		picture, err := s.photos.PrimaryPhoto(ctx, profile.ID)
+		if err != nil {
+			if errors.Is(err, photo.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("fetching photo of profile %s: %w", profile.ID, err)
+		}
+		photos = append(photos, dataexport.File{Name: fmt.Sprintf("photos/%s.jpg", profile.ID), Data: picture})
+	}
+
+	var buf bytes.Buffer
+	err = dataexport.Write(&buf, &dataexport.Archive{
+		UserID:      export.UserID,
+		GeneratedAt: now,
+		Sections: []dataexport.Section{
+			{Name: "profiles", Data: data.Profiles},
+			{Name: "contact_details", Data: contacts},
+			{Name: "profile_history", Data: revisions},
+			{Name: "partner_preferences", Data: preferences},
+			{Name: "privacy_settings", Data: data.Privacy},
+			{Name: "profile_drafts", Data: drafts},
+			{Name: "interests", Data: data.Interests},
+			{Name: "profiles_viewed", Data: data.ViewsMade},
+			{Name: "profile_views_received", Data: viewsReceived},
+			{Name: "contact_reveals", Data: data.ContactReveals},
+			{Name: "blocked_profiles", Data: data.Blocks},
+			{Name: "saved_searches", Data: savedSearches},
+			{Name: "profile_managers", Data: data.Managers},
+			{Name: "profile_transfers", Data: data.Transfers},
+			{Name: "share_links", Data: shareLinks},
+			{Name: "profile_boosts", Data: data.Boosts},
+			{Name: "subscriptions", Data: data.Subscriptions},
+			{Name: "consents", Data: consents},
+		},
+		Files: photos,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// toResponse creates the response for an export, with a fresh download token if it is ready
+func (s *dataExportService) toResponse(export *model.DataExport, now time.Time) *dto.DataExportResponse {
+	response := dto.DataExportFromModel(export)
+	if export.Status != model.DataExportStatusCompleted || export.ExpiresAt == nil || !now.Before(*export.ExpiresAt) {
+		return response
+	}
+
+	downloadExpiresAt := now.Add(s.cfg.DownloadTTL)
+	if export.ExpiresAt.Before(downloadExpiresAt) {
+		downloadExpiresAt = *export.ExpiresAt
+	}
+	response.DownloadToken = s.signer.Sign(export.ID, downloadExpiresAt)
+	response.DownloadExpiresAt = &downloadExpiresAt
+
+	return response
+}
@@ -1,8 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/requestinfo"
 )
 
 // Common service errors
@@ -19,6 +23,12 @@ var (
 	// ErrUnauthorized is returned when a user doesn't have permission
 	ErrUnauthorized = errors.New("unauthorized action")
 
+	// ErrQuotaExceeded is returned when a user has used up a limited allowance
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// ErrNotEntitled is returned when the user's membership plan doesn't include a feature
+	ErrNotEntitled = errors.New("not entitled")
+
 	// ErrInternal is returned for unexpected errors
 	ErrInternal = errors.New("internal service error")
 )
@@ -83,3 +93,26 @@ func NewValidationError(operation, service string, fields []ValidationError) err
 		Fields:    fields,
 	}
 }
+
+// isRepoNotFound reports whether err is a repository not-found error
+func isRepoNotFound(err error) bool {
+	var repoErr *repository.RepositoryError
+	return errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrNotFound)
+}
+
+// isRepoDuplicate reports whether err is a repository duplicate key error
+func isRepoDuplicate(err error) bool {
+	var repoErr *repository.RepositoryError
+	return errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrDuplicateKey)
+}
+
+// isRepoInvalidOperation reports whether err is a repository invalid operation error
+func isRepoInvalidOperation(err error) bool {
+	var repoErr *repository.RepositoryError
+	return errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrInvalidOperation)
+}
+
+// requestIDFromContext returns the request ID stored in the context by the request logger, if any
+func requestIDFromContext(ctx context.Context) string {
+	return requestinfo.RequestID(ctx)
+}
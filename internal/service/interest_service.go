@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	interestServiceName = "InterestService"
+)
+
+// interestService implements InterestService
+type interestService struct {
+	interestRepo repository.InterestRepository
+	profileRepo  repository.UserProfileRepository
+	blockRepo    repository.ProfileBlockRepository
+	managerRepo  repository.ProfileManagerRepository
+	logger       *logger.Logger
+}
+
+// NewInterestService creates a new interest service
+func NewInterestService(
+	interestRepo repository.InterestRepository,
+	profileRepo repository.UserProfileRepository,
+	blockRepo repository.ProfileBlockRepository,
+	managerRepo repository.ProfileManagerRepository,
+	logger *logger.Logger,
+) InterestService {
+	return &interestService{
+		interestRepo: interestRepo,
+		profileRepo:  profileRepo,
+		blockRepo:    blockRepo,
+		managerRepo:  managerRepo,
+		logger:       logger,
+	}
+}
+
+// SendInterest sends an interest from the user's profile to another profile
+func (s *interestService) SendInterest(
+	ctx context.Context,
+	actor Actor,
+	profileID uuid.UUID,
+	req *dto.SendInterestRequest,
+) (*dto.InterestResponse, error) {
+	const op = "SendInterest"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, interestServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.ID == profileID {
+		return nil, NewError(ErrValidation, op, interestServiceName, "you cannot send an interest to your own profile")
+	}
+
+	receiver, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, interestServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+		}
+
+		s.logger.Error("Failed to get profile for interest",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to retrieve profile")
+	}
+	if receiver.UserID == actor.UserID {
+		return nil, NewError(ErrValidation, op, interestServiceName, "you cannot send an interest to a profile of your own account")
+	}
+
+	blocked, err := isBlockedBetween(ctx, s.blockRepo, profile.ID, profileID)
+	if err != nil {
+		s.logger.Error("Failed to check blocks",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to send interest")
+	}
+	if blocked {
+		// Don't reveal the block to the sender
+		return nil, NewError(ErrNotFound, op, interestServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+	}
+
+	// Only one interest may exist between two profiles, whoever sent it
+	_, err = s.interestRepo.GetBetween(ctx, profile.ID, profileID)
+	if err == nil {
+		return nil, NewError(ErrDuplicate, op, interestServiceName, "an interest already exists between these profiles")
+	}
+	if !isRepoNotFound(err) {
+		s.logger.Error("Failed to check existing interest",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to send interest")
+	}
+
+	interest := &model.Interest{
+		SenderProfileID:   profile.ID,
+		ReceiverProfileID: profileID,
+		Status:            model.InterestStatusPending,
+		Message:           req.Message,
+	}
+	if err := s.interestRepo.Create(ctx, interest); err != nil {
+		if isRepoDuplicate(err) {
+			return nil, NewError(ErrDuplicate, op, interestServiceName, "an interest already exists between these profiles")
+		}
+
+		s.logger.Error("Failed to create interest",
+			zap.String("profile_id", profile.ID.String()),
+			zap.String("receiver_profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to send interest")
+	}
+
+	s.logger.UserProfileEvent(ctx, "interest_sent", actor.UserID.String(), profile.ID.String(),
+		zap.String("receiver_profile_id", profileID.String()))
+
+	return dto.InterestFromModel(interest), nil
+}
+
+// RespondToInterest accepts or declines a pending interest received by the user's profile,
+// or by a profile the user manages with permission to respond to interests
+func (s *interestService) RespondToInterest(
+	ctx context.Context,
+	userID uuid.UUID,
+	interestID uuid.UUID,
+	req *dto.RespondInterestRequest,
+) (*dto.InterestResponse, error) {
+	const op = "RespondToInterest"
+
+	interest, err := s.interestRepo.GetByID(ctx, interestID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, interestServiceName, fmt.Sprintf("interest with ID %s not found", interestID))
+		}
+
+		s.logger.Error("Failed to get interest",
+			zap.String("interest_id", interestID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to retrieve interest")
+	}
+
+	profile, err := s.profileRepo.GetByID(ctx, interest.ReceiverProfileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, interestServiceName, fmt.Sprintf("interest with ID %s not found", interestID))
+		}
+
+		s.logger.Error("Failed to get receiver profile",
+			zap.String("profile_id", interest.ReceiverProfileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to retrieve profile")
+	}
+
+	manager, err := authorizeProfileAction(ctx, s.managerRepo, s.logger, op, interestServiceName,
+		profile, userID, model.ProfileManagerScopeRespondInterests, "only the receiver can respond to an interest")
+	if err != nil {
+		return nil, err
+	}
+
+	status := model.InterestStatusDeclined
+	if req.Action == "accept" {
+		status = model.InterestStatusAccepted
+	}
+
+	respondedAt := time.Now().UTC()
+	if err := s.interestRepo.Respond(ctx, interest.ID, status, respondedAt); err != nil {
+		if isRepoInvalidOperation(err) {
+			return nil, NewError(ErrValidation, op, interestServiceName, "the interest has already been responded to")
+		}
+
+		s.logger.Error("Failed to respond to interest",
+			zap.String("interest_id", interest.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to respond to interest")
+	}
+
+	interest.Status = status
+	interest.RespondedAt = &respondedAt
+
+	s.logger.UserProfileEvent(ctx, "interest_"+string(status), userID.String(), profile.ID.String(),
+		append(actorFields(profile, manager),
+			zap.String("interest_id", interest.ID.String()),
+			zap.String("sender_profile_id", interest.SenderProfileID.String()))...)
+
+	return dto.InterestFromModel(interest), nil
+}
+
+// ListReceivedInterests lists interests received by the user's profile, optionally by status
+func (s *interestService) ListReceivedInterests(
+	ctx context.Context,
+	actor Actor,
+	status string,
+	page,
+	limit int,
+) (*dto.InterestListResponse, error) {
+	return s.listInterests(ctx, "ListReceivedInterests", actor, status, page, limit, s.interestRepo.ListReceived)
+}
+
+// ListSentInterests lists interests sent by the user's profile, optionally by status
+func (s *interestService) ListSentInterests(
+	ctx context.Context,
+	actor Actor,
+	status string,
+	page,
+	limit int,
+) (*dto.InterestListResponse, error) {
+	return s.listInterests(ctx, "ListSentInterests", actor, status, page, limit, s.interestRepo.ListSent)
+}
+
+// listInterests validates the status filter and lists one page of the user's interests with list
+func (s *interestService) listInterests(
+	ctx context.Context,
+	op string,
+	actor Actor,
+	status string,
+	page,
+	limit int,
+	list func(ctx context.Context, profileID uuid.UUID, status model.InterestStatus, page, limit int) ([]*model.Interest, int64, error),
+) (*dto.InterestListResponse, error) {
+	switch model.InterestStatus(status) {
+	case "", model.InterestStatusPending, model.InterestStatusAccepted, model.InterestStatusDeclined:
+	default:
+		return nil, NewValidationError(op, interestServiceName, []ValidationError{
+			{Field: "status", Message: "must be one of pending, accepted, declined"},
+		})
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, interestServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	interests, total, err := list(ctx, profile.ID, model.InterestStatus(status), page, limit)
+	if err != nil {
+		s.logger.Error("Failed to list interests",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, interestServiceName, "failed to list interests")
+	}
+
+	response := &dto.InterestListResponse{
+		Interests: make([]*dto.InterestResponse, len(interests)),
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}
+	for i, interest := range interests {
+		response.Interests[i] = dto.InterestFromModel(interest)
+	}
+
+	return response, nil
+}
@@ -2,22 +2,52 @@ package service
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
 )
 
+// Actor identifies who performs an operation: the authenticated user and the profile
+// they act as. An account may own several profiles, so operations on behalf of a
+// profile are explicit about which one; a zero ProfileID selects the account's active profile.
+// ProfileCode is set in place of ProfileID when the profile was named by its public code.
+type Actor struct {
+	UserID      uuid.UUID
+	ProfileID   uuid.UUID
+	ProfileCode string
+}
+
+// ProfileResolver resolves the profile references accepted by the API
+type ProfileResolver interface {
+	// ResolveProfileRef returns the ID of the profile referred to by its ID or its public code
+	ResolveProfileRef(ctx context.Context, ref string) (uuid.UUID, error)
+}
+
 // UserProfileService defines operations available for user profiles
 type UserProfileService interface {
-	// CreateProfile creates a new user profile
+	ProfileResolver
+
+	// CreateProfile creates a new profile owned by the user's account
 	CreateProfile(ctx context.Context, userID uuid.UUID, req *dto.CreateUserProfileRequest) (*dto.UserProfileResponse, error)
 
-	// GetProfileByID retrieves a profile by ID
-	GetProfileByID(ctx context.Context, profileID uuid.UUID, requestingUserID uuid.UUID) (*dto.UserProfileResponse, error)
+	// GetProfileByID retrieves a profile by ID, recording the view by the actor's profile. A
+	// boost ID, from a boosted search result the profile was opened from, counts a click.
+	// Profiles blocked either way with any profile of the actor's account are not found.
+	GetProfileByID(ctx context.Context, profileID uuid.UUID, actor Actor, boostID uuid.UUID) (*dto.UserProfileResponse, error)
+
+	// GetProfileByCode retrieves a profile by its public code, recording the view by the
+	// actor's profile. A boost ID counts a click and blocks hide it as with GetProfileByID.
+	GetProfileByCode(ctx context.Context, code string, actor Actor, boostID uuid.UUID) (*dto.UserProfileResponse, error)
 
-	// GetProfileByUserID retrieves a profile by user ID
-	GetProfileByUserID(ctx context.Context, userID uuid.UUID, requestingUserID uuid.UUID) (*dto.UserProfileResponse, error)
+	// ListAccountProfiles lists the profiles owned by the user's account, marking the active one
+	ListAccountProfiles(ctx context.Context, userID uuid.UUID) ([]*dto.AccountProfileResponse, error)
+
+	// SwitchProfile makes one of the account's profiles the one requests act as by default
+	SwitchProfile(ctx context.Context, userID uuid.UUID, profileID uuid.UUID) (*dto.AccountProfileResponse, error)
 
 	// UpdateProfile updates an existing profile
 	UpdateProfile(ctx context.Context, userID uuid.UUID, profileID uuid.UUID, req *dto.CreateUserProfileRequest) (*dto.UserProfileResponse, error)
@@ -25,6 +55,377 @@ type UserProfileService interface {
 	// DeleteProfile deletes a profile
 	DeleteProfile(ctx context.Context, userID uuid.UUID, profileID uuid.UUID) error
 
-	// SearchProfiles searches for profiles based on criteria
-	SearchProfiles(ctx context.Context, filter repository.ProfileFilter, page, limit int) ([]*dto.UserProfileResponse, int64, error)
+	// SearchProfiles searches for profiles based on criteria, leaving out profiles blocked
+	// either way with any profile of the requesting account
+	SearchProfiles(ctx context.Context, filter repository.ProfileFilter, opts SearchOptions) (*dto.ProfileSearchResponse, error)
+}
+
+// ProfileDraftService defines operations for filling in a profile step by step.
+// An account has at most one draft at a time.
+type ProfileDraftService interface {
+	// GetDraft retrieves the user's profile draft with the steps done so far and the next one
+	GetDraft(ctx context.Context, userID uuid.UUID) (*dto.ProfileDraftResponse, error)
+
+	// SaveDraftStep validates one step and merges it into the user's draft, starting a draft if there is none
+	SaveDraftStep(ctx context.Context, userID uuid.UUID, req dto.DraftStepRequest) (*dto.ProfileDraftResponse, error)
+
+	// SubmitDraft creates a profile from the user's completed draft, validated as CreateProfile does, and deletes the draft
+	SubmitDraft(ctx context.Context, userID uuid.UUID) (*dto.UserProfileResponse, error)
+
+	// DiscardDraft deletes the user's profile draft
+	DiscardDraft(ctx context.Context, userID uuid.UUID) error
+}
+
+// ProfileRevisionService defines operations on the change history of profiles
+type ProfileRevisionService interface {
+	// GetProfileHistory retrieves the changes made to a profile the user owns or manages with edit permission
+	GetProfileHistory(ctx context.Context, userID, profileID uuid.UUID, page, limit int) (*dto.ProfileRevisionListResponse, error)
+
+	// GetProfileHistoryAsAdmin retrieves the changes made to any profile, with their flags
+	GetProfileHistoryAsAdmin(ctx context.Context, profileID uuid.UUID, page, limit int) (*dto.ProfileRevisionListResponse, error)
+
+	// ListFlaggedRevisions retrieves the changes flagged as suspicious across all profiles
+	ListFlaggedRevisions(ctx context.Context, page, limit int) (*dto.ProfileRevisionListResponse, error)
+
+	// RevertProfile restores a profile to its state after one of its revisions, recording the revert as a revision
+	RevertProfile(ctx context.Context, adminID, profileID, revisionID uuid.UUID) (*dto.UserProfileResponse, error)
+}
+
+// ProfileRetentionService defines operations on deleted profiles: restoring them during
+// the restore window and anonymizing them once past retention
+type ProfileRetentionService interface {
+	// ListDeletedProfiles lists the profiles of the user's account that were deleted and can still be restored
+	ListDeletedProfiles(ctx context.Context, userID uuid.UUID) ([]*dto.DeletedProfileResponse, error)
+
+	// RestoreProfile restores a deleted profile the user owns or manages with delete permission
+	RestoreProfile(ctx context.Context, userID, profileID uuid.UUID) (*dto.UserProfileResponse, error)
+
+	// PurgeDeletedProfiles anonymizes the profiles deleted longer ago than the retention period
+	PurgeDeletedProfiles(ctx context.Context) error
+}
+
+// DataExportService defines operations for exporting everything held about an account
+type DataExportService interface {
+	// RequestExport queues an export of the user's account, to be assembled in the background
+	RequestExport(ctx context.Context, userID uuid.UUID) (*dto.DataExportResponse, error)
+
+	// ListExports lists the exports of the user's account, newest first
+	ListExports(ctx context.Context, userID uuid.UUID) ([]*dto.DataExportResponse, error)
+
+	// GetExport retrieves an export of the user's account, with a download token once it is ready
+	GetExport(ctx context.Context, userID, exportID uuid.UUID) (*dto.DataExportResponse, error)
+
+	// DownloadExport returns the archive behind a download token
+	DownloadExport(ctx context.Context, token string) (*dto.DataExportArchive, error)
+
+	// ProcessExports assembles the queued exports and drops the expired archives
+	ProcessExports(ctx context.Context) error
+}
+
+// AccountErasureService defines operations for erasing accounts at their owner's request
+type AccountErasureService interface {
+	// RequestErasure queues the erasure of the user's account, returning the one in progress if any
+	RequestErasure(ctx context.Context, userID uuid.UUID) (*dto.AccountErasureResponse, error)
+
+	// GetErasure retrieves the latest erasure of the user's account, with its receipt once complete
+	GetErasure(ctx context.Context, userID uuid.UUID) (*dto.AccountErasureResponse, error)
+
+	// ProcessErasures carries out the queued erasures and resumes interrupted ones
+	ProcessErasures(ctx context.Context) error
+
+	// VerifyReceipts checks that no erasure receipt was altered, removed or reordered
+	VerifyReceipts(ctx context.Context) (*dto.ErasureReceiptChainResponse, error)
+}
+
+// ConsentChecker tells which mandatory policies a user still has to accept. The
+// middleware gating profile operations consults it.
+type ConsentChecker interface {
+	// MissingConsents returns the mandatory policies whose current version the user hasn't accepted
+	MissingConsents(ctx context.Context, userID uuid.UUID) ([]model.ConsentPolicy, error)
+}
+
+// ConsentService defines operations for the policies users agree to
+type ConsentService interface {
+	ConsentChecker
+
+	// GetConsents returns where the user stands on each policy
+	GetConsents(ctx context.Context, userID uuid.UUID) ([]*dto.ConsentResponse, error)
+
+	// GrantConsent records the user agreeing to the current version of a policy
+	GrantConsent(ctx context.Context, userID uuid.UUID, req *dto.GrantConsentRequest) (*dto.ConsentResponse, error)
+
+	// WithdrawConsent records the user withdrawing their agreement to a policy
+	WithdrawConsent(ctx context.Context, userID uuid.UUID, policy model.ConsentPolicy) (*dto.ConsentResponse, error)
+
+	// ListConsentHistory lists every grant and withdrawal of the user, newest first
+	ListConsentHistory(ctx context.Context, userID uuid.UUID) ([]*dto.ConsentRecordResponse, error)
+}
+
+// SearchOptions controls pagination and optional extras of a profile search
+type SearchOptions struct {
+	Page   int
+	Limit  int
+	Cursor string               // opaque cursor from a previous page; takes precedence over Page
+	Count  repository.CountMode // defaults to exact for page-based and none for cursor-based requests
+	Facets bool                 // also return facet counts for the filter
+	UserID uuid.UUID            // requesting user, whose plan must allow advanced filters; zero skips the check
+}
+
+// SavedSearchService defines operations available for saved searches and their alerts
+type SavedSearchService interface {
+	// CreateSavedSearch saves a named search filter for the user
+	CreateSavedSearch(ctx context.Context, userID uuid.UUID, req *dto.CreateSavedSearchRequest) (*dto.SavedSearchResponse, error)
+
+	// ListSavedSearches lists the user's saved searches
+	ListSavedSearches(ctx context.Context, userID uuid.UUID) ([]*dto.SavedSearchResponse, error)
+
+	// DeleteSavedSearch deletes one of the user's saved searches
+	DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) error
+
+	// RunSavedSearch runs one of the user's saved searches
+	RunSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID, opts SearchOptions) (*dto.ProfileSearchResponse, error)
+
+	// ListAlerts lists the user's saved search alerts
+	ListAlerts(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.SearchAlertListResponse, error)
+
+	// ProcessAlerts finds profiles created since the last run of every due saved search,
+	// records alerts for them and notifies the owners
+	ProcessAlerts(ctx context.Context) error
+}
+
+// PartnerPreferenceService defines operations available for partner preferences
+type PartnerPreferenceService interface {
+	// GetPreferences retrieves the partner preferences of the user's profile
+	GetPreferences(ctx context.Context, actor Actor) (*dto.PartnerPreferenceResponse, error)
+
+	// UpdatePreferences replaces the partner preferences of the user's profile
+	UpdatePreferences(ctx context.Context, actor Actor, req *dto.PartnerPreferenceRequest) (*dto.PartnerPreferenceResponse, error)
+}
+
+// ProfileBlockService defines operations available for blocking profiles
+type ProfileBlockService interface {
+	// BlockProfile hides a profile from the user's profile and vice versa
+	BlockProfile(ctx context.Context, actor Actor, blockedProfileID uuid.UUID) error
+
+	// UnblockProfile removes a block made by the user's profile
+	UnblockProfile(ctx context.Context, actor Actor, blockedProfileID uuid.UUID) error
+
+	// ListBlockedProfiles lists the profiles blocked by the user's profile
+	ListBlockedProfiles(ctx context.Context, actor Actor) ([]*dto.ProfileBlockResponse, error)
+}
+
+// RecommendationService defines operations available for daily match recommendations
+type RecommendationService interface {
+	// ListRecommendations lists the latest recommendations for the user's profile
+	ListRecommendations(ctx context.Context, actor Actor, page, limit int) (*dto.RecommendationListResponse, error)
+
+	// GiveFeedback records a skip or not-interested action on a recommendation
+	GiveFeedback(ctx context.Context, actor Actor, recommendationID uuid.UUID, req *dto.RecommendationFeedbackRequest) error
+
+	// GenerateRecommendations computes the day's recommendations for every profile that doesn't have them yet
+	GenerateRecommendations(ctx context.Context) error
+}
+
+// SimilarProfileService defines operations for finding profiles similar to a given one
+type SimilarProfileService interface {
+	// GetSimilarProfiles lists profiles of the same gender most similar to the given profile,
+	// leaving out the requesting account's own profiles and profiles blocked either way.
+	// A profile blocked with the requesting account has no similar profiles to show.
+	GetSimilarProfiles(ctx context.Context, profileID uuid.UUID, actor Actor, limit int) ([]*dto.SimilarProfileResponse, error)
+}
+
+// InterestService defines operations available for interests between profiles
+type InterestService interface {
+	// SendInterest sends an interest from the user's profile to another profile
+	SendInterest(ctx context.Context, actor Actor, profileID uuid.UUID, req *dto.SendInterestRequest) (*dto.InterestResponse, error)
+
+	// RespondToInterest accepts or declines a pending interest received by the user's profile
+	RespondToInterest(ctx context.Context, userID uuid.UUID, interestID uuid.UUID, req *dto.RespondInterestRequest) (*dto.InterestResponse, error)
+
+	// ListReceivedInterests lists interests received by the user's profile, optionally by status
+	ListReceivedInterests(ctx context.Context, actor Actor, status string, page, limit int) (*dto.InterestListResponse, error)
+
+	// ListSentInterests lists interests sent by the user's profile, optionally by status
+	ListSentInterests(ctx context.Context, actor Actor, status string, page, limit int) (*dto.InterestListResponse, error)
+}
+
+// ContactService defines operations available for contact details
+type ContactService interface {
+	// GetContactDetails retrieves the contact details of the user's own profile
+	GetContactDetails(ctx context.Context, actor Actor) (*dto.ContactDetailsResponse, error)
+
+	// GetManagedContactDetails retrieves the contact details of a profile the user
+	// owns or manages with the view contacts scope
+	GetManagedContactDetails(ctx context.Context, userID uuid.UUID, profileID uuid.UUID) (*dto.ContactDetailsResponse, error)
+
+	// UpdateContactDetails replaces the contact details of the user's own profile
+	UpdateContactDetails(ctx context.Context, actor Actor, req *dto.ContactDetailsRequest) (*dto.ContactDetailsResponse, error)
+
+	// RevealContact reveals a profile's contact details to the user, once per pair of profiles,
+	// if their profiles share an accepted interest or the user has reveals left this month
+	RevealContact(ctx context.Context, actor Actor, profileID uuid.UUID) (*dto.ContactRevealResponse, error)
+
+	// GetRevealQuota reports the user's contact reveal quota for the current month, shared by the account's profiles
+	GetRevealQuota(ctx context.Context, userID uuid.UUID) (*dto.ContactQuotaResponse, error)
+}
+
+// Feature is a capability that depends on the user's membership plan
+type Feature string
+
+// Features gated by membership plans
+const (
+	// FeatureWhoViewedMe lets users see who viewed their profile
+	FeatureWhoViewedMe Feature = "who_viewed_me"
+
+	// FeatureAdvancedFilters lets users search by keywords and distance
+	FeatureAdvancedFilters Feature = "advanced_filters"
+)
+
+// Entitlements are what a user's current membership plan allows
+type Entitlements struct {
+	PlanCode              string
+	PlanName              string
+	ExpiresAt             *time.Time // nil on the free plan
+	MonthlyContactReveals int
+	MonthlyProfileBoosts  int
+	WhoViewedMe           bool
+	AdvancedFilters       bool
+}
+
+// Allows reports whether the entitlements include a feature
+func (e *Entitlements) Allows(feature Feature) bool {
+	switch feature {
+	case FeatureWhoViewedMe:
+		return e.WhoViewedMe
+	case FeatureAdvancedFilters:
+		return e.AdvancedFilters
+	}
+	return false
+}
+
+// Entitler resolves a user's entitlements. Services consult it before gated operations.
+type Entitler interface {
+	// Entitlements returns what the user's current plan allows
+	Entitlements(ctx context.Context, userID uuid.UUID) (*Entitlements, error)
+}
+
+// MembershipService defines operations available for membership plans and subscriptions
+type MembershipService interface {
+	Entitler
+
+	// ListPlans lists the plans that can be subscribed to
+	ListPlans(ctx context.Context) ([]*dto.MembershipPlanResponse, error)
+
+	// GetMembership retrieves the user's current plan and entitlements
+	GetMembership(ctx context.Context, userID uuid.UUID) (*dto.MembershipResponse, error)
+
+	// Subscribe starts a subscription to a paid plan and returns the checkout to pay for it
+	Subscribe(ctx context.Context, userID uuid.UUID, req *dto.SubscribeRequest) (*dto.CheckoutResponse, error)
+
+	// HandlePaymentWebhook applies a payment provider's webhook event to the subscription it refers to
+	HandlePaymentWebhook(ctx context.Context, provider string, header http.Header, body []byte) error
+}
+
+// ProfileViewService defines operations available for profile views
+type ProfileViewService interface {
+	// ListProfileViewers lists who viewed the user's profile, most recent first
+	ListProfileViewers(ctx context.Context, actor Actor, page, limit int) (*dto.ProfileViewerListResponse, error)
+}
+
+// ProfileBoostService defines operations available for profile boosts
+type ProfileBoostService interface {
+	// StartBoost features the user's profile near the top of search results for the configured
+	// period, charged to the monthly boost allowance of their plan
+	StartBoost(ctx context.Context, actor Actor) (*dto.ProfileBoostResponse, error)
+
+	// ListBoosts lists the boosts of the user's profile with the impressions and clicks they generated
+	ListBoosts(ctx context.Context, actor Actor) ([]*dto.ProfileBoostResponse, error)
+}
+
+// ProfileManagerService defines operations available for delegating the management of a profile
+type ProfileManagerService interface {
+	// InviteManager invites another user to manage the user's own profile with the given scopes
+	InviteManager(ctx context.Context, actor Actor, req *dto.InviteProfileManagerRequest) (*dto.ProfileManagerResponse, error)
+
+	// ListManagers lists the pending and active managers of the user's own profile
+	ListManagers(ctx context.Context, actor Actor) ([]*dto.ProfileManagerResponse, error)
+
+	// UpdateManagerScopes changes the scopes of a manager of the user's own profile
+	UpdateManagerScopes(ctx context.Context, actor Actor, managerID uuid.UUID, req *dto.UpdateProfileManagerRequest) (*dto.ProfileManagerResponse, error)
+
+	// RevokeManager ends a delegation, either by the profile owner or by the manager stepping down
+	RevokeManager(ctx context.Context, userID uuid.UUID, managerID uuid.UUID) error
+
+	// ListManagedProfiles lists the profiles the user manages or is invited to manage
+	ListManagedProfiles(ctx context.Context, userID uuid.UUID) ([]*dto.ManagedProfileResponse, error)
+
+	// AcceptInvitation accepts an invitation to manage a profile
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, managerID uuid.UUID) (*dto.ManagedProfileResponse, error)
+}
+
+// ProfileTransferService defines operations for handing a profile over to another account
+type ProfileTransferService interface {
+	// InitiateTransfer offers the actor's profile to another account and returns the
+	// one-time token the receiving user accepts it with
+	InitiateTransfer(ctx context.Context, actor Actor, req *dto.InitiateProfileTransferRequest) (*dto.ProfileTransferCreatedResponse, error)
+
+	// ListTransfers lists the pending transfers the user has offered or been offered
+	ListTransfers(ctx context.Context, userID uuid.UUID) ([]*dto.ProfileTransferResponse, error)
+
+	// CancelTransfer withdraws a transfer by the current owner, or declines it by the receiving user
+	CancelTransfer(ctx context.Context, userID uuid.UUID, transferID uuid.UUID) error
+
+	// AcceptTransfer makes the user's account the owner of the profile offered with the token
+	AcceptTransfer(ctx context.Context, userID uuid.UUID, req *dto.AcceptProfileTransferRequest) (*dto.UserProfileResponse, error)
+}
+
+// ProfileShareService defines operations for sharing a profile with people outside the platform,
+// as a printable biodata or as a public link to a read-only snapshot
+type ProfileShareService interface {
+	// GetPrivacySettings retrieves what the user's profile shows on its biodata and share links
+	GetPrivacySettings(ctx context.Context, actor Actor) (*dto.ProfilePrivacyResponse, error)
+
+	// UpdatePrivacySettings changes what the user's profile shows on its biodata and new share links
+	UpdatePrivacySettings(ctx context.Context, actor Actor, req *dto.UpdateProfilePrivacyRequest) (*dto.ProfilePrivacyResponse, error)
+
+	// GetBiodata renders the printable biodata of a profile as its privacy settings allow.
+	// Profiles hidden from the actor by a block are not found, as with viewing them.
+	GetBiodata(ctx context.Context, profileID uuid.UUID, actor Actor) (*dto.BiodataDocument, error)
+
+	// CreateShareLink snapshots the user's profile and returns a signed public link to it
+	CreateShareLink(ctx context.Context, actor Actor, req *dto.CreateShareLinkRequest) (*dto.ShareLinkCreatedResponse, error)
+
+	// ListShareLinks lists the share links of the user's profile with their view counts
+	ListShareLinks(ctx context.Context, actor Actor) ([]*dto.ShareLinkResponse, error)
+
+	// RevokeShareLink disables a share link of a profile owned by the user
+	RevokeShareLink(ctx context.Context, userID uuid.UUID, linkID uuid.UUID) error
+
+	// GetSharedProfile returns the snapshot behind a share link token, counting the view
+	GetSharedProfile(ctx context.Context, token string) (*dto.SharedProfileResponse, error)
+
+	// GetSharedBiodata renders the biodata of the snapshot behind a share link token, counting the view
+	GetSharedBiodata(ctx context.Context, token string) (*dto.BiodataDocument, error)
+}
+
+// CompletenessScorer scores how complete profiles are. Services consult it to keep the
+// stored score, used for search ranking and filtering, current as profiles change.
+type CompletenessScorer interface {
+	// ScoreProfile returns the completeness of a profile as it is about to be saved, as a percentage
+	ScoreProfile(ctx context.Context, profile *model.UserProfile) (int, error)
+
+	// RefreshCompleteness recomputes and stores the completeness score of a profile
+	RefreshCompleteness(ctx context.Context, profileID uuid.UUID) error
+}
+
+// ProfileCompletenessService defines operations for the completeness of profiles
+type ProfileCompletenessService interface {
+	CompletenessScorer
+
+	// GetMyProfile retrieves the user's profile with its completeness and the checklist of what is missing
+	GetMyProfile(ctx context.Context, actor Actor) (*dto.MyProfileResponse, error)
+
+	// BackfillScores stores the completeness scores of profiles that don't have one yet,
+	// and returns the number of profiles scored
+	BackfillScores(ctx context.Context) (int64, error)
 }
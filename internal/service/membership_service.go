@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/payment"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	membershipServiceName = "MembershipService"
+)
+
+// membershipService implements MembershipService
+type membershipService struct {
+	planRepo         repository.MembershipPlanRepository
+	subscriptionRepo repository.SubscriptionRepository
+	provider         payment.Provider
+	logger           *logger.Logger
+}
+
+// NewMembershipService creates a new membership service that collects payments through provider
+func NewMembershipService(
+	planRepo repository.MembershipPlanRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	provider payment.Provider,
+	logger *logger.Logger,
+) MembershipService {
+	return &membershipService{
+		planRepo:         planRepo,
+		subscriptionRepo: subscriptionRepo,
+		provider:         provider,
+		logger:           logger,
+	}
+}
+
+// Entitlements returns what the user's current plan allows: the plan of the active
+// subscription expiring last, or the free plan when there is none
+func (s *membershipService) Entitlements(ctx context.Context, userID uuid.UUID) (*Entitlements, error) {
+	const op = "Entitlements"
+
+	subscription, err := s.subscriptionRepo.GetCurrent(ctx, userID, time.Now().UTC())
+	if err == nil && subscription.Plan != nil {
+		return entitlementsFromPlan(subscription.Plan, subscription.ExpiresAt), nil
+	}
+	if err != nil && !isRepoNotFound(err) {
+		s.logger.Error("Failed to get current subscription",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, membershipServiceName, "failed to resolve membership")
+	}
+
+	plan, err := s.planRepo.GetByCode(ctx, model.FreePlanCode)
+	if err != nil {
+		s.logger.Error("Failed to get free plan", zap.Error(err))
+		return nil, NewError(ErrInternal, op, membershipServiceName, "failed to resolve membership")
+	}
+
+	return entitlementsFromPlan(plan, nil), nil
+}
+
+// ListPlans lists the plans that can be subscribed to
+func (s *membershipService) ListPlans(ctx context.Context) ([]*dto.MembershipPlanResponse, error) {
+	const op = "ListPlans"
+
+	plans, err := s.planRepo.List(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list membership plans", zap.Error(err))
+		return nil, NewError(ErrInternal, op, membershipServiceName, "failed to list plans")
+	}
+
+	results := make([]*dto.MembershipPlanResponse, len(plans))
+	for i, plan := range plans {
+		results[i] = dto.MembershipPlanFromModel(plan)
+	}
+
+	return results, nil
+}
+
+// GetMembership retrieves the user's current plan and entitlements
+func (s *membershipService) GetMembership(ctx context.Context, userID uuid.UUID) (*dto.MembershipResponse, error) {
+	entitlements, err := s.Entitlements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MembershipResponse{
+		PlanCode:  entitlements.PlanCode,
+		PlanName:  entitlements.PlanName,
+		ExpiresAt: entitlements.ExpiresAt,
+		Entitlements: dto.EntitlementsResponse{
+			MonthlyContactReveals: entitlements.MonthlyContactReveals,
+			MonthlyProfileBoosts:  entitlements.MonthlyProfileBoosts,
+			WhoViewedMe:           entitlements.WhoViewedMe,
+			AdvancedFilters:       entitlements.AdvancedFilters,
+		},
+	}, nil
+}
+
+// Subscribe starts a pending subscription to a paid plan. It becomes active when the
+// payment provider reports the payment through its webhook.
+func (s *membershipService) Subscribe(ctx context.Context, userID uuid.UUID, req *dto.SubscribeRequest) (*dto.CheckoutResponse, error) {
+	const op = "Subscribe"
+
+	plan, err := s.planRepo.GetByCode(ctx, req.PlanCode)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, membershipServiceName, fmt.Sprintf("plan %s not found", req.PlanCode))
+		}
+
+		s.logger.Error("Failed to get membership plan",
+			zap.String("plan_code", req.PlanCode),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, membershipServiceName, "failed to retrieve plan")
+	}
+
+	if !plan.IsActive {
+		return nil, NewError(ErrNotFound, op, membershipServiceName, fmt.Sprintf("plan %s not found", req.PlanCode))
+	}
+	if plan.PricePaise <= 0 {
+		return nil, NewError(ErrValidation, op, membershipServiceName, "the free plan doesn't need a subscription")
+	}
+
+	subscription := &model.Subscription{
+		ID:       uuid.New(),
+		UserID:   userID,
+		PlanCode: plan.Code,
+		Status:   model.SubscriptionStatusPending,
+		Provider: s.provider.Name(),
+	}
+
+	checkout, err := s.provider.CreateCheckout(ctx, payment.CheckoutRequest{
+		SubscriptionID: subscription.ID,
+		UserID:         userID,
+		PlanCode:       plan.Code,
+		AmountPaise:    plan.PricePaise,
+		Currency:       plan.Currency,
+	})
+	if err != nil {
+		s.logger.Error("Failed to create checkout",
+			zap.String("user_id", userID.String()),
+			zap.String("provider", s.provider.Name()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, membershipServiceName, "failed to start payment")
+	}
+
+	subscription.ProviderReference = checkout.Reference
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		s.logger.Error("Failed to create subscription",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, membershipServiceName, "failed to start subscription")
+	}
+
+	s.logger.UserProfileEvent(ctx, "subscription_started", userID.String(), "",
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("plan_code", plan.Code))
+
+	return &dto.CheckoutResponse{
+		SubscriptionID: subscription.ID,
+		PlanCode:       plan.Code,
+		AmountPaise:    plan.PricePaise,
+		Currency:       plan.Currency,
+		Provider:       subscription.Provider,
+		Reference:      checkout.Reference,
+		CheckoutURL:    checkout.URL,
+	}, nil
+}
+
+// HandlePaymentWebhook applies a payment provider's webhook event to the subscription it
+// refers to. Redelivered events and events that no longer apply are acknowledged without effect.
+func (s *membershipService) HandlePaymentWebhook(ctx context.Context, provider string, header http.Header, body []byte) error {
+	const op = "HandlePaymentWebhook"
+
+	if provider != s.provider.Name() {
+		return NewError(ErrNotFound, op, membershipServiceName, fmt.Sprintf("payment provider %s not configured", provider))
+	}
+
+	event, err := s.provider.ParseWebhook(header, body)
+	if err != nil {
+		s.logger.Warn("Rejected payment webhook",
+			zap.String("provider", provider),
+			zap.Error(err))
+		return NewError(ErrValidation, op, membershipServiceName, "invalid webhook")
+	}
+
+	subscription, err := s.subscriptionRepo.GetByReference(ctx, provider, event.Reference)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return NewError(ErrNotFound, op, membershipServiceName, fmt.Sprintf("no subscription for reference %s", event.Reference))
+		}
+
+		s.logger.Error("Failed to get subscription for payment event",
+			zap.String("provider", provider),
+			zap.String("reference", event.Reference),
+			zap.Error(err))
+		return NewError(ErrInternal, op, membershipServiceName, "failed to process payment event")
+	}
+
+	record := &model.PaymentEvent{
+		Provider:       provider,
+		EventID:        event.ID,
+		EventType:      string(event.Type),
+		SubscriptionID: &subscription.ID,
+		Payload:        event.Payload,
+	}
+
+	switch event.Type {
+	case payment.EventPaymentSucceeded:
+		var activated *model.Subscription
+		activated, err = s.subscriptionRepo.Activate(ctx, subscription.ID, record)
+		if err == nil {
+			s.logger.UserProfileEvent(ctx, "subscription_activated", subscription.UserID.String(), "",
+				zap.String("subscription_id", subscription.ID.String()),
+				zap.String("plan_code", subscription.PlanCode),
+				zap.Timep("expires_at", activated.ExpiresAt))
+		}
+	case payment.EventPaymentFailed:
+		if subscription.Status != model.SubscriptionStatusPending {
+			return nil
+		}
+		err = s.subscriptionRepo.SetStatus(ctx, subscription.ID, model.SubscriptionStatusFailed, record)
+	case payment.EventPaymentRefunded:
+		err = s.subscriptionRepo.SetStatus(ctx, subscription.ID, model.SubscriptionStatusCancelled, record)
+		if err == nil {
+			s.logger.UserProfileEvent(ctx, "subscription_cancelled", subscription.UserID.String(), "",
+				zap.String("subscription_id", subscription.ID.String()))
+		}
+	default:
+		s.logger.Debug("Ignoring payment event",
+			zap.String("provider", provider),
+			zap.String("event_type", string(event.Type)))
+		return nil
+	}
+
+	if err != nil {
+		if isRepoDuplicate(err) || isRepoInvalidOperation(err) {
+			s.logger.Info("Payment event already applied",
+				zap.String("provider", provider),
+				zap.String("event_id", event.ID),
+				zap.String("subscription_id", subscription.ID.String()))
+			return nil
+		}
+
+		s.logger.Error("Failed to apply payment event",
+			zap.String("provider", provider),
+			zap.String("event_id", event.ID),
+			zap.String("subscription_id", subscription.ID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, membershipServiceName, "failed to process payment event")
+	}
+
+	return nil
+}
+
+// entitlementsFromPlan creates Entitlements from a plan
+func entitlementsFromPlan(plan *model.MembershipPlan, expiresAt *time.Time) *Entitlements {
+	return &Entitlements{
+		PlanCode:              plan.Code,
+		PlanName:              plan.Name,
+		ExpiresAt:             expiresAt,
+		MonthlyContactReveals: plan.MonthlyContactReveals,
+		MonthlyProfileBoosts:  plan.MonthlyProfileBoosts,
+		WhoViewedMe:           plan.WhoViewedMe,
+		AdvancedFilters:       plan.AdvancedFilters,
+	}
+}
+
+// requireFeature returns ErrNotEntitled unless the user's plan includes the feature
+func requireFeature(
+	ctx context.Context,
+	entitler Entitler,
+	op, svcName string,
+	userID uuid.UUID,
+	feature Feature,
+) error {
+	entitlements, err := entitler.Entitlements(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !entitlements.Allows(feature) {
+		return NewError(ErrNotEntitled, op, svcName, fmt.Sprintf("your membership plan doesn't include %s", feature))
+	}
+
+	return nil
+}
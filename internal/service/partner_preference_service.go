@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	partnerPreferenceServiceName = "PartnerPreferenceService"
+)
+
+// partnerPreferenceService implements PartnerPreferenceService
+type partnerPreferenceService struct {
+	preferenceRepo repository.PartnerPreferenceRepository
+	profileRepo    repository.UserProfileRepository
+	scorer         CompletenessScorer
+	logger         *logger.Logger
+}
+
+// NewPartnerPreferenceService creates a new partner preference service
+func NewPartnerPreferenceService(
+	preferenceRepo repository.PartnerPreferenceRepository,
+	profileRepo repository.UserProfileRepository,
+	scorer CompletenessScorer,
+	logger *logger.Logger,
+) PartnerPreferenceService {
+	return &partnerPreferenceService{
+		preferenceRepo: preferenceRepo,
+		profileRepo:    profileRepo,
+		scorer:         scorer,
+		logger:         logger,
+	}
+}
+
+// GetPreferences retrieves the partner preferences of the user's profile.
+// A profile without preferences gets an empty response.
+func (s *partnerPreferenceService) GetPreferences(ctx context.Context, actor Actor) (*dto.PartnerPreferenceResponse, error) {
+	const op = "GetPreferences"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, partnerPreferenceServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	preference, err := s.preferenceRepo.GetByProfileID(ctx, profile.ID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return &dto.PartnerPreferenceResponse{}, nil
+		}
+
+		s.logger.Error("Failed to get partner preferences",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, partnerPreferenceServiceName, "failed to retrieve preferences")
+	}
+
+	response, err := dto.PartnerPreferenceFromModel(preference)
+	if err != nil {
+		s.logger.Error("Failed to decode partner preferences",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, partnerPreferenceServiceName, "failed to decode preferences")
+	}
+
+	return response, nil
+}
+
+// UpdatePreferences replaces the partner preferences of the user's profile
+func (s *partnerPreferenceService) UpdatePreferences(
+	ctx context.Context,
+	actor Actor,
+	req *dto.PartnerPreferenceRequest,
+) (*dto.PartnerPreferenceResponse, error) {
+	const op = "UpdatePreferences"
+
+	if validationErrors := validatePreferenceRequest(req); len(validationErrors) > 0 {
+		return nil, NewValidationError(op, partnerPreferenceServiceName, validationErrors)
+	}
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, partnerPreferenceServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Preferences are stored as a search filter so that they can be used for matching directly
+	criteria, err := json.Marshal(preferenceFilter(req))
+	if err != nil {
+		return nil, NewError(ErrInternal, op, partnerPreferenceServiceName, "failed to serialize preferences")
+	}
+
+	preference := &model.PartnerPreference{
+		ProfileID: profile.ID,
+		Criteria:  criteria,
+	}
+
+	if err := s.preferenceRepo.Upsert(ctx, preference); err != nil {
+		s.logger.Error("Failed to save partner preferences",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, partnerPreferenceServiceName, "failed to save preferences")
+	}
+
+	s.logger.UserProfileEvent(ctx, "partner_preferences_updated", actor.UserID.String(), profile.ID.String())
+
+	// Setting preferences is part of a complete profile
+	if err := s.scorer.RefreshCompleteness(ctx, profile.ID); err != nil {
+		s.logger.Warn("Failed to refresh profile completeness",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+	}
+
+	return &dto.PartnerPreferenceResponse{
+		PartnerPreferenceRequest: *req,
+		UpdatedAt:                &preference.UpdatedAt,
+	}, nil
+}
+
+// validatePreferenceRequest checks that the preference ranges are consistent
+func validatePreferenceRequest(req *dto.PartnerPreferenceRequest) []ValidationError {
+	var errors []ValidationError
+
+	if req.MinAge != nil && req.MaxAge != nil && *req.MinAge > *req.MaxAge {
+		errors = append(errors, ValidationError{
+			Field:   "max_age",
+			Message: "Maximum age must not be less than minimum age",
+		})
+	}
+
+	if req.MinHeight != nil && req.MaxHeight != nil && *req.MinHeight > *req.MaxHeight {
+		errors = append(errors, ValidationError{
+			Field:   "max_height",
+			Message: "Maximum height must not be less than minimum height",
+		})
+	}
+
+	return errors
+}
+
+// preferenceFilter converts partner preferences to the equivalent search filter
+func preferenceFilter(req *dto.PartnerPreferenceRequest) repository.ProfileFilter {
+	filter := repository.ProfileFilter{
+		MinAge:    req.MinAge,
+		MaxAge:    req.MaxAge,
+		MinHeight: req.MinHeight,
+		MaxHeight: req.MaxHeight,
+	}
+
+	for _, v := range req.Community {
+		filter.Community = append(filter.Community, model.Community(v))
+	}
+	for _, v := range req.Nationality {
+		filter.Nationality = append(filter.Nationality, model.Nationality(v))
+	}
+	for _, v := range req.MaritalStatus {
+		filter.MaritalStatus = append(filter.MaritalStatus, model.MaritalStatus(v))
+	}
+	for _, v := range req.HomeDistrict {
+		filter.HomeDistrict = append(filter.HomeDistrict, model.HomeDistrict(v))
+	}
+
+	return filter
+}
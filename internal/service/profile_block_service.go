@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileBlockServiceName = "ProfileBlockService"
+)
+
+// profileBlockService implements ProfileBlockService
+type profileBlockService struct {
+	blockRepo   repository.ProfileBlockRepository
+	profileRepo repository.UserProfileRepository
+	logger      *logger.Logger
+}
+
+// NewProfileBlockService creates a new profile block service
+func NewProfileBlockService(
+	blockRepo repository.ProfileBlockRepository,
+	profileRepo repository.UserProfileRepository,
+	logger *logger.Logger,
+) ProfileBlockService {
+	return &profileBlockService{
+		blockRepo:   blockRepo,
+		profileRepo: profileRepo,
+		logger:      logger,
+	}
+}
+
+// BlockProfile hides a profile from the user's profile and vice versa
+func (s *profileBlockService) BlockProfile(ctx context.Context, actor Actor, blockedProfileID uuid.UUID) error {
+	const op = "BlockProfile"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileBlockServiceName, actor)
+	if err != nil {
+		return err
+	}
+
+	if profile.ID == blockedProfileID {
+		return NewError(ErrValidation, op, profileBlockServiceName, "you cannot block your own profile")
+	}
+
+	if _, err := s.profileRepo.GetByID(ctx, blockedProfileID); err != nil {
+		if isRepoNotFound(err) {
+			return NewError(ErrNotFound, op, profileBlockServiceName, fmt.Sprintf("profile with ID %s not found", blockedProfileID))
+		}
+
+		s.logger.Error("Failed to get profile to block",
+			zap.String("profile_id", blockedProfileID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileBlockServiceName, "failed to retrieve profile")
+	}
+
+	block := &model.ProfileBlock{
+		ProfileID:        profile.ID,
+		BlockedProfileID: blockedProfileID,
+	}
+	if err := s.blockRepo.Create(ctx, block); err != nil {
+		s.logger.Error("Failed to block profile",
+			zap.String("profile_id", profile.ID.String()),
+			zap.String("blocked_profile_id", blockedProfileID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileBlockServiceName, "failed to block profile")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_blocked", actor.UserID.String(), profile.ID.String(),
+		zap.String("blocked_profile_id", blockedProfileID.String()))
+
+	return nil
+}
+
+// UnblockProfile removes a block made by the user's profile
+func (s *profileBlockService) UnblockProfile(ctx context.Context, actor Actor, blockedProfileID uuid.UUID) error {
+	const op = "UnblockProfile"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileBlockServiceName, actor)
+	if err != nil {
+		return err
+	}
+
+	if err := s.blockRepo.Delete(ctx, profile.ID, blockedProfileID); err != nil {
+		if isRepoNotFound(err) {
+			return NewError(ErrNotFound, op, profileBlockServiceName, fmt.Sprintf("profile %s is not blocked", blockedProfileID))
+		}
+
+		s.logger.Error("Failed to unblock profile",
+			zap.String("profile_id", profile.ID.String()),
+			zap.String("blocked_profile_id", blockedProfileID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileBlockServiceName, "failed to unblock profile")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_unblocked", actor.UserID.String(), profile.ID.String(),
+		zap.String("blocked_profile_id", blockedProfileID.String()))
+
+	return nil
+}
+
+// ListBlockedProfiles lists the profiles blocked by the user's profile
+func (s *profileBlockService) ListBlockedProfiles(ctx context.Context, actor Actor) ([]*dto.ProfileBlockResponse, error) {
+	const op = "ListBlockedProfiles"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileBlockServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.blockRepo.ListByProfileID(ctx, profile.ID)
+	if err != nil {
+		s.logger.Error("Failed to list blocked profiles",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileBlockServiceName, "failed to list blocked profiles")
+	}
+
+	results := make([]*dto.ProfileBlockResponse, len(blocks))
+	for i, block := range blocks {
+		results[i] = dto.ProfileBlockFromModel(block)
+	}
+
+	return results, nil
+}
+
+// hideIfBlocked returns ErrNotFound when a block stands between a profile and any
+// profile of the user's account, so that blocked members can't tell the profile exists
+func hideIfBlocked(
+	ctx context.Context,
+	blockRepo repository.ProfileBlockRepository,
+	log *logger.Logger,
+	op, svcName string,
+	userID uuid.UUID,
+	profile *model.UserProfile,
+) error {
+	if profile.UserID == userID {
+		return nil
+	}
+
+	blocked, err := blockRepo.IsBlockedForAccount(ctx, userID, profile.ID)
+	if err != nil {
+		log.Error("Failed to check profile blocks",
+			zap.String("user_id", userID.String()),
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, svcName, "failed to retrieve profile")
+	}
+	if blocked {
+		return NewError(ErrNotFound, op, svcName, "profile not found")
+	}
+
+	return nil
+}
+
+// isBlockedBetween reports whether either profile has blocked the other
+func isBlockedBetween(ctx context.Context, blockRepo repository.ProfileBlockRepository, profileID, otherProfileID uuid.UUID) (bool, error) {
+	related, err := blockRepo.ListRelatedProfileIDs(ctx, profileID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, id := range related {
+		if id == otherProfileID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
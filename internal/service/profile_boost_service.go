@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileBoostServiceName = "ProfileBoostService"
+)
+
+// profileBoostService implements ProfileBoostService
+type profileBoostService struct {
+	boostRepo   repository.ProfileBoostRepository
+	profileRepo repository.UserProfileRepository
+	entitler    Entitler
+	boostCfg    config.BoostConfig
+	logger      *logger.Logger
+}
+
+// NewProfileBoostService creates a new profile boost service
+func NewProfileBoostService(
+	boostRepo repository.ProfileBoostRepository,
+	profileRepo repository.UserProfileRepository,
+	entitler Entitler,
+	boostCfg config.BoostConfig,
+	logger *logger.Logger,
+) ProfileBoostService {
+	return &profileBoostService{
+		boostRepo:   boostRepo,
+		profileRepo: profileRepo,
+		entitler:    entitler,
+		boostCfg:    boostCfg,
+		logger:      logger,
+	}
+}
+
+// StartBoost features the user's profile near the top of search results from now on
+func (s *profileBoostService) StartBoost(ctx context.Context, actor Actor) (*dto.ProfileBoostResponse, error) {
+	const op = "StartBoost"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileBoostServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements, err := s.entitler.Entitlements(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if entitlements.MonthlyProfileBoosts <= 0 {
+		return nil, NewError(ErrNotEntitled, op, profileBoostServiceName, "your membership plan doesn't include profile boosts")
+	}
+
+	now := time.Now().UTC()
+	boost := &model.ProfileBoost{
+		ProfileID: profile.ID,
+		UserID:    actor.UserID,
+		StartsAt:  now,
+		EndsAt:    now.Add(s.boostCfg.Duration),
+	}
+
+	created, err := s.boostRepo.CreateWithinQuota(ctx, boost, monthStart(now), entitlements.MonthlyProfileBoosts)
+	if err != nil {
+		if isRepoDuplicate(err) {
+			return nil, NewError(ErrDuplicate, op, profileBoostServiceName, "your profile is already boosted")
+		}
+
+		s.logger.Error("Failed to create profile boost",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileBoostServiceName, "failed to boost profile")
+	}
+	if !created {
+		return nil, NewError(ErrQuotaExceeded, op, profileBoostServiceName, "monthly profile boosts used up")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_boosted", actor.UserID.String(), profile.ID.String(),
+		zap.String("boost_id", boost.ID.String()),
+		zap.Time("ends_at", boost.EndsAt))
+
+	return dto.ProfileBoostFromModel(boost, now), nil
+}
+
+// ListBoosts lists the boosts of the user's profile, newest first
+func (s *profileBoostService) ListBoosts(ctx context.Context, actor Actor) ([]*dto.ProfileBoostResponse, error) {
+	const op = "ListBoosts"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileBoostServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	boosts, err := s.boostRepo.ListByProfileID(ctx, profile.ID)
+	if err != nil {
+		s.logger.Error("Failed to list profile boosts",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileBoostServiceName, "failed to list boosts")
+	}
+
+	now := time.Now().UTC()
+	results := make([]*dto.ProfileBoostResponse, len(boosts))
+	for i, boost := range boosts {
+		results[i] = dto.ProfileBoostFromModel(boost, now)
+	}
+
+	return results, nil
+}
+
+// interleaveBoosted places boosted hits at every interval-th position from the top of
+// the organic hits, dropping organic duplicates of boosted profiles. Boosted hits left
+// over when the organic hits run out are appended, and the page is trimmed to limit.
+func interleaveBoosted(organic, boosted []*repository.ProfileSearchHit, interval, limit int) []*repository.ProfileSearchHit {
+	if len(boosted) == 0 {
+		return organic
+	}
+	if interval < 1 {
+		interval = 1
+	}
+
+	boostedIDs := make(map[uuid.UUID]bool, len(boosted))
+	for _, hit := range boosted {
+		boostedIDs[hit.Profile.ID] = true
+	}
+
+	merged := make([]*repository.ProfileSearchHit, 0, len(organic)+len(boosted))
+	next := 0
+	for _, hit := range organic {
+		if boostedIDs[hit.Profile.ID] {
+			continue
+		}
+		if next < len(boosted) && len(merged)%interval == 0 {
+			merged = append(merged, boosted[next])
+			next++
+		}
+		merged = append(merged, hit)
+	}
+
+	merged = append(merged, boosted[next:]...)
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/completeness"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/photo"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileCompletenessServiceName = "ProfileCompletenessService"
+)
+
+// profileCompletenessService implements ProfileCompletenessService
+type profileCompletenessService struct {
+	profileRepo    repository.UserProfileRepository
+	preferenceRepo repository.PartnerPreferenceRepository
+	photos         photo.Store
+	logger         *logger.Logger
+}
+
+// NewProfileCompletenessService creates a new profile completeness service
+func NewProfileCompletenessService(
+	profileRepo repository.UserProfileRepository,
+	preferenceRepo repository.PartnerPreferenceRepository,
+	photos photo.Store,
+	logger *logger.Logger,
+) ProfileCompletenessService {
+	return &profileCompletenessService{
+		profileRepo:    profileRepo,
+		preferenceRepo: preferenceRepo,
+		photos:         photos,
+		logger:         logger,
+	}
+}
+
+// GetMyProfile retrieves the user's profile with its completeness. Photos are uploaded
+// through the media service, so the stored score is brought up to date here as well.
+func (s *profileCompletenessService) GetMyProfile(ctx context.Context, actor Actor) (*dto.MyProfileResponse, error) {
+	const op = "GetMyProfile"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileCompletenessServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.evaluate(ctx, profile)
+	if err != nil {
+		s.logger.Error("Failed to evaluate profile completeness",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileCompletenessServiceName, "failed to evaluate profile completeness")
+	}
+
+	if result.Score != profile.CompletenessScore {
+		// The profile is still returned if the score can't be stored; the next change stores it
+		if err := s.profileRepo.UpdateCompletenessScore(ctx, profile.ID, result.Score); err != nil {
+			s.logger.Warn("Failed to store profile completeness score",
+				zap.String("profile_id", profile.ID.String()),
+				zap.Error(err))
+		} else {
+			profile.CompletenessScore = result.Score
+		}
+	}
+
+	return &dto.MyProfileResponse{
+		UserProfileResponse: dto.FromModel(profile),
+		Completeness:        dto.CompletenessFromResult(result),
+	}, nil
+}
+
+// ScoreProfile returns the completeness of a profile as it is about to be saved
+func (s *profileCompletenessService) ScoreProfile(ctx context.Context, profile *model.UserProfile) (int, error) {
+	result, err := s.evaluate(ctx, profile)
+	if err != nil {
+		return 0, err
+	}
+	return result.Score, nil
+}
+
+// RefreshCompleteness recomputes and stores the completeness score of a profile
+func (s *profileCompletenessService) RefreshCompleteness(ctx context.Context, profileID uuid.UUID) error {
+	const op = "RefreshCompleteness"
+
+	profile, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return NewError(ErrNotFound, op, profileCompletenessServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+		}
+		return NewError(ErrInternal, op, profileCompletenessServiceName, "failed to retrieve profile")
+	}
+
+	result, err := s.evaluate(ctx, profile)
+	if err != nil {
+		return NewError(ErrInternal, op, profileCompletenessServiceName, "failed to evaluate profile completeness")
+	}
+	if result.Score == profile.CompletenessScore {
+		return nil
+	}
+
+	if err := s.profileRepo.UpdateCompletenessScore(ctx, profile.ID, result.Score); err != nil {
+		return NewError(ErrInternal, op, profileCompletenessServiceName, "failed to store profile completeness score")
+	}
+
+	return nil
+}
+
+// BackfillScores scores the profiles whose completeness score is 0, which are those
+// created before scores were stored. A profile that really scores 0 is scored again
+// at each run, which is harmless.
+func (s *profileCompletenessService) BackfillScores(ctx context.Context) (int64, error) {
+	const batchSize = 500
+
+	var updated int64
+	lastID := uuid.Nil
+	for {
+		profiles, err := s.profileRepo.ListUnscored(ctx, lastID, batchSize)
+		if err != nil {
+			return updated, err
+		}
+
+		for _, profile := range profiles {
+			result, err := s.evaluate(ctx, profile)
+			if err != nil {
+				return updated, fmt.Errorf("evaluate profile %s: %w", profile.ID, err)
+			}
+			if result.Score == 0 {
+				continue
+			}
+
+			if err := s.profileRepo.UpdateCompletenessScore(ctx, profile.ID, result.Score); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+
+		if len(profiles) < batchSize {
+			return updated, nil
+		}
+		lastID = profiles[len(profiles)-1].ID
+	}
+}
+
+// evaluate looks up the photos and preferences of a profile and scores its completeness
+func (s *profileCompletenessService) evaluate(ctx context.Context, profile *model.UserProfile) (*completeness.Result, error) {
+	facts := &completeness.Facts{Profile: profile}
+
+	// A profile being created has no ID yet, and so no photos or preferences
+	if profile.ID != uuid.Nil {
+		count, err := s.photos.CountPhotos(ctx, profile.ID)
+		switch {
+		case errors.Is(err, photo.ErrUnavailable):
+			facts.PhotosUnknown = true
+		case err != nil:
+			return nil, fmt.Errorf("count photos: %w", err)
+		}
+		facts.PhotoCount = count
+
+		_, err = s.preferenceRepo.GetByProfileID(ctx, profile.ID)
+		if err != nil && !isRepoNotFound(err) {
+			return nil, fmt.Errorf("get partner preferences: %w", err)
+		}
+		facts.HasPreferences = err == nil
+	}
+
+	return completeness.Evaluate(facts), nil
+}
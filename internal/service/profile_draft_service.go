@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileDraftServiceName = "ProfileDraftService"
+)
+
+// draftStepValidators check a step beyond its request binding, with the same rules
+// CreateProfile applies to the whole request. Steps without one need nothing more.
+var draftStepValidators = map[model.ProfileDraftStep]func(req *dto.CreateUserProfileRequest) []ValidationError{
+	model.ProfileDraftStepBasic:    validateBasicDetails,
+	model.ProfileDraftStepPhysical: validatePhysicalDetails,
+	model.ProfileDraftStepLocation: validateLocationDetails,
+}
+
+// profileDraftService implements ProfileDraftService
+type profileDraftService struct {
+	draftRepo   repository.ProfileDraftRepository
+	profileRepo repository.UserProfileRepository
+	profiles    UserProfileService
+	logger      *logger.Logger
+}
+
+// NewProfileDraftService creates a new profile draft service
+func NewProfileDraftService(
+	draftRepo repository.ProfileDraftRepository,
+	profileRepo repository.UserProfileRepository,
+	profiles UserProfileService,
+	logger *logger.Logger,
+) ProfileDraftService {
+	return &profileDraftService{
+		draftRepo:   draftRepo,
+		profileRepo: profileRepo,
+		profiles:    profiles,
+		logger:      logger,
+	}
+}
+
+// GetDraft retrieves the user's profile draft with the steps done so far
+func (s *profileDraftService) GetDraft(ctx context.Context, userID uuid.UUID) (*dto.ProfileDraftResponse, error) {
+	const op = "GetDraft"
+
+	draft, err := s.getDraft(ctx, op, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.draftResponse(op, draft)
+}
+
+// SaveDraftStep validates one step and merges it into the user's draft, starting a draft if there is none
+func (s *profileDraftService) SaveDraftStep(
+	ctx context.Context,
+	userID uuid.UUID,
+	req dto.DraftStepRequest,
+) (*dto.ProfileDraftResponse, error) {
+	const op = "SaveDraftStep"
+
+	if userID == uuid.Nil {
+		return nil, NewError(ErrValidation, op, profileDraftServiceName, "user ID is required")
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to serialize draft step")
+	}
+
+	if validate, ok := draftStepValidators[req.Step()]; ok {
+		// The step's fields are validated on their own, as the request they are part of
+		var stepReq dto.CreateUserProfileRequest
+		if err := json.Unmarshal(data, &stepReq); err != nil {
+			return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to read draft step")
+		}
+		if validationErrors := validate(&stepReq); len(validationErrors) > 0 {
+			return nil, NewValidationError(op, profileDraftServiceName, validationErrors)
+		}
+	}
+
+	draft := &model.ProfileDraft{
+		UserID:   userID,
+		Data:     data,
+		LastStep: req.Step(),
+	}
+	if err := s.draftRepo.SaveStep(ctx, draft); err != nil {
+		s.logger.Error("Failed to save profile draft step",
+			zap.String("user_id", userID.String()),
+			zap.String("step", string(req.Step())),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to save draft")
+	}
+
+	s.logger.Info("Profile draft step saved",
+		zap.String("user_id", userID.String()),
+		zap.String("draft_id", draft.ID.String()),
+		zap.String("step", string(req.Step())),
+		zap.String("request_id", requestIDFromContext(ctx)))
+
+	return s.draftResponse(op, draft)
+}
+
+// SubmitDraft creates a profile from the user's draft once every step is done. The
+// draft goes through the same validation as CreateProfile, and is deleted in the
+// transaction that creates the profile so that it can't be submitted twice.
+func (s *profileDraftService) SubmitDraft(ctx context.Context, userID uuid.UUID) (*dto.UserProfileResponse, error) {
+	const op = "SubmitDraft"
+
+	draft, err := s.getDraft(ctx, op, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := dto.CompletedDraftSteps(draft)
+	if err != nil {
+		s.logger.Error("Failed to decode profile draft",
+			zap.String("draft_id", draft.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to read draft")
+	}
+	if missing := missingDraftSteps(completed); len(missing) > 0 {
+		return nil, NewError(ErrValidation, op, profileDraftServiceName,
+			fmt.Sprintf("complete the %s steps before submitting", strings.Join(missing, ", ")))
+	}
+
+	req, err := dto.DraftRequest(draft)
+	if err != nil {
+		s.logger.Error("Failed to decode profile draft",
+			zap.String("draft_id", draft.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to read draft")
+	}
+
+	var profile *dto.UserProfileResponse
+	err = s.profileRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		if profile, err = s.profiles.CreateProfile(txCtx, userID, req); err != nil {
+			return err
+		}
+
+		if err := s.draftRepo.Delete(txCtx, draft.ID); err != nil {
+			if isRepoNotFound(err) {
+				return NewError(ErrNotFound, op, profileDraftServiceName, "the draft was already submitted or discarded")
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		var svcErr *ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, svcErr
+		}
+
+		s.logger.Error("Failed to submit profile draft",
+			zap.String("draft_id", draft.ID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to submit draft")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_draft_submitted", userID.String(), profile.ID.String(),
+		zap.String("draft_id", draft.ID.String()))
+
+	return profile, nil
+}
+
+// DiscardDraft deletes the user's profile draft
+func (s *profileDraftService) DiscardDraft(ctx context.Context, userID uuid.UUID) error {
+	const op = "DiscardDraft"
+
+	draft, err := s.getDraft(ctx, op, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.draftRepo.Delete(ctx, draft.ID); err != nil {
+		if isRepoNotFound(err) {
+			return NewError(ErrNotFound, op, profileDraftServiceName, "you have no profile draft")
+		}
+
+		s.logger.Error("Failed to delete profile draft",
+			zap.String("draft_id", draft.ID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileDraftServiceName, "failed to discard draft")
+	}
+
+	s.logger.Info("Profile draft discarded",
+		zap.String("user_id", userID.String()),
+		zap.String("draft_id", draft.ID.String()),
+		zap.String("request_id", requestIDFromContext(ctx)))
+
+	return nil
+}
+
+// getDraft retrieves the user's draft, mapping a missing draft to ErrNotFound
+func (s *profileDraftService) getDraft(ctx context.Context, op string, userID uuid.UUID) (*model.ProfileDraft, error) {
+	draft, err := s.draftRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, profileDraftServiceName, "you have no profile draft")
+		}
+
+		s.logger.Error("Failed to get profile draft",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to retrieve draft")
+	}
+
+	return draft, nil
+}
+
+// draftResponse converts a draft, which fails only if its stored data is corrupt
+func (s *profileDraftService) draftResponse(op string, draft *model.ProfileDraft) (*dto.ProfileDraftResponse, error) {
+	response, err := dto.ProfileDraftFromModel(draft)
+	if err != nil {
+		s.logger.Error("Failed to decode profile draft",
+			zap.String("draft_id", draft.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileDraftServiceName, "failed to read draft")
+	}
+	return response, nil
+}
+
+// missingDraftSteps returns the steps not among the completed ones, in step order
+func missingDraftSteps(completed []model.ProfileDraftStep) []string {
+	done := make(map[model.ProfileDraftStep]bool, len(completed))
+	for _, step := range completed {
+		done[step] = true
+	}
+
+	var missing []string
+	for _, step := range model.ProfileDraftSteps {
+		if !done[step] {
+			missing = append(missing, string(step))
+		}
+	}
+	return missing
+}
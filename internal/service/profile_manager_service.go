@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileManagerServiceName = "ProfileManagerService"
+)
+
+// profileManagerService implements ProfileManagerService
+type profileManagerService struct {
+	managerRepo repository.ProfileManagerRepository
+	profileRepo repository.UserProfileRepository
+	logger      *logger.Logger
+}
+
+// NewProfileManagerService creates a new profile manager service
+func NewProfileManagerService(
+	managerRepo repository.ProfileManagerRepository,
+	profileRepo repository.UserProfileRepository,
+	logger *logger.Logger,
+) ProfileManagerService {
+	return &profileManagerService{
+		managerRepo: managerRepo,
+		profileRepo: profileRepo,
+		logger:      logger,
+	}
+}
+
+// InviteManager invites another user to manage the user's own profile with the given scopes
+func (s *profileManagerService) InviteManager(
+	ctx context.Context,
+	actor Actor,
+	req *dto.InviteProfileManagerRequest,
+) (*dto.ProfileManagerResponse, error) {
+	const op = "InviteManager"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileManagerServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserID == actor.UserID {
+		return nil, NewError(ErrValidation, op, profileManagerServiceName, "you already own this profile")
+	}
+
+	manager := &model.ProfileManager{
+		ProfileID:     profile.ID,
+		ManagerUserID: req.UserID,
+		InvitedBy:     actor.UserID,
+		Status:        model.ProfileManagerStatusInvited,
+	}
+	manager.SetScopes(toManagerScopes(req.Scopes))
+
+	if err := s.managerRepo.Create(ctx, manager); err != nil {
+		if isRepoDuplicate(err) {
+			return nil, NewError(ErrDuplicate, op, profileManagerServiceName, "the user already manages or is invited to manage your profile")
+		}
+
+		s.logger.Error("Failed to create profile manager invitation",
+			zap.String("profile_id", profile.ID.String()),
+			zap.String("manager_user_id", req.UserID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileManagerServiceName, "failed to invite manager")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_manager_invited", actor.UserID.String(), profile.ID.String(),
+		zap.String("manager_user_id", req.UserID.String()),
+		zap.Strings("scopes", req.Scopes))
+
+	return dto.ProfileManagerFromModel(manager), nil
+}
+
+// ListManagers lists the pending and active managers of the user's own profile
+func (s *profileManagerService) ListManagers(ctx context.Context, actor Actor) ([]*dto.ProfileManagerResponse, error) {
+	const op = "ListManagers"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileManagerServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	managers, err := s.managerRepo.ListByProfileID(ctx, profile.ID)
+	if err != nil {
+		s.logger.Error("Failed to list profile managers",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileManagerServiceName, "failed to list managers")
+	}
+
+	response := make([]*dto.ProfileManagerResponse, len(managers))
+	for i, manager := range managers {
+		response[i] = dto.ProfileManagerFromModel(manager)
+	}
+
+	return response, nil
+}
+
+// UpdateManagerScopes changes the scopes of a manager of the user's own profile
+func (s *profileManagerService) UpdateManagerScopes(
+	ctx context.Context,
+	actor Actor,
+	managerID uuid.UUID,
+	req *dto.UpdateProfileManagerRequest,
+) (*dto.ProfileManagerResponse, error) {
+	const op = "UpdateManagerScopes"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileManagerServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := s.getManager(ctx, op, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if manager.ProfileID != profile.ID || manager.Status == model.ProfileManagerStatusRevoked {
+		return nil, NewError(ErrNotFound, op, profileManagerServiceName, fmt.Sprintf("manager with ID %s not found", managerID))
+	}
+
+	manager.SetScopes(toManagerScopes(req.Scopes))
+	if err := s.managerRepo.UpdateScopes(ctx, manager); err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, profileManagerServiceName, fmt.Sprintf("manager with ID %s not found", managerID))
+		}
+
+		s.logger.Error("Failed to update profile manager scopes",
+			zap.String("manager_id", managerID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileManagerServiceName, "failed to update manager")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_manager_updated", actor.UserID.String(), profile.ID.String(),
+		zap.String("manager_user_id", manager.ManagerUserID.String()),
+		zap.Strings("scopes", req.Scopes))
+
+	return dto.ProfileManagerFromModel(manager), nil
+}
+
+// RevokeManager ends a delegation, either by the profile owner or by the manager stepping down
+func (s *profileManagerService) RevokeManager(ctx context.Context, userID uuid.UUID, managerID uuid.UUID) error {
+	const op = "RevokeManager"
+
+	notFound := NewError(ErrNotFound, op, profileManagerServiceName, fmt.Sprintf("manager with ID %s not found", managerID))
+
+	manager, err := s.getManager(ctx, op, managerID)
+	if err != nil {
+		return err
+	}
+
+	if manager.ManagerUserID != userID {
+		profile, err := s.profileRepo.GetByID(ctx, manager.ProfileID)
+		if err != nil {
+			if isRepoNotFound(err) {
+				return notFound
+			}
+
+			s.logger.Error("Failed to get profile for manager revocation",
+				zap.String("profile_id", manager.ProfileID.String()),
+				zap.Error(err))
+			return NewError(ErrInternal, op, profileManagerServiceName, "failed to retrieve profile")
+		}
+		if profile.UserID != userID {
+			return notFound
+		}
+	}
+
+	if err := s.managerRepo.Revoke(ctx, manager.ID, time.Now().UTC()); err != nil {
+		if isRepoInvalidOperation(err) {
+			return notFound
+		}
+
+		s.logger.Error("Failed to revoke profile manager",
+			zap.String("manager_id", managerID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileManagerServiceName, "failed to revoke manager")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_manager_revoked", userID.String(), manager.ProfileID.String(),
+		zap.String("manager_user_id", manager.ManagerUserID.String()))
+
+	return nil
+}
+
+// ListManagedProfiles lists the profiles the user manages or is invited to manage
+func (s *profileManagerService) ListManagedProfiles(ctx context.Context, userID uuid.UUID) ([]*dto.ManagedProfileResponse, error) {
+	const op = "ListManagedProfiles"
+
+	managers, err := s.managerRepo.ListByManagerUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list managed profiles",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileManagerServiceName, "failed to list managed profiles")
+	}
+
+	response := make([]*dto.ManagedProfileResponse, len(managers))
+	for i, manager := range managers {
+		response[i] = dto.ManagedProfileFromModel(manager)
+	}
+
+	return response, nil
+}
+
+// AcceptInvitation accepts an invitation to manage a profile
+func (s *profileManagerService) AcceptInvitation(
+	ctx context.Context,
+	userID uuid.UUID,
+	managerID uuid.UUID,
+) (*dto.ManagedProfileResponse, error) {
+	const op = "AcceptInvitation"
+
+	manager, err := s.getManager(ctx, op, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if manager.ManagerUserID != userID {
+		return nil, NewError(ErrNotFound, op, profileManagerServiceName, fmt.Sprintf("invitation with ID %s not found", managerID))
+	}
+
+	acceptedAt := time.Now().UTC()
+	if err := s.managerRepo.Accept(ctx, manager.ID, acceptedAt); err != nil {
+		if isRepoInvalidOperation(err) {
+			return nil, NewError(ErrValidation, op, profileManagerServiceName, "the invitation is no longer pending")
+		}
+
+		s.logger.Error("Failed to accept profile manager invitation",
+			zap.String("manager_id", managerID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileManagerServiceName, "failed to accept invitation")
+	}
+
+	manager.Status = model.ProfileManagerStatusActive
+	manager.AcceptedAt = &acceptedAt
+
+	s.logger.UserProfileEvent(ctx, "profile_manager_accepted", userID.String(), manager.ProfileID.String(),
+		zap.String("invited_by", manager.InvitedBy.String()))
+
+	return dto.ManagedProfileFromModel(manager), nil
+}
+
+// getManager retrieves a delegation by ID, mapping repository errors to service errors
+func (s *profileManagerService) getManager(ctx context.Context, op string, managerID uuid.UUID) (*model.ProfileManager, error) {
+	manager, err := s.managerRepo.GetByID(ctx, managerID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, profileManagerServiceName, fmt.Sprintf("manager with ID %s not found", managerID))
+		}
+
+		s.logger.Error("Failed to get profile manager",
+			zap.String("manager_id", managerID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileManagerServiceName, "failed to retrieve manager")
+	}
+
+	return manager, nil
+}
+
+// toManagerScopes converts validated scope names to model scopes
+func toManagerScopes(names []string) []model.ProfileManagerScope {
+	scopes := make([]model.ProfileManagerScope, len(names))
+	for i, name := range names {
+		scopes[i] = model.ProfileManagerScope(name)
+	}
+	return scopes
+}
+
+// authorizeProfileAction checks that the user owns the profile or manages it with
+// the given scope, returning ErrUnauthorized with the denied message otherwise.
+// It returns the delegation the user acts through, or nil when the user is the owner.
+func authorizeProfileAction(
+	ctx context.Context,
+	managerRepo repository.ProfileManagerRepository,
+	log *logger.Logger,
+	op, svcName string,
+	profile *model.UserProfile,
+	userID uuid.UUID,
+	scope model.ProfileManagerScope,
+	denied string,
+) (*model.ProfileManager, error) {
+	if profile.UserID == userID {
+		return nil, nil
+	}
+
+	manager, err := managerRepo.GetActive(ctx, profile.ID, userID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrUnauthorized, op, svcName, denied)
+		}
+
+		log.Error("Failed to get profile manager",
+			zap.String("profile_id", profile.ID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, svcName, "failed to check profile permissions")
+	}
+
+	if !manager.Allows(scope) {
+		return nil, NewError(ErrUnauthorized, op, svcName, denied)
+	}
+
+	return manager, nil
+}
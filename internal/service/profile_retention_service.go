@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileRetentionServiceName = "ProfileRetentionService"
+
+	// purgeBatchSize is how many profiles a purge run anonymizes per query
+	purgeBatchSize = 100
+)
+
+// profileRetentionService implements ProfileRetentionService
+type profileRetentionService struct {
+	profileRepo repository.UserProfileRepository
+	managerRepo repository.ProfileManagerRepository
+	erasureRepo repository.AccountErasureRepository
+	profileCfg  config.ProfileConfig
+	logger      *logger.Logger
+}
+
+// NewProfileRetentionService creates a new profile retention service
+func NewProfileRetentionService(
+	profileRepo repository.UserProfileRepository,
+	managerRepo repository.ProfileManagerRepository,
+	erasureRepo repository.AccountErasureRepository,
+	profileCfg config.ProfileConfig,
+	logger *logger.Logger,
+) ProfileRetentionService {
+	return &profileRetentionService{
+		profileRepo: profileRepo,
+		managerRepo: managerRepo,
+		erasureRepo: erasureRepo,
+		profileCfg:  profileCfg,
+		logger:      logger,
+	}
+}
+
+// ListDeletedProfiles lists the profiles of the user's account that were deleted within the restore window
+func (s *profileRetentionService) ListDeletedProfiles(ctx context.Context, userID uuid.UUID) ([]*dto.DeletedProfileResponse, error) {
+	const op = "ListDeletedProfiles"
+
+	profiles, err := s.profileRepo.ListDeletedByUserID(ctx, userID, time.Now().Add(-s.profileCfg.RestoreWindow))
+	if err != nil {
+		s.logger.Error("Failed to list deleted profiles",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileRetentionServiceName, "failed to list deleted profiles")
+	}
+
+	response := make([]*dto.DeletedProfileResponse, len(profiles))
+	for i, profile := range profiles {
+		response[i] = &dto.DeletedProfileResponse{
+			UserProfileResponse: dto.FromModel(profile),
+			DeletedAt:           profile.DeletedAt.Time,
+			RestorableUntil:     profile.DeletedAt.Time.Add(s.profileCfg.RestoreWindow),
+		}
+	}
+
+	return response, nil
+}
+
+// RestoreProfile restores a deleted profile within the restore window. The restored
+// profile counts towards the profiles the account may own again, and can't be restored
+// if the account has since created another profile of its own.
+func (s *profileRetentionService) RestoreProfile(ctx context.Context, userID, profileID uuid.UUID) (*dto.UserProfileResponse, error) {
+	const op = "RestoreProfile"
+
+	profile, err := s.profileRepo.GetDeletedByID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, profileRetentionServiceName,
+				fmt.Sprintf("no deleted profile with ID %s", profileID))
+		}
+
+		s.logger.Error("Failed to get deleted profile",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileRetentionServiceName, "failed to retrieve profile")
+	}
+
+	// The owner or a manager with delete permission can undo a deletion
+	manager, err := authorizeProfileAction(ctx, s.managerRepo, s.logger, op, profileRetentionServiceName,
+		profile, userID, model.ProfileManagerScopeDelete,
+		"you can only restore your own profile or one you manage with delete permission")
+	if err != nil {
+		return nil, err
+	}
+
+	deletedAfter := time.Now().Add(-s.profileCfg.RestoreWindow)
+	if !profile.DeletedAt.Time.After(deletedAfter) {
+		return nil, NewError(ErrValidation, op, profileRetentionServiceName,
+			fmt.Sprintf("profiles can only be restored within %s of being deleted", formatWindow(s.profileCfg.RestoreWindow)))
+	}
+
+	restored, err := s.profileRepo.RestoreWithinLimit(ctx, profile, deletedAfter, s.profileCfg.MaxPerAccount)
+	if err != nil {
+		switch {
+		case isRepoNotFound(err):
+			return nil, NewError(ErrNotFound, op, profileRetentionServiceName, "the profile can no longer be restored")
+		case isRepoDuplicate(err):
+			return nil, NewError(ErrDuplicate, op, profileRetentionServiceName, "the account already has another profile of its own")
+		}
+
+		s.logger.Error("Failed to restore profile",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileRetentionServiceName, "failed to restore profile")
+	}
+	if !restored {
+		return nil, NewError(ErrValidation, op, profileRetentionServiceName,
+			fmt.Sprintf("an account can own at most %d profiles", s.profileCfg.MaxPerAccount))
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_restored", userID.String(), profileID.String(),
+		actorFields(profile, manager)...)
+
+	return dto.FromModel(profile), nil
+}
+
+// PurgeDeletedProfiles anonymizes the profiles deleted longer ago than the retention
+// period, along with their contact details and change history. A profile that fails
+// is logged and retried on the next run.
+func (s *profileRetentionService) PurgeDeletedProfiles(ctx context.Context) error {
+	const op = "PurgeDeletedProfiles"
+
+	deletedBefore := time.Now().Add(-s.profileCfg.Retention)
+	attempted := make(map[uuid.UUID]bool)
+	purged := 0
+
+	for {
+		ids, err := s.profileRepo.ListPurgeable(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return NewError(ErrInternal, op, profileRetentionServiceName, err.Error())
+		}
+
+		pending := 0
+		for _, id := range ids {
+			// Failed profiles stay purgeable, so stop once a batch only returns profiles already tried
+			if attempted[id] {
+				continue
+			}
+			attempted[id] = true
+			pending++
+
+			if err := s.purgeProfile(ctx, id, deletedBefore); err != nil {
+				s.logger.Error("Failed to purge deleted profile",
+					zap.String("profile_id", id.String()),
+					zap.Error(err))
+				continue
+			}
+			purged++
+		}
+
+		if pending == 0 || len(ids) < purgeBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if purged > 0 {
+		s.logger.Info("Purged deleted profiles",
+			zap.Int("profiles", purged),
+			zap.Time("deleted_before", deletedBefore))
+	}
+
+	return ctx.Err()
+}
+
+// purgeProfile anonymizes one profile and erases the records holding its details, the
+// same ones an account erasure does, in a single transaction. A profile restored or
+// purged meanwhile is left as it is.
+func (s *profileRetentionService) purgeProfile(ctx context.Context, profileID uuid.UUID, deletedBefore time.Time) error {
+	err := s.profileRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.profileRepo.Purge(txCtx, profileID, deletedBefore); err != nil {
+			return err
+		}
+		_, err := s.erasureRepo.EraseProfileRecords(txCtx, profileID)
+		return err
+	})
+	if err != nil && isRepoNotFound(err) {
+		return nil
+	}
+	return err
+}
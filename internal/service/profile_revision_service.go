@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/revision"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileRevisionServiceName = "ProfileRevisionService"
+)
+
+// revisionRecorder records the changes made to profiles, flagging updates that change
+// a sensitive field more often than the configured limit
+type revisionRecorder struct {
+	repo   repository.ProfileRevisionRepository
+	cfg    config.ProfileConfig
+	logger *logger.Logger
+}
+
+// newRevisionRecorder creates a revision recorder
+func newRevisionRecorder(
+	repo repository.ProfileRevisionRepository,
+	cfg config.ProfileConfig,
+	logger *logger.Logger,
+) *revisionRecorder {
+	return &revisionRecorder{
+		repo:   repo,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// record stores the difference between two versions of a profile as a revision by the
+// actor. Nothing is recorded when no field changed. It runs in the transaction ctx
+// carries, so that the revision is saved along with the change.
+func (r *revisionRecorder) record(
+	ctx context.Context,
+	rev *model.ProfileRevision,
+	before, after *model.UserProfile,
+) error {
+	changes, err := revision.Diff(before, after)
+	if err != nil {
+		return fmt.Errorf("diff profile: %w", err)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if rev.Changes, err = json.Marshal(changes); err != nil {
+		return fmt.Errorf("serialize profile changes: %w", err)
+	}
+	rev.ProfileID = after.ID
+	rev.RequestID = requestIDFromContext(ctx)
+	rev.CreatedAt = time.Now()
+
+	if rev.Action == model.ProfileRevisionActionUpdate {
+		if err := r.flag(ctx, rev, changes); err != nil {
+			return err
+		}
+	}
+
+	if err := r.repo.Create(ctx, rev); err != nil {
+		return err
+	}
+
+	if rev.Flagged {
+		r.logger.Warn("Suspicious profile change flagged",
+			zap.String("profile_id", rev.ProfileID.String()),
+			zap.String("revision_id", rev.ID.String()),
+			zap.String("actor_id", rev.ActorID.String()),
+			zap.String("reason", rev.FlagReason),
+			zap.String("request_id", rev.RequestID))
+	}
+
+	return nil
+}
+
+// flag flags an update that brings the changes of a sensitive field within the window
+// up to the limit
+func (r *revisionRecorder) flag(ctx context.Context, rev *model.ProfileRevision, changes revision.Changes) error {
+	if r.cfg.SensitiveChangeLimit <= 0 {
+		return nil
+	}
+
+	since := rev.CreatedAt.Add(-r.cfg.SensitiveChangeWindow)
+	for _, field := range revision.SensitiveFields {
+		if _, ok := changes[field]; !ok {
+			continue
+		}
+
+		earlier, err := r.repo.CountFieldUpdates(ctx, rev.ProfileID, field, since)
+		if err != nil {
+			return err
+		}
+
+		// This update is not stored yet, so it is counted on top of the earlier ones
+		if count := earlier + 1; count >= int64(r.cfg.SensitiveChangeLimit) {
+			rev.Flagged = true
+			rev.FlagReason = fmt.Sprintf("%s changed %d times within %s",
+				field, count, formatWindow(r.cfg.SensitiveChangeWindow))
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// formatWindow describes a duration in days when it is a whole number of them
+func formatWindow(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d >= day && d%day == 0 {
+		return fmt.Sprintf("%d days", d/day)
+	}
+	return d.String()
+}
+
+// profileRevisionService implements ProfileRevisionService
+type profileRevisionService struct {
+	revisionRepo repository.ProfileRevisionRepository
+	profileRepo  repository.UserProfileRepository
+	managerRepo  repository.ProfileManagerRepository
+	scorer       CompletenessScorer
+	revisions    *revisionRecorder
+	logger       *logger.Logger
+}
+
+// NewProfileRevisionService creates a new profile revision service
+func NewProfileRevisionService(
+	revisionRepo repository.ProfileRevisionRepository,
+	profileRepo repository.UserProfileRepository,
+	managerRepo repository.ProfileManagerRepository,
+	scorer CompletenessScorer,
+	profileCfg config.ProfileConfig,
+	logger *logger.Logger,
+) ProfileRevisionService {
+	return &profileRevisionService{
+		revisionRepo: revisionRepo,
+		profileRepo:  profileRepo,
+		managerRepo:  managerRepo,
+		scorer:       scorer,
+		revisions:    newRevisionRecorder(revisionRepo, profileCfg, logger),
+		logger:       logger,
+	}
+}
+
+// GetProfileHistory retrieves the changes made to a profile the user owns or manages
+// with edit permission. Flags are left out; they are for moderators.
+func (s *profileRevisionService) GetProfileHistory(
+	ctx context.Context,
+	userID, profileID uuid.UUID,
+	page, limit int,
+) (*dto.ProfileRevisionListResponse, error) {
+	const op = "GetProfileHistory"
+
+	profile, err := s.getProfile(ctx, op, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeProfileAction(ctx, s.managerRepo, s.logger, op, profileRevisionServiceName,
+		profile, userID, model.ProfileManagerScopeEdit,
+		"you can only view the history of your own profile or one you manage with edit permission"); err != nil {
+		return nil, err
+	}
+
+	response, err := s.listRevisions(ctx, op, profileID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, rev := range response.Revisions {
+		rev.Flagged = false
+		rev.FlagReason = ""
+	}
+
+	return response, nil
+}
+
+// GetProfileHistoryAsAdmin retrieves the changes made to any profile, with their flags
+func (s *profileRevisionService) GetProfileHistoryAsAdmin(
+	ctx context.Context,
+	profileID uuid.UUID,
+	page, limit int,
+) (*dto.ProfileRevisionListResponse, error) {
+	const op = "GetProfileHistoryAsAdmin"
+
+	if _, err := s.getProfile(ctx, op, profileID); err != nil {
+		return nil, err
+	}
+
+	return s.listRevisions(ctx, op, profileID, page, limit)
+}
+
+// ListFlaggedRevisions retrieves the changes flagged as suspicious across all profiles
+func (s *profileRevisionService) ListFlaggedRevisions(ctx context.Context, page, limit int) (*dto.ProfileRevisionListResponse, error) {
+	const op = "ListFlaggedRevisions"
+
+	page, limit = revisionPage(page, limit)
+
+	revisions, total, err := s.revisionRepo.ListFlagged(ctx, page, limit)
+	if err != nil {
+		s.logger.Error("Failed to list flagged profile revisions", zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileRevisionServiceName, "failed to list flagged revisions")
+	}
+
+	return s.revisionList(op, revisions, total, page, limit)
+}
+
+// RevertProfile restores a profile to its state after one of its revisions by undoing
+// every later revision, newest first. The revert is itself recorded as a revision, so
+// it can be reverted in turn.
+func (s *profileRevisionService) RevertProfile(
+	ctx context.Context,
+	adminID, profileID, revisionID uuid.UUID,
+) (*dto.UserProfileResponse, error) {
+	const op = "RevertProfile"
+
+	var restored *model.UserProfile
+	err := s.profileRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		current, err := s.getProfile(txCtx, op, profileID)
+		if err != nil {
+			return err
+		}
+
+		target, err := s.revisionRepo.GetByID(txCtx, profileID, revisionID)
+		if err != nil {
+			if isRepoNotFound(err) {
+				return NewError(ErrNotFound, op, profileRevisionServiceName,
+					fmt.Sprintf("profile %s has no revision %s", profileID, revisionID))
+			}
+			return err
+		}
+
+		later, err := s.revisionRepo.ListAfter(txCtx, target)
+		if err != nil {
+			return err
+		}
+
+		profile := *current
+		for _, rev := range later {
+			changes, err := revision.Parse(rev.Changes)
+			if err != nil {
+				return fmt.Errorf("parse revision %s: %w", rev.ID, err)
+			}
+			if err := revision.Undo(&profile, changes); err != nil {
+				return fmt.Errorf("undo revision %s: %w", rev.ID, err)
+			}
+		}
+
+		changes, err := revision.Diff(current, &profile)
+		if err != nil {
+			return fmt.Errorf("diff profile: %w", err)
+		}
+		if len(changes) == 0 {
+			return NewError(ErrValidation, op, profileRevisionServiceName, "the profile already matches this revision")
+		}
+
+		setResidenceLocation(&profile)
+		profile.UpdatedBy = &adminID
+		if score, err := s.scorer.ScoreProfile(txCtx, &profile); err == nil {
+			profile.CompletenessScore = score
+		} else {
+			s.logger.Warn("Failed to score profile completeness",
+				zap.String("profile_id", profileID.String()),
+				zap.Error(err))
+		}
+
+		if err := s.profileRepo.Update(txCtx, &profile); err != nil {
+			return err
+		}
+
+		rev := &model.ProfileRevision{
+			Action:     model.ProfileRevisionActionRevert,
+			ActorID:    adminID,
+			ActorRole:  model.ProfileRevisionActorAdmin,
+			RevertedTo: &target.ID,
+		}
+		if err := s.revisions.record(txCtx, rev, current, &profile); err != nil {
+			return err
+		}
+
+		restored = &profile
+		return nil
+	})
+	if err != nil {
+		var svcErr *ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, svcErr
+		}
+
+		s.logger.Error("Failed to revert profile",
+			zap.String("profile_id", profileID.String()),
+			zap.String("revision_id", revisionID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileRevisionServiceName, "failed to revert profile")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_reverted", adminID.String(), profileID.String(),
+		zap.String("owner_id", restored.UserID.String()),
+		zap.String("acted_as", string(model.ProfileRevisionActorAdmin)),
+		zap.String("reverted_to", revisionID.String()))
+
+	return dto.FromModel(restored), nil
+}
+
+// getProfile retrieves a profile, mapping a missing profile to ErrNotFound
+func (s *profileRevisionService) getProfile(ctx context.Context, op string, profileID uuid.UUID) (*model.UserProfile, error) {
+	profile, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, profileRevisionServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+		}
+
+		s.logger.Error("Failed to get profile",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileRevisionServiceName, "failed to retrieve profile")
+	}
+
+	return profile, nil
+}
+
+// listRevisions retrieves a page of the revisions of a profile
+func (s *profileRevisionService) listRevisions(
+	ctx context.Context,
+	op string,
+	profileID uuid.UUID,
+	page, limit int,
+) (*dto.ProfileRevisionListResponse, error) {
+	page, limit = revisionPage(page, limit)
+
+	revisions, total, err := s.revisionRepo.ListByProfileID(ctx, profileID, page, limit)
+	if err != nil {
+		s.logger.Error("Failed to list profile revisions",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileRevisionServiceName, "failed to list profile history")
+	}
+
+	return s.revisionList(op, revisions, total, page, limit)
+}
+
+// revisionList converts a page of revisions, which fails only if stored changes are corrupt
+func (s *profileRevisionService) revisionList(
+	op string,
+	revisions []*model.ProfileRevision,
+	total int64,
+	page, limit int,
+) (*dto.ProfileRevisionListResponse, error) {
+	response := &dto.ProfileRevisionListResponse{
+		Revisions: make([]*dto.ProfileRevisionResponse, 0, len(revisions)),
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}
+	for _, rev := range revisions {
+		item, err := dto.ProfileRevisionFromModel(rev)
+		if err != nil {
+			s.logger.Error("Failed to decode profile revision",
+				zap.String("revision_id", rev.ID.String()),
+				zap.Error(err))
+			return nil, NewError(ErrInternal, op, profileRevisionServiceName, "failed to read profile history")
+		}
+		response.Revisions = append(response.Revisions, item)
+	}
+
+	return response, nil
+}
+
+// revisionPage applies the default page and page size
+func revisionPage(page, limit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	return page, limit
+}
@@ -0,0 +1,494 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/biodata"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/photo"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/hijri"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/sharetoken"
+	"go.uber.org/zap"
+)
+
+const (
+	profileShareServiceName = "ProfileShareService"
+)
+
+// profileShareService implements ProfileShareService
+type profileShareService struct {
+	privacyRepo repository.ProfilePrivacyRepository
+	linkRepo    repository.ProfileShareLinkRepository
+	profileRepo repository.UserProfileRepository
+	blockRepo   repository.ProfileBlockRepository
+	photos      photo.Store
+	signer      *sharetoken.Signer
+	cfg         config.ShareConfig
+	logger      *logger.Logger
+}
+
+// NewProfileShareService creates a new profile share service
+func NewProfileShareService(
+	privacyRepo repository.ProfilePrivacyRepository,
+	linkRepo repository.ProfileShareLinkRepository,
+	profileRepo repository.UserProfileRepository,
+	blockRepo repository.ProfileBlockRepository,
+	photos photo.Store,
+	cfg config.ShareConfig,
+	logger *logger.Logger,
+) ProfileShareService {
+	return &profileShareService{
+		privacyRepo: privacyRepo,
+		linkRepo:    linkRepo,
+		profileRepo: profileRepo,
+		blockRepo:   blockRepo,
+		photos:      photos,
+		signer:      sharetoken.NewSigner(cfg.Secret),
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+// GetPrivacySettings retrieves what the user's profile shows on its biodata and share links
+func (s *profileShareService) GetPrivacySettings(ctx context.Context, actor Actor) (*dto.ProfilePrivacyResponse, error) {
+	const op = "GetPrivacySettings"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileShareServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getPrivacySettings(ctx, op, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ProfilePrivacyFromModel(settings), nil
+}
+
+// UpdatePrivacySettings changes what the user's profile shows. Share links minted
+// earlier keep the snapshot they were minted with; revoking them withdraws it.
+func (s *profileShareService) UpdatePrivacySettings(
+	ctx context.Context,
+	actor Actor,
+	req *dto.UpdateProfilePrivacyRequest,
+) (*dto.ProfilePrivacyResponse, error) {
+	const op = "UpdatePrivacySettings"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileShareServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getPrivacySettings(ctx, op, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Apply(settings)
+	settings.UpdatedBy = &actor.UserID
+
+	if err := s.privacyRepo.Upsert(ctx, settings); err != nil {
+		s.logger.Error("Failed to update privacy settings",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileShareServiceName, "failed to update privacy settings")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_privacy_updated", actor.UserID.String(), profile.ID.String(),
+		zap.Bool("show_photo", settings.ShowPhoto),
+		zap.Bool("show_date_of_birth", settings.ShowDateOfBirth))
+
+	return dto.ProfilePrivacyFromModel(settings), nil
+}
+
+// GetBiodata renders the printable biodata of a profile. The owner gets the same sheet
+// as anyone else, so it can be checked before being handed out.
+func (s *profileShareService) GetBiodata(ctx context.Context, profileID uuid.UUID, actor Actor) (*dto.BiodataDocument, error) {
+	const op = "GetBiodata"
+
+	profile, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, profileShareServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+		}
+
+		s.logger.Error("Failed to get profile for biodata",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileShareServiceName, "failed to retrieve profile")
+	}
+
+	// Blocked members can't get the biodata any more than they can view the profile
+	if err := hideIfBlocked(ctx, s.blockRepo, s.logger, op, profileShareServiceName, actor.UserID, profile); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getPrivacySettings(ctx, op, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := s.renderBiodata(ctx, op, profile.ID, dto.ProfileSnapshotFromModel(profile, settings, time.Now().UTC()))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_biodata_downloaded", actor.UserID.String(), profile.ID.String(),
+		zap.String("owner_id", profile.UserID.String()))
+
+	return document, nil
+}
+
+// CreateShareLink snapshots the user's profile as its privacy settings allow and returns
+// a signed link to the snapshot that works without an account until it expires or is revoked
+func (s *profileShareService) CreateShareLink(
+	ctx context.Context,
+	actor Actor,
+	req *dto.CreateShareLinkRequest,
+) (*dto.ShareLinkCreatedResponse, error) {
+	const op = "CreateShareLink"
+
+	ttl := s.cfg.LinkTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > s.cfg.MaxLinkTTL {
+		return nil, NewValidationError(op, profileShareServiceName, []ValidationError{{
+			Field:   "expires_in_hours",
+			Message: fmt.Sprintf("Share links can last at most %d hours", int(s.cfg.MaxLinkTTL.Hours())),
+		}})
+	}
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileShareServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getPrivacySettings(ctx, op, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	snapshot, err := json.Marshal(dto.ProfileSnapshotFromModel(profile, settings, now))
+	if err != nil {
+		s.logger.Error("Failed to encode profile snapshot",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileShareServiceName, "failed to create share link")
+	}
+
+	link := &model.ProfileShareLink{
+		ProfileID: profile.ID,
+		CreatedBy: actor.UserID,
+		Label:     req.Label,
+		Snapshot:  snapshot,
+		// Tokens carry the expiry in whole seconds, so the stored expiry matches it exactly
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+		CreatedAt: now,
+	}
+
+	if err := s.linkRepo.Create(ctx, link); err != nil {
+		s.logger.Error("Failed to create share link",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileShareServiceName, "failed to create share link")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_share_link_created", actor.UserID.String(), profile.ID.String(),
+		zap.String("share_link_id", link.ID.String()),
+		zap.Time("expires_at", link.ExpiresAt))
+
+	return &dto.ShareLinkCreatedResponse{
+		ShareLinkResponse: dto.ShareLinkFromModel(link, now),
+		Token:             s.signer.Sign(link.ID, link.ExpiresAt),
+	}, nil
+}
+
+// ListShareLinks lists the share links of the user's profile with their view counts
+func (s *profileShareService) ListShareLinks(ctx context.Context, actor Actor) ([]*dto.ShareLinkResponse, error) {
+	const op = "ListShareLinks"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileShareServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.ListByProfileID(ctx, profile.ID)
+	if err != nil {
+		s.logger.Error("Failed to list share links",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileShareServiceName, "failed to list share links")
+	}
+
+	now := time.Now().UTC()
+	response := make([]*dto.ShareLinkResponse, len(links))
+	for i, link := range links {
+		response[i] = dto.ShareLinkFromModel(link, now)
+	}
+
+	return response, nil
+}
+
+// RevokeShareLink disables a share link of a profile owned by the user
+func (s *profileShareService) RevokeShareLink(ctx context.Context, userID uuid.UUID, linkID uuid.UUID) error {
+	const op = "RevokeShareLink"
+
+	notFound := NewError(ErrNotFound, op, profileShareServiceName, fmt.Sprintf("share link with ID %s not found", linkID))
+
+	link, err := s.linkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return notFound
+		}
+
+		s.logger.Error("Failed to get share link",
+			zap.String("share_link_id", linkID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileShareServiceName, "failed to retrieve share link")
+	}
+
+	profile, err := s.profileRepo.GetByID(ctx, link.ProfileID)
+	if err != nil && !isRepoNotFound(err) {
+		s.logger.Error("Failed to get profile of share link",
+			zap.String("share_link_id", linkID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileShareServiceName, "failed to retrieve share link")
+	}
+	if err != nil || profile.UserID != userID {
+		return notFound
+	}
+
+	if err := s.linkRepo.Revoke(ctx, link.ID, userID, time.Now().UTC()); err != nil {
+		if isRepoInvalidOperation(err) {
+			return NewError(ErrValidation, op, profileShareServiceName, "the share link is already revoked")
+		}
+
+		s.logger.Error("Failed to revoke share link",
+			zap.String("share_link_id", linkID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileShareServiceName, "failed to revoke share link")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_share_link_revoked", userID.String(), profile.ID.String(),
+		zap.String("share_link_id", link.ID.String()),
+		zap.Int64("view_count", link.ViewCount))
+
+	return nil
+}
+
+// GetSharedProfile returns the snapshot behind a share link token, counting the view
+func (s *profileShareService) GetSharedProfile(ctx context.Context, token string) (*dto.SharedProfileResponse, error) {
+	const op = "GetSharedProfile"
+
+	link, snapshot, err := s.openShareLink(ctx, op, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SharedProfileResponse{
+		Profile:   snapshot,
+		ExpiresAt: link.ExpiresAt,
+	}, nil
+}
+
+// GetSharedBiodata renders the biodata of the snapshot behind a share link token, counting the view
+func (s *profileShareService) GetSharedBiodata(ctx context.Context, token string) (*dto.BiodataDocument, error) {
+	const op = "GetSharedBiodata"
+
+	link, snapshot, err := s.openShareLink(ctx, op, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderBiodata(ctx, op, link.ProfileID, snapshot)
+}
+
+// openShareLink verifies a share link token, counts the view and decodes the link's snapshot.
+// Forged, expired and revoked links are all reported as not found.
+func (s *profileShareService) openShareLink(
+	ctx context.Context,
+	op, token string,
+) (*model.ProfileShareLink, *dto.ProfileSnapshot, error) {
+	notFound := NewError(ErrNotFound, op, profileShareServiceName, "the share link is invalid, expired or revoked")
+
+	now := time.Now().UTC()
+	linkID, err := s.signer.Verify(token, now)
+	if err != nil {
+		return nil, nil, notFound
+	}
+
+	link, err := s.linkRepo.RecordView(ctx, linkID, now)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, nil, notFound
+		}
+
+		s.logger.Error("Failed to record share link view",
+			zap.String("share_link_id", linkID.String()),
+			zap.Error(err))
+		return nil, nil, NewError(ErrInternal, op, profileShareServiceName, "failed to open share link")
+	}
+
+	var snapshot dto.ProfileSnapshot
+	if err := json.Unmarshal(link.Snapshot, &snapshot); err != nil {
+		s.logger.Error("Failed to decode profile snapshot",
+			zap.String("share_link_id", link.ID.String()),
+			zap.Error(err))
+		return nil, nil, NewError(ErrInternal, op, profileShareServiceName, "failed to open share link")
+	}
+
+	s.logger.Info("Share link viewed",
+		zap.String("share_link_id", link.ID.String()),
+		zap.String("profile_id", link.ProfileID.String()),
+		zap.Int64("view_count", link.ViewCount),
+		zap.String("request_id", requestIDFromContext(ctx)))
+
+	return link, &snapshot, nil
+}
+
+// getPrivacySettings retrieves the privacy settings of a profile
+func (s *profileShareService) getPrivacySettings(ctx context.Context, op string, profileID uuid.UUID) (*model.ProfilePrivacySettings, error) {
+	settings, err := s.privacyRepo.GetByProfileID(ctx, profileID)
+	if err != nil {
+		s.logger.Error("Failed to get privacy settings",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileShareServiceName, "failed to retrieve privacy settings")
+	}
+
+	return settings, nil
+}
+
+// renderBiodata renders a snapshot as a PDF, with the profile's current photo if the
+// snapshot shows it. A missing or unusable photo leaves the sheet without one.
+func (s *profileShareService) renderBiodata(
+	ctx context.Context,
+	op string,
+	profileID uuid.UUID,
+	snapshot *dto.ProfileSnapshot,
+) (*dto.BiodataDocument, error) {
+	var picture []byte
+	if snapshot.ShowPhoto {
+		var err error
+		picture, err = s.photos.PrimaryPhoto(ctx, profileID)
+		if err != nil && !errors.Is(err, photo.ErrNotFound) {
+			s.logger.Warn("Failed to get profile photo for biodata",
+				zap.String("profile_id", profileID.String()),
+				zap.Error(err))
+		}
+	}
+
+	sheet := biodataSheet(snapshot, picture)
+
+	var buf bytes.Buffer
+	err := biodata.Render(&buf, sheet)
+	if errors.Is(err, biodata.ErrUnsupportedPhoto) {
+		s.logger.Warn("Profile photo can't be placed on biodata",
+			zap.String("profile_id", profileID.String()))
+
+		sheet.Photo = nil
+		buf.Reset()
+		err = biodata.Render(&buf, sheet)
+	}
+	if err != nil {
+		s.logger.Error("Failed to render biodata",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileShareServiceName, "failed to render biodata")
+	}
+
+	return &dto.BiodataDocument{
+		Filename: fmt.Sprintf("biodata-%s.pdf", snapshot.ProfileCode),
+		Content:  buf.Bytes(),
+	}, nil
+}
+
+// biodataSheet lays out a profile snapshot in the sections of a traditional biodata
+func biodataSheet(snapshot *dto.ProfileSnapshot, picture []byte) *biodata.Sheet {
+	lookingFor := "Bride"
+	if snapshot.IsGroom {
+		lookingFor = "Groom"
+	}
+
+	personal := []biodata.Field{
+		{Label: "Profile For", Value: lookingFor},
+		{Label: "Created By", Value: snapshot.ProfileCreatedBy},
+		{Label: "Age", Value: fmt.Sprintf("%d years", snapshot.Age)},
+	}
+	if snapshot.DateOfBirth != "" {
+		if dob, err := time.Parse("2006-01-02", snapshot.DateOfBirth); err == nil {
+			value := dob.Format("2 January 2006")
+			// Families who keep the Hijri date see it first
+			if date, err := hijri.Parse(snapshot.DateOfBirthHijri); err == nil {
+				value = fmt.Sprintf("%s (%s)", date.Format(), value)
+			}
+			personal = append(personal, biodata.Field{Label: "Date of Birth", Value: value})
+		}
+	}
+	personal = append(personal,
+		biodata.Field{Label: "Height", Value: strconv.FormatFloat(snapshot.Height, 'f', -1, 64) + " cm"},
+		biodata.Field{Label: "Weight", Value: strconv.FormatFloat(snapshot.Weight, 'f', -1, 64) + " kg"},
+		biodata.Field{Label: "Marital Status", Value: snapshot.MaritalStatus},
+		biodata.Field{Label: "Physically Challenged", Value: yesNo(snapshot.IsPhysicallyChallenged)},
+	)
+
+	location := []biodata.Field{
+		{Label: "Nationality", Value: snapshot.Nationality},
+		{Label: "Home District", Value: snapshot.HomeDistrict},
+	}
+	if snapshot.ResidenceCity != "" {
+		location = append(location, biodata.Field{Label: "Residing In", Value: snapshot.ResidenceCity})
+	}
+
+	sections := []biodata.Section{
+		{Title: "Personal Details", Fields: personal},
+		{Title: "Religious Background", Fields: []biodata.Field{{Label: "Community", Value: snapshot.Community}}},
+		{Title: "Location", Fields: location},
+	}
+
+	var career []biodata.Field
+	if snapshot.Education != "" {
+		career = append(career, biodata.Field{Label: "Education", Value: snapshot.Education})
+	}
+	if snapshot.Occupation != "" {
+		career = append(career, biodata.Field{Label: "Occupation", Value: snapshot.Occupation})
+	}
+	if len(career) > 0 {
+		sections = append(sections, biodata.Section{Title: "Education & Career", Fields: career})
+	}
+
+	if snapshot.AboutMe != "" {
+		sections = append(sections, biodata.Section{Title: "About", Text: snapshot.AboutMe})
+	}
+
+	return &biodata.Sheet{
+		Title:    snapshot.Name,
+		Subtitle: "Profile " + snapshot.ProfileCode,
+		Photo:    picture,
+		Sections: sections,
+		Footer: fmt.Sprintf("Qubool Kallyaanam biodata as of %s. Contact details are shared with members only.",
+			snapshot.TakenAt.Format("2 January 2006")),
+	}
+}
+
+// yesNo spells out a boolean for printed documents
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
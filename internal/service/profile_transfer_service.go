@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileTransferServiceName = "ProfileTransferService"
+
+	// transferTokenBytes is the amount of randomness in a transfer token
+	transferTokenBytes = 32
+)
+
+// profileTransferService implements ProfileTransferService
+type profileTransferService struct {
+	transferRepo repository.ProfileTransferRepository
+	profileRepo  repository.UserProfileRepository
+	managerRepo  repository.ProfileManagerRepository
+	cfg          config.ProfileConfig
+	logger       *logger.Logger
+}
+
+// NewProfileTransferService creates a new profile transfer service
+func NewProfileTransferService(
+	transferRepo repository.ProfileTransferRepository,
+	profileRepo repository.UserProfileRepository,
+	managerRepo repository.ProfileManagerRepository,
+	cfg config.ProfileConfig,
+	logger *logger.Logger,
+) ProfileTransferService {
+	return &profileTransferService{
+		transferRepo: transferRepo,
+		profileRepo:  profileRepo,
+		managerRepo:  managerRepo,
+		cfg:          cfg,
+		logger:       logger,
+	}
+}
+
+// InitiateTransfer offers the actor's profile to another account
+func (s *profileTransferService) InitiateTransfer(
+	ctx context.Context,
+	actor Actor,
+	req *dto.InitiateProfileTransferRequest,
+) (*dto.ProfileTransferCreatedResponse, error) {
+	const op = "InitiateTransfer"
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileTransferServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserID == actor.UserID {
+		return nil, NewError(ErrValidation, op, profileTransferServiceName, "the profile already belongs to your account")
+	}
+
+	token, tokenHash, err := newTransferToken()
+	if err != nil {
+		s.logger.Error("Failed to generate transfer token", zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileTransferServiceName, "failed to start transfer")
+	}
+
+	now := time.Now().UTC()
+	transfer := &model.ProfileTransfer{
+		ProfileID:        profile.ID,
+		FromUserID:       actor.UserID,
+		ToUserID:         req.UserID,
+		TokenHash:        tokenHash,
+		ProfileCreatedBy: profile.ProfileCreatedBy,
+		Status:           model.ProfileTransferStatusPending,
+		ExpiresAt:        now.Add(s.cfg.TransferTTL),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := s.transferRepo.Create(ctx, transfer); err != nil {
+		if isRepoDuplicate(err) {
+			return nil, NewError(ErrDuplicate, op, profileTransferServiceName, "the profile already has a pending transfer; cancel it first")
+		}
+
+		s.logger.Error("Failed to create profile transfer",
+			zap.String("profile_id", profile.ID.String()),
+			zap.String("to_user_id", req.UserID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileTransferServiceName, "failed to start transfer")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_transfer_initiated", actor.UserID.String(), profile.ID.String(),
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("to_user_id", req.UserID.String()),
+		zap.Time("expires_at", transfer.ExpiresAt))
+
+	return &dto.ProfileTransferCreatedResponse{
+		ProfileTransferResponse: dto.ProfileTransferFromModel(transfer),
+		Token:                   token,
+	}, nil
+}
+
+// ListTransfers lists the pending transfers the user has offered or been offered
+func (s *profileTransferService) ListTransfers(ctx context.Context, userID uuid.UUID) ([]*dto.ProfileTransferResponse, error) {
+	const op = "ListTransfers"
+
+	transfers, err := s.transferRepo.ListPendingByUserID(ctx, userID, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("Failed to list profile transfers",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileTransferServiceName, "failed to list transfers")
+	}
+
+	response := make([]*dto.ProfileTransferResponse, len(transfers))
+	for i, transfer := range transfers {
+		response[i] = dto.ProfileTransferFromModel(transfer)
+	}
+
+	return response, nil
+}
+
+// CancelTransfer withdraws a transfer by the current owner, or declines it by the receiving user
+func (s *profileTransferService) CancelTransfer(ctx context.Context, userID uuid.UUID, transferID uuid.UUID) error {
+	const op = "CancelTransfer"
+
+	notFound := NewError(ErrNotFound, op, profileTransferServiceName, fmt.Sprintf("transfer with ID %s not found", transferID))
+
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return notFound
+		}
+
+		s.logger.Error("Failed to get profile transfer",
+			zap.String("transfer_id", transferID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileTransferServiceName, "failed to retrieve transfer")
+	}
+	if transfer.FromUserID != userID && transfer.ToUserID != userID {
+		return notFound
+	}
+
+	if err := s.transferRepo.Cancel(ctx, transfer.ID, userID, time.Now().UTC()); err != nil {
+		if isRepoInvalidOperation(err) {
+			return NewError(ErrValidation, op, profileTransferServiceName, "the transfer is no longer pending")
+		}
+
+		s.logger.Error("Failed to cancel profile transfer",
+			zap.String("transfer_id", transferID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, profileTransferServiceName, "failed to cancel transfer")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_transfer_cancelled", userID.String(), transfer.ProfileID.String(),
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("from_user_id", transfer.FromUserID.String()),
+		zap.String("to_user_id", transfer.ToUserID.String()))
+
+	return nil
+}
+
+// AcceptTransfer makes the user's account the owner of the profile offered with the token.
+// Accepting the transfer, re-pointing the profile and revoking the delegations granted by
+// the previous owner happen in one transaction.
+func (s *profileTransferService) AcceptTransfer(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *dto.AcceptProfileTransferRequest,
+) (*dto.UserProfileResponse, error) {
+	const op = "AcceptTransfer"
+
+	notFound := NewError(ErrNotFound, op, profileTransferServiceName, "no transfer to your account matches the token")
+
+	transfer, err := s.transferRepo.GetByTokenHash(ctx, hashTransferToken(req.Token))
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, notFound
+		}
+
+		s.logger.Error("Failed to get profile transfer by token",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileTransferServiceName, "failed to retrieve transfer")
+	}
+	if transfer.ToUserID != userID {
+		return nil, notFound
+	}
+
+	acceptedAt := time.Now().UTC()
+	if !transfer.IsOpen(acceptedAt) {
+		return nil, NewError(ErrValidation, op, profileTransferServiceName, "the transfer is no longer pending or has expired")
+	}
+
+	var profile *model.UserProfile
+	var revoked int64
+	err = s.profileRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.transferRepo.Accept(txCtx, transfer.ID, acceptedAt, requestIDFromContext(ctx)); err != nil {
+			if isRepoInvalidOperation(err) {
+				return NewError(ErrValidation, op, profileTransferServiceName, "the transfer is no longer pending or has expired")
+			}
+			return err
+		}
+
+		transferred, err := s.profileRepo.Transfer(txCtx, transfer.ProfileID, transfer.FromUserID, userID, s.cfg.MaxPerAccount)
+		if err != nil {
+			if isRepoInvalidOperation(err) {
+				return NewError(ErrValidation, op, profileTransferServiceName, "the profile is no longer available from the account that offered it")
+			}
+			if isRepoDuplicate(err) {
+				return NewError(ErrDuplicate, op, profileTransferServiceName, "your account already has a profile of your own")
+			}
+			return err
+		}
+		if !transferred {
+			return NewError(ErrValidation, op, profileTransferServiceName,
+				fmt.Sprintf("an account can own at most %d profiles", s.cfg.MaxPerAccount))
+		}
+
+		// Delegations were granted by the previous owner; the new owner invites their own
+		if revoked, err = s.managerRepo.RevokeAllForProfile(txCtx, transfer.ProfileID, acceptedAt); err != nil {
+			return err
+		}
+
+		profile, err = s.profileRepo.GetByID(txCtx, transfer.ProfileID)
+		return err
+	})
+	if err != nil {
+		var svcErr *ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, svcErr
+		}
+
+		s.logger.Error("Failed to accept profile transfer",
+			zap.String("transfer_id", transfer.ID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileTransferServiceName, "failed to accept transfer")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_transferred", userID.String(), profile.ID.String(),
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("from_user_id", transfer.FromUserID.String()),
+		zap.String("profile_created_by", string(profile.ProfileCreatedBy)),
+		zap.Int64("revoked_managers", revoked))
+
+	return dto.FromModel(profile), nil
+}
+
+// newTransferToken returns a random one-time transfer token and the hash stored in its place
+func newTransferToken() (string, string, error) {
+	buf := make([]byte, transferTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashTransferToken(token), nil
+}
+
+// hashTransferToken returns the hex-encoded SHA-256 of a transfer token
+func hashTransferToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	profileViewServiceName = "ProfileViewService"
+)
+
+// profileViewService implements ProfileViewService
+type profileViewService struct {
+	viewRepo    repository.ProfileViewRepository
+	profileRepo repository.UserProfileRepository
+	logger      *logger.Logger
+}
+
+// NewProfileViewService creates a new profile view service
+func NewProfileViewService(
+	viewRepo repository.ProfileViewRepository,
+	profileRepo repository.UserProfileRepository,
+	logger *logger.Logger,
+) ProfileViewService {
+	return &profileViewService{
+		viewRepo:    viewRepo,
+		profileRepo: profileRepo,
+		logger:      logger,
+	}
+}
+
+// ListProfileViewers lists who viewed the user's profile, most recent first. Access
+// depends on the user's plan and is enforced by the route with middleware.RequireFeature.
+func (s *profileViewService) ListProfileViewers(ctx context.Context, actor Actor, page, limit int) (*dto.ProfileViewerListResponse, error) {
+	const op = "ListProfileViewers"
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, profileViewServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	views, total, err := s.viewRepo.ListViewers(ctx, profile.ID, page, limit)
+	if err != nil {
+		s.logger.Error("Failed to list profile viewers",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, profileViewServiceName, "failed to list profile viewers")
+	}
+
+	response := &dto.ProfileViewerListResponse{
+		Viewers: make([]*dto.ProfileViewerResponse, 0, len(views)),
+		Total:   total,
+		Page:    page,
+		Limit:   limit,
+	}
+	for _, view := range views {
+		if view.Viewer == nil {
+			continue
+		}
+		response.Viewers = append(response.Viewers, dto.ProfileViewerFromModel(view))
+	}
+
+	return response, nil
+}
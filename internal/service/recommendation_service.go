@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/geo"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	recommendationServiceName = "RecommendationService"
+
+	// recommendationBatchSize is the number of profiles loaded per batch when generating recommendations
+	recommendationBatchSize = 100
+)
+
+// Weights of the signals that rank candidates. Preferences of the viewing profile are
+// applied as hard filters; everything here only orders the remaining candidates.
+const (
+	scoreMutualMatch   = 3.0 // the candidate's own preferences accept the viewer
+	scoreNoPreferences = 1.0 // the candidate hasn't set preferences
+	scoreSameDistrict  = 1.0
+	scoreSameCommunity = 1.0
+	scoreNearby        = 1.0 // both reside within nearbyKm of each other
+	scoreAgeCloseness  = 1.0 // scaled down linearly to zero at ageGapLimit years
+	scoreNewProfile    = 0.5 // the candidate joined within newProfileAge
+
+	nearbyKm      = 100
+	ageGapLimit   = 10
+	newProfileAge = 30 * 24 * time.Hour
+)
+
+// recommendationService implements RecommendationService
+type recommendationService struct {
+	recommendationRepo repository.RecommendationRepository
+	preferenceRepo     repository.PartnerPreferenceRepository
+	profileRepo        repository.UserProfileRepository
+	cfg                config.MatchingConfig
+	logger             *logger.Logger
+}
+
+// NewRecommendationService creates a new recommendation service
+func NewRecommendationService(
+	recommendationRepo repository.RecommendationRepository,
+	preferenceRepo repository.PartnerPreferenceRepository,
+	profileRepo repository.UserProfileRepository,
+	cfg config.MatchingConfig,
+	logger *logger.Logger,
+) RecommendationService {
+	return &recommendationService{
+		recommendationRepo: recommendationRepo,
+		preferenceRepo:     preferenceRepo,
+		profileRepo:        profileRepo,
+		cfg:                cfg,
+		logger:             logger,
+	}
+}
+
+// ListRecommendations lists the latest recommendations for the user's profile
+func (s *recommendationService) ListRecommendations(
+	ctx context.Context,
+	actor Actor,
+	page,
+	limit int,
+) (*dto.RecommendationListResponse, error) {
+	const op = "ListRecommendations"
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, recommendationServiceName, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations, total, err := s.recommendationRepo.ListLatest(ctx, profile.ID, page, limit)
+	if err != nil {
+		s.logger.Error("Failed to list recommendations",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, recommendationServiceName, "failed to list recommendations")
+	}
+
+	response := &dto.RecommendationListResponse{
+		Recommendations: make([]*dto.RecommendationResponse, 0, len(recommendations)),
+		Total:           total,
+		Page:            page,
+		Limit:           limit,
+	}
+	for _, recommendation := range recommendations {
+		if recommendation.Candidate == nil {
+			continue
+		}
+		response.Recommendations = append(response.Recommendations, dto.RecommendationFromModel(recommendation))
+		response.GeneratedOn = recommendation.GeneratedOn.Format("2006-01-02")
+	}
+
+	return response, nil
+}
+
+// GiveFeedback records a skip or not-interested action on a recommendation. Skipped
+// profiles return after the skip cooldown, not-interested ones never.
+func (s *recommendationService) GiveFeedback(
+	ctx context.Context,
+	actor Actor,
+	recommendationID uuid.UUID,
+	req *dto.RecommendationFeedbackRequest,
+) error {
+	const op = "GiveFeedback"
+
+	var status model.RecommendationStatus
+	switch req.Action {
+	case "skip":
+		status = model.RecommendationStatusSkipped
+	case "not_interested":
+		status = model.RecommendationStatusNotInterested
+	default:
+		return NewError(ErrValidation, op, recommendationServiceName, fmt.Sprintf("unknown action %q", req.Action))
+	}
+
+	profile, err := getActingProfile(ctx, s.profileRepo, s.logger, op, recommendationServiceName, actor)
+	if err != nil {
+		return err
+	}
+
+	recommendation, err := s.recommendationRepo.GetByID(ctx, recommendationID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return NewError(ErrNotFound, op, recommendationServiceName, fmt.Sprintf("recommendation with ID %s not found", recommendationID))
+		}
+
+		s.logger.Error("Failed to get recommendation",
+			zap.String("recommendation_id", recommendationID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, recommendationServiceName, "failed to retrieve recommendation")
+	}
+
+	if recommendation.ProfileID != profile.ID {
+		return NewError(ErrNotFound, op, recommendationServiceName, fmt.Sprintf("recommendation with ID %s not found", recommendationID))
+	}
+
+	if err := s.recommendationRepo.UpdateStatus(ctx, recommendationID, status); err != nil {
+		s.logger.Error("Failed to record recommendation feedback",
+			zap.String("recommendation_id", recommendationID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, recommendationServiceName, "failed to record feedback")
+	}
+
+	s.logger.UserProfileEvent(ctx, "recommendation_feedback", actor.UserID.String(), profile.ID.String(),
+		zap.String("candidate_id", recommendation.CandidateID.String()),
+		zap.String("status", string(status)))
+
+	return nil
+}
+
+// GenerateRecommendations computes the day's recommendations for every profile that
+// doesn't have them yet. Runs are stored per day, so repeated runs are cheap.
+func (s *recommendationService) GenerateRecommendations(ctx context.Context) error {
+	const op = "GenerateRecommendations"
+
+	now := time.Now()
+	day := recommendationDay(now)
+	afterID := uuid.Nil
+	generated := 0
+
+	for {
+		profiles, err := s.recommendationRepo.ListProfilesDue(ctx, day, afterID, recommendationBatchSize)
+		if err != nil {
+			return NewError(ErrInternal, op, recommendationServiceName, err.Error())
+		}
+
+		for _, profile := range profiles {
+			afterID = profile.ID
+			if err := s.generateForProfile(ctx, profile, day, now); err != nil {
+				s.logger.Error("Failed to generate recommendations",
+					zap.String("profile_id", profile.ID.String()),
+					zap.Error(err))
+				continue
+			}
+			generated++
+		}
+
+		if len(profiles) < recommendationBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if generated > 0 {
+		s.logger.Info("Recommendations generated",
+			zap.String("day", day.Format("2006-01-02")),
+			zap.Int("profiles", generated))
+	}
+
+	return ctx.Err()
+}
+
+// generateForProfile ranks the candidates for one profile and stores the top ones
+func (s *recommendationService) generateForProfile(ctx context.Context, profile *model.UserProfile, day, now time.Time) error {
+	filter, err := s.loadPreferenceFilter(ctx, profile.ID)
+	if err != nil {
+		return err
+	}
+
+	isGroom := !profile.IsGroom
+	filter.IsGroom = &isGroom
+
+	candidates, err := s.recommendationRepo.FindCandidates(ctx, profile.ID, filter, repository.CandidateOptions{
+		RepeatSince:  day.Add(-s.cfg.RepeatAfter),
+		SkippedSince: day.Add(-s.cfg.SkipCooldown),
+		Limit:        s.cfg.CandidatePoolSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	candidateIDs := make([]uuid.UUID, len(candidates))
+	for i, candidate := range candidates {
+		candidateIDs[i] = candidate.ID
+	}
+
+	preferences, err := s.preferenceRepo.ListByProfileIDs(ctx, candidateIDs)
+	if err != nil {
+		return err
+	}
+
+	candidateFilters := make(map[uuid.UUID]repository.ProfileFilter, len(preferences))
+	for _, preference := range preferences {
+		var f repository.ProfileFilter
+		if err := json.Unmarshal(preference.Criteria, &f); err != nil {
+			return err
+		}
+		candidateFilters[preference.ProfileID] = f
+	}
+
+	type scored struct {
+		candidate *model.UserProfile
+		score     float64
+	}
+
+	ranked := make([]scored, len(candidates))
+	for i, candidate := range candidates {
+		f, hasPreferences := candidateFilters[candidate.ID]
+		var candidateFilter *repository.ProfileFilter
+		if hasPreferences {
+			candidateFilter = &f
+		}
+		ranked[i] = scored{candidate: candidate, score: scoreCandidate(profile, candidate, candidateFilter, now)}
+	}
+
+	// Candidates arrive newest first, so a stable sort keeps newer profiles ahead on equal scores
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	if len(ranked) > s.cfg.DailyRecommendations {
+		ranked = ranked[:s.cfg.DailyRecommendations]
+	}
+
+	recommendations := make([]*model.Recommendation, len(ranked))
+	for i, r := range ranked {
+		recommendations[i] = &model.Recommendation{
+			ProfileID:   profile.ID,
+			CandidateID: r.candidate.ID,
+			GeneratedOn: day,
+			Rank:        i + 1,
+			Score:       math.Round(r.score*100) / 100,
+			Status:      model.RecommendationStatusNew,
+		}
+	}
+
+	_, err = s.recommendationRepo.SaveRun(ctx, &model.RecommendationRun{
+		ProfileID:   profile.ID,
+		GeneratedOn: day,
+		Candidates:  len(recommendations),
+	}, recommendations)
+	return err
+}
+
+// loadPreferenceFilter returns the partner preferences of a profile as a search filter,
+// or an empty filter when the profile has none
+func (s *recommendationService) loadPreferenceFilter(ctx context.Context, profileID uuid.UUID) (repository.ProfileFilter, error) {
+	var filter repository.ProfileFilter
+
+	preference, err := s.preferenceRepo.GetByProfileID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return filter, nil
+		}
+		return filter, err
+	}
+
+	err = json.Unmarshal(preference.Criteria, &filter)
+	return filter, err
+}
+
+// recommendationDay returns the calendar day recommendations generated at now belong to
+func recommendationDay(now time.Time) time.Time {
+	year, month, day := now.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// scoreCandidate rates how good a match a candidate is for the viewing profile.
+// candidateFilter holds the candidate's own preferences, nil if they have none.
+func scoreCandidate(viewer, candidate *model.UserProfile, candidateFilter *repository.ProfileFilter, now time.Time) float64 {
+	score := 0.0
+
+	if candidateFilter == nil {
+		score += scoreNoPreferences
+	} else if matchesPreferences(*candidateFilter, viewer) {
+		score += scoreMutualMatch
+	}
+
+	if viewer.HomeDistrict == candidate.HomeDistrict {
+		score += scoreSameDistrict
+	}
+
+	if viewer.Community == candidate.Community {
+		score += scoreSameCommunity
+	}
+
+	if viewer.Latitude != nil && viewer.Longitude != nil && candidate.Latitude != nil && candidate.Longitude != nil {
+		distance := geo.DistanceKm(
+			geo.Point{Lat: *viewer.Latitude, Lng: *viewer.Longitude},
+			geo.Point{Lat: *candidate.Latitude, Lng: *candidate.Longitude})
+		if distance <= nearbyKm {
+			score += scoreNearby
+		}
+	}
+
+	gap := math.Abs(float64(viewer.Age() - candidate.Age()))
+	if gap < ageGapLimit {
+		score += scoreAgeCloseness * (1 - gap/ageGapLimit)
+	}
+
+	if now.Sub(candidate.CreatedAt) <= newProfileAge {
+		score += scoreNewProfile
+	}
+
+	return score
+}
+
+// matchesPreferences reports whether a profile satisfies the criteria of a preference filter
+func matchesPreferences(filter repository.ProfileFilter, profile *model.UserProfile) bool {
+	age := profile.Age()
+	if filter.MinAge != nil && age < *filter.MinAge {
+		return false
+	}
+	if filter.MaxAge != nil && age > *filter.MaxAge {
+		return false
+	}
+	if filter.MinHeight != nil && profile.Height < *filter.MinHeight {
+		return false
+	}
+	if filter.MaxHeight != nil && profile.Height > *filter.MaxHeight {
+		return false
+	}
+	if filter.MinCompleteness != nil && profile.CompletenessScore < *filter.MinCompleteness {
+		return false
+	}
+
+	return matchesAny(filter.Community, profile.Community) &&
+		matchesAny(filter.Nationality, profile.Nationality) &&
+		matchesAny(filter.MaritalStatus, profile.MaritalStatus) &&
+		matchesAny(filter.HomeDistrict, profile.HomeDistrict)
+}
+
+// matchesAny reports whether value is one of the accepted values; an empty list accepts everything
+func matchesAny[T comparable](accepted []T, value T) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+	for _, v := range accepted {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
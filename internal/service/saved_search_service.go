@@ -0,0 +1,404 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/notification"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	savedSearchServiceName = "SavedSearchService"
+
+	// maxSavedSearchesPerUser limits how many searches a user can save
+	maxSavedSearchesPerUser = 20
+	// dueSearchBatchSize is the number of due saved searches processed per batch
+	dueSearchBatchSize = 100
+	// alertSearchPageSize is the number of matches fetched at a time when producing alerts
+	alertSearchPageSize = 50
+)
+
+// savedSearchService implements SavedSearchService
+type savedSearchService struct {
+	searchRepo     repository.SavedSearchRepository
+	alertRepo      repository.SearchAlertRepository
+	profileRepo    repository.UserProfileRepository
+	profileService UserProfileService
+	entitler       Entitler
+	notifier       notification.Notifier
+	logger         *logger.Logger
+}
+
+// NewSavedSearchService creates a new saved search service
+func NewSavedSearchService(
+	searchRepo repository.SavedSearchRepository,
+	alertRepo repository.SearchAlertRepository,
+	profileRepo repository.UserProfileRepository,
+	profileService UserProfileService,
+	entitler Entitler,
+	notifier notification.Notifier,
+	logger *logger.Logger,
+) SavedSearchService {
+	return &savedSearchService{
+		searchRepo:     searchRepo,
+		alertRepo:      alertRepo,
+		profileRepo:    profileRepo,
+		profileService: profileService,
+		entitler:       entitler,
+		notifier:       notifier,
+		logger:         logger,
+	}
+}
+
+// CreateSavedSearch saves a named search filter for the user
+func (s *savedSearchService) CreateSavedSearch(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *dto.CreateSavedSearchRequest,
+) (*dto.SavedSearchResponse, error) {
+	const op = "CreateSavedSearch"
+
+	if userID == uuid.Nil {
+		return nil, NewError(ErrValidation, op, savedSearchServiceName, "user ID is required")
+	}
+
+	// Parse the filter strictly so that typos don't silently widen the search
+	var filter repository.ProfileFilter
+	decoder := json.NewDecoder(bytes.NewReader(req.Filter))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&filter); err != nil {
+		return nil, NewValidationError(op, savedSearchServiceName, []ValidationError{{
+			Field:   "filter",
+			Message: fmt.Sprintf("Invalid search filter: %v", err),
+		}})
+	}
+
+	// Validate it now, as a filter the search rejects would fail every alert run
+	if validationErrors := validateSearchFilter(filter); len(validationErrors) > 0 {
+		return nil, NewValidationError(op, savedSearchServiceName, validationErrors)
+	}
+
+	existing, err := s.searchRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list saved searches",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, savedSearchServiceName, "failed to create saved search")
+	}
+	if len(existing) >= maxSavedSearchesPerUser {
+		return nil, NewValidationError(op, savedSearchServiceName, []ValidationError{{
+			Field:   "name",
+			Message: fmt.Sprintf("You can save at most %d searches", maxSavedSearchesPerUser),
+		}})
+	}
+
+	// Store the normalized filter
+	filterJSON, err := json.Marshal(filter)
+	if err != nil {
+		return nil, NewError(ErrInternal, op, savedSearchServiceName, "failed to serialize filter")
+	}
+
+	search := &model.SavedSearch{
+		UserID:    userID,
+		Name:      req.Name,
+		Filter:    filterJSON,
+		Frequency: model.AlertFrequency(req.Frequency),
+		LastRunAt: time.Now(),
+	}
+
+	if err := s.searchRepo.Create(ctx, search); err != nil {
+		s.logger.Error("Failed to create saved search",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, savedSearchServiceName, "failed to create saved search")
+	}
+
+	s.logger.Info("Saved search created",
+		zap.String("user_id", userID.String()),
+		zap.String("saved_search_id", search.ID.String()),
+		zap.String("frequency", req.Frequency))
+
+	return dto.SavedSearchFromModel(search), nil
+}
+
+// ListSavedSearches lists the user's saved searches
+func (s *savedSearchService) ListSavedSearches(ctx context.Context, userID uuid.UUID) ([]*dto.SavedSearchResponse, error) {
+	const op = "ListSavedSearches"
+
+	searches, err := s.searchRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list saved searches",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, savedSearchServiceName, "failed to list saved searches")
+	}
+
+	results := make([]*dto.SavedSearchResponse, len(searches))
+	for i, search := range searches {
+		results[i] = dto.SavedSearchFromModel(search)
+	}
+
+	return results, nil
+}
+
+// DeleteSavedSearch deletes one of the user's saved searches
+func (s *savedSearchService) DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) error {
+	const op = "DeleteSavedSearch"
+
+	if _, err := s.getOwnedSearch(ctx, op, userID, searchID); err != nil {
+		return err
+	}
+
+	if err := s.searchRepo.Delete(ctx, searchID); err != nil {
+		s.logger.Error("Failed to delete saved search",
+			zap.String("saved_search_id", searchID.String()),
+			zap.Error(err))
+		return NewError(ErrInternal, op, savedSearchServiceName, "failed to delete saved search")
+	}
+
+	s.logger.Info("Saved search deleted",
+		zap.String("user_id", userID.String()),
+		zap.String("saved_search_id", searchID.String()))
+
+	return nil
+}
+
+// RunSavedSearch runs one of the user's saved searches
+func (s *savedSearchService) RunSavedSearch(
+	ctx context.Context,
+	userID uuid.UUID,
+	searchID uuid.UUID,
+	opts SearchOptions,
+) (*dto.ProfileSearchResponse, error) {
+	const op = "RunSavedSearch"
+
+	search, err := s.getOwnedSearch(ctx, op, userID, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter repository.ProfileFilter
+	if err := json.Unmarshal(search.Filter, &filter); err != nil {
+		s.logger.Error("Failed to decode saved search filter",
+			zap.String("saved_search_id", searchID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, savedSearchServiceName, "failed to decode saved search")
+	}
+
+	opts.UserID = userID
+	return s.profileService.SearchProfiles(ctx, filter, opts)
+}
+
+// ListAlerts lists the user's saved search alerts
+func (s *savedSearchService) ListAlerts(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.SearchAlertListResponse, error) {
+	const op = "ListAlerts"
+
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	alerts, total, err := s.alertRepo.ListByUserID(ctx, userID, page, limit)
+	if err != nil {
+		s.logger.Error("Failed to list search alerts",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, savedSearchServiceName, "failed to list alerts")
+	}
+
+	results := make([]*dto.SearchAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		results[i] = dto.SearchAlertFromModel(alert)
+	}
+
+	return &dto.SearchAlertListResponse{
+		Alerts: results,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+	}, nil
+}
+
+// ProcessAlerts finds profiles created since the last run of every due saved search,
+// records alerts for them and notifies the owners
+func (s *savedSearchService) ProcessAlerts(ctx context.Context) error {
+	const op = "ProcessAlerts"
+
+	now := time.Now()
+	processed := make(map[uuid.UUID]bool)
+
+	for {
+		searches, err := s.searchRepo.ListDue(ctx, now, dueSearchBatchSize)
+		if err != nil {
+			return NewError(ErrInternal, op, savedSearchServiceName, err.Error())
+		}
+
+		pending := 0
+		for _, search := range searches {
+			// Instant searches stay due, so stop once a batch only returns searches already handled
+			if processed[search.ID] {
+				continue
+			}
+			processed[search.ID] = true
+			pending++
+
+			if err := s.claimAndProcessSearch(ctx, search.ID, now); err != nil {
+				s.logger.Error("Failed to process saved search alerts",
+					zap.String("saved_search_id", search.ID.String()),
+					zap.Error(err))
+			}
+		}
+
+		if pending == 0 || len(searches) < dueSearchBatchSize || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// claimAndProcessSearch runs the alerts of a saved search while holding its row lock,
+// so that instances running the job at the same time don't notify the owner twice. A
+// search that another instance holds, or has run since it was listed, is skipped.
+func (s *savedSearchService) claimAndProcessSearch(ctx context.Context, searchID uuid.UUID, now time.Time) error {
+	return s.profileRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		search, err := s.searchRepo.Claim(txCtx, searchID, now)
+		if err != nil || search == nil {
+			return err
+		}
+		return s.processSearch(txCtx, search, now)
+	})
+}
+
+// processSearch produces alerts for profiles matching a saved search that were
+// created after its last run, paging through all of them, notifies the owner and
+// then advances the last run time. A failed notification leaves the last run time
+// as it was, so that the search is retried and its unnotified alerts sent again.
+func (s *savedSearchService) processSearch(ctx context.Context, search *model.SavedSearch, now time.Time) error {
+	var filter repository.ProfileFilter
+	if err := json.Unmarshal(search.Filter, &filter); err != nil {
+		return err
+	}
+
+	// Searches saved with filters of a plan that has since lapsed get no alerts until the
+	// owner is entitled again, as searching interactively would be refused
+	if usesAdvancedFilters(filter) {
+		entitlements, err := s.entitler.Entitlements(ctx, search.UserID)
+		if err != nil {
+			return err
+		}
+		if !entitlements.Allows(FeatureAdvancedFilters) {
+			s.logger.Info("Skipped saved search alerts for filters the plan doesn't include",
+				zap.String("user_id", search.UserID.String()),
+				zap.String("saved_search_id", search.ID.String()))
+			return s.searchRepo.MarkRun(ctx, search.ID, now)
+		}
+	}
+
+	since := search.LastRunAt
+	filter.CreatedAfter = &since
+	filter.CreatedBefore = &now
+	filter.ViewerUserID = &search.UserID
+
+	var after *repository.Cursor
+	for {
+		page, err := s.profileRepo.SearchProfiles(ctx, filter, repository.PageRequest{
+			Limit: alertSearchPageSize,
+			After: after,
+			Count: repository.CountNone,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range page.Hits {
+			// Never alert users about their own profile
+			if hit.Profile.UserID == search.UserID {
+				continue
+			}
+
+			alert := &model.SearchAlert{
+				SavedSearchID: search.ID,
+				UserID:        search.UserID,
+				ProfileID:     hit.Profile.ID,
+			}
+			if _, err := s.alertRepo.CreateIfNew(ctx, alert); err != nil {
+				return err
+			}
+		}
+
+		if page.Next == nil {
+			break
+		}
+		after = page.Next
+	}
+
+	// Alerts of earlier runs whose notification failed are sent along with the new ones
+	unnotified, err := s.alertRepo.ListUnnotifiedIDs(ctx, search.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(unnotified) > 0 {
+		err := s.notifier.Notify(ctx, notification.Notification{
+			UserID: search.UserID,
+			Type:   notification.TypeSavedSearchMatch,
+			Title:  "New matches for your saved search",
+			Body:   fmt.Sprintf("%d new profiles match \"%s\"", len(unnotified), search.Name),
+			Data: map[string]string{
+				"saved_search_id": search.ID.String(),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send saved search notification: %w", err)
+		}
+		if err := s.alertRepo.MarkNotified(ctx, unnotified, time.Now()); err != nil {
+			return err
+		}
+
+		s.logger.Info("Saved search alerts sent",
+			zap.String("user_id", search.UserID.String()),
+			zap.String("saved_search_id", search.ID.String()),
+			zap.Int("alerts", len(unnotified)))
+	}
+
+	return s.searchRepo.MarkRun(ctx, search.ID, now)
+}
+
+// getOwnedSearch retrieves a saved search and checks that it belongs to the user
+func (s *savedSearchService) getOwnedSearch(
+	ctx context.Context,
+	op string,
+	userID uuid.UUID,
+	searchID uuid.UUID,
+) (*model.SavedSearch, error) {
+	search, err := s.searchRepo.GetByID(ctx, searchID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, savedSearchServiceName, fmt.Sprintf("saved search with ID %s not found", searchID))
+		}
+
+		s.logger.Error("Failed to get saved search",
+			zap.String("saved_search_id", searchID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, savedSearchServiceName, "failed to retrieve saved search")
+	}
+
+	if search.UserID != userID {
+		s.logger.Warn("Unauthorized saved search access attempt",
+			zap.String("requester_id", userID.String()),
+			zap.String("saved_search_id", searchID.String()))
+		return nil, NewError(ErrNotFound, op, savedSearchServiceName, fmt.Sprintf("saved search with ID %s not found", searchID))
+	}
+
+	return search, nil
+}
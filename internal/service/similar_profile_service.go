@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/similarity"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/cache"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	similarProfileServiceName = "SimilarProfileService"
+
+	// similarCacheSlack is the number of extra ranked profiles cached beyond the maximum
+	// result count, so that removing the viewer's blocks and profiles deleted since still
+	// leaves a full list
+	similarCacheSlack = 20
+)
+
+// similarProfile is a candidate with its similarity to the profile being viewed
+type similarProfile struct {
+	profileID uuid.UUID
+	score     float64
+}
+
+// similarProfileService implements SimilarProfileService
+type similarProfileService struct {
+	profileRepo repository.UserProfileRepository
+	blockRepo   repository.ProfileBlockRepository
+	similarity  similarity.Func
+	cfg         config.SimilarConfig
+	// cache holds the ranked similar profile IDs per profile ID, independent of the viewer.
+	// The profiles themselves are loaded when serving, so that deleted and transferred
+	// profiles drop out and edits show without waiting for the entry to expire.
+	cache  *cache.TTL[uuid.UUID, []similarProfile]
+	logger *logger.Logger
+}
+
+// NewSimilarProfileService creates a new similar profile service ranking candidates with the given similarity function
+func NewSimilarProfileService(
+	profileRepo repository.UserProfileRepository,
+	blockRepo repository.ProfileBlockRepository,
+	similarityFunc similarity.Func,
+	cfg config.SimilarConfig,
+	logger *logger.Logger,
+) SimilarProfileService {
+	return &similarProfileService{
+		profileRepo: profileRepo,
+		blockRepo:   blockRepo,
+		similarity:  similarityFunc,
+		cfg:         cfg,
+		cache:       cache.NewTTL[uuid.UUID, []similarProfile](cfg.CacheTTL, cfg.CacheSize),
+		logger:      logger,
+	}
+}
+
+// GetSimilarProfiles lists profiles of the same gender most similar to the given profile
+func (s *similarProfileService) GetSimilarProfiles(
+	ctx context.Context,
+	profileID uuid.UUID,
+	actor Actor,
+	limit int,
+) ([]*dto.SimilarProfileResponse, error) {
+	const op = "GetSimilarProfiles"
+
+	if limit <= 0 || limit > s.cfg.MaxResults {
+		limit = s.cfg.MaxResults
+	}
+
+	ranked, err := s.rankedSimilar(ctx, op, actor, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded, err := s.excludedFor(ctx, op, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(ranked))
+	for _, candidate := range ranked {
+		if !excluded[candidate.profileID] {
+			ids = append(ids, candidate.profileID)
+		}
+	}
+	live, err := s.profileRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to load similar profiles",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, similarProfileServiceName, "failed to find similar profiles")
+	}
+	profiles := make(map[uuid.UUID]*model.UserProfile, len(live))
+	for _, profile := range live {
+		profiles[profile.ID] = profile
+	}
+
+	results := make([]*dto.SimilarProfileResponse, 0, limit)
+	for _, candidate := range ranked {
+		if len(results) == limit {
+			break
+		}
+		profile, ok := profiles[candidate.profileID]
+		if !ok || profile.UserID == actor.UserID {
+			continue
+		}
+		results = append(results, &dto.SimilarProfileResponse{
+			UserProfileResponse: dto.FromModel(profile),
+			Similarity:          math.Round(candidate.score*1000) / 1000,
+		})
+	}
+
+	return results, nil
+}
+
+// rankedSimilar returns the profiles most similar to a profile, best first, from the cache
+// when possible. Profiles the actor's account is blocked with are not found.
+func (s *similarProfileService) rankedSimilar(ctx context.Context, op string, actor Actor, profileID uuid.UUID) ([]similarProfile, error) {
+	profile, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, similarProfileServiceName, fmt.Sprintf("profile with ID %s not found", profileID))
+		}
+
+		s.logger.Error("Failed to get profile by ID",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, similarProfileServiceName, "failed to retrieve profile")
+	}
+
+	if err := hideIfBlocked(ctx, s.blockRepo, s.logger, op, similarProfileServiceName, actor.UserID, profile); err != nil {
+		return nil, err
+	}
+
+	if ranked, ok := s.cache.Get(profileID); ok {
+		return ranked, nil
+	}
+
+	candidates, err := s.profileRepo.FindSimilar(ctx, profile, repository.SimilarOptions{
+		AgeWindowYears: s.cfg.AgeWindowYears,
+		Limit:          s.cfg.PoolSize,
+	})
+	if err != nil {
+		s.logger.Error("Failed to find similar profile candidates",
+			zap.String("profile_id", profileID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, similarProfileServiceName, "failed to find similar profiles")
+	}
+
+	ranked := make([]similarProfile, len(candidates))
+	for i, candidate := range candidates {
+		ranked[i] = similarProfile{profileID: candidate.ID, score: s.similarity(profile, candidate)}
+	}
+
+	// Candidates arrive closest in age first, which breaks ties
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	if keep := s.cfg.MaxResults + similarCacheSlack; len(ranked) > keep {
+		ranked = ranked[:keep]
+	}
+
+	s.cache.Set(profileID, ranked)
+	return ranked, nil
+}
+
+// excludedFor returns the profiles hidden from a viewer: the acting profile and
+// every profile it blocked or was blocked by
+func (s *similarProfileService) excludedFor(ctx context.Context, op string, actor Actor) (map[uuid.UUID]bool, error) {
+	excluded := make(map[uuid.UUID]bool)
+
+	viewer, err := getActingProfile(ctx, s.profileRepo, s.logger, op, similarProfileServiceName, actor)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) && actor.ProfileID == uuid.Nil {
+			// Users without a profile can't have blocks
+			return excluded, nil
+		}
+		return nil, err
+	}
+	excluded[viewer.ID] = true
+
+	blocked, err := s.blockRepo.ListRelatedProfileIDs(ctx, viewer.ID)
+	if err != nil {
+		s.logger.Error("Failed to load profiles excluded for viewer",
+			zap.String("profile_id", viewer.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, similarProfileServiceName, "failed to find similar profiles")
+	}
+	for _, id := range blocked {
+		excluded[id] = true
+	}
+
+	return excluded, nil
+}
@@ -2,14 +2,22 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/age"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/config"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/dto"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/geo"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/repository"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/hijri"
 	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/logger"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/profilecode"
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/sharetoken"
 	"go.uber.org/zap"
 )
 
@@ -17,23 +25,61 @@ const (
 	serviceName = "UserProfileService"
 	minAge      = 18
 	maxAge      = 80
+
+	// maxSearchRadiusKm is the largest radius accepted for distance searches
+	maxSearchRadiusKm = 1000
 )
 
 // userProfileService implements UserProfileService
 type userProfileService struct {
-	repo   repository.UserProfileRepository
-	logger *logger.Logger
+	repo        repository.UserProfileRepository
+	viewRepo    repository.ProfileViewRepository
+	boostRepo   repository.ProfileBoostRepository
+	managerRepo repository.ProfileManagerRepository
+	blockRepo   repository.ProfileBlockRepository
+	entitler    Entitler
+	scorer      CompletenessScorer
+	revisions   *revisionRecorder
+	cursors     *sharetoken.Signer
+	searchCfg   config.SearchConfig
+	boostCfg    config.BoostConfig
+	profileCfg  config.ProfileConfig
+	logger      *logger.Logger
 }
 
 // NewUserProfileService creates a new user profile service
-func NewUserProfileService(repo repository.UserProfileRepository, logger *logger.Logger) UserProfileService {
+func NewUserProfileService(
+	repo repository.UserProfileRepository,
+	viewRepo repository.ProfileViewRepository,
+	boostRepo repository.ProfileBoostRepository,
+	managerRepo repository.ProfileManagerRepository,
+	blockRepo repository.ProfileBlockRepository,
+	revisionRepo repository.ProfileRevisionRepository,
+	entitler Entitler,
+	scorer CompletenessScorer,
+	searchCfg config.SearchConfig,
+	boostCfg config.BoostConfig,
+	profileCfg config.ProfileConfig,
+	logger *logger.Logger,
+) UserProfileService {
 	return &userProfileService{
-		repo:   repo,
-		logger: logger,
+		repo:        repo,
+		viewRepo:    viewRepo,
+		boostRepo:   boostRepo,
+		managerRepo: managerRepo,
+		blockRepo:   blockRepo,
+		entitler:    entitler,
+		scorer:      scorer,
+		revisions:   newRevisionRecorder(revisionRepo, profileCfg, logger),
+		cursors:     sharetoken.NewSigner(searchCfg.CursorSecret),
+		searchCfg:   searchCfg,
+		boostCfg:    boostCfg,
+		profileCfg:  profileCfg,
+		logger:      logger,
 	}
 }
 
-// CreateProfile creates a new user profile
+// CreateProfile creates a new profile owned by the user's account
 func (s *userProfileService) CreateProfile(
 	ctx context.Context,
 	userID uuid.UUID,
@@ -52,17 +98,6 @@ func (s *userProfileService) CreateProfile(
 		return nil, NewValidationError(op, serviceName, validationErrors)
 	}
 
-	// Check if profile already exists for this user
-	existingProfile, err := s.repo.GetByUserID(ctx, userID)
-	if err == nil && existingProfile != nil {
-		return nil, NewError(ErrDuplicate, op, serviceName, "profile already exists for this user")
-	} else if err != nil && !errors.Is(errors.Unwrap(err), repository.ErrNotFound) {
-		s.logger.Error("Failed to check for existing profile",
-			zap.String("user_id", userID.String()),
-			zap.Error(err))
-		return nil, NewError(ErrInternal, op, serviceName, "failed to check for existing profile")
-	}
-
 	// Convert request to model
 	profile, err := req.ToModel(userID)
 	if err != nil {
@@ -71,19 +106,39 @@ func (s *userProfileService) CreateProfile(
 			zap.Error(err))
 		return nil, NewError(ErrValidation, op, serviceName, err.Error())
 	}
+	setResidenceLocation(profile)
+	s.scoreCompleteness(ctx, profile)
+
+	// Create the profile, within the number of profiles an account may own, along with
+	// its first revision
+	err = s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err := s.repo.CreateWithinLimit(txCtx, profile, s.profileCfg.MaxPerAccount)
+		if err != nil {
+			if isRepoDuplicate(err) {
+				return NewError(ErrDuplicate, op, serviceName, "your account already has a profile of your own")
+			}
+			return err
+		}
+		if !created {
+			return NewError(ErrValidation, op, serviceName,
+				fmt.Sprintf("an account can own at most %d profiles", s.profileCfg.MaxPerAccount))
+		}
 
-	// Create the profile
-	err = s.repo.Create(ctx, profile)
+		return s.revisions.record(txCtx, &model.ProfileRevision{
+			Action:    model.ProfileRevisionActionCreate,
+			ActorID:   userID,
+			ActorRole: model.ProfileRevisionActorOwner,
+		}, nil, profile)
+	})
 	if err != nil {
+		var svcErr *ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, svcErr
+		}
+
 		s.logger.Error("Failed to create profile",
 			zap.String("user_id", userID.String()),
 			zap.Error(err))
-
-		var repoErr *repository.RepositoryError
-		if errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrDuplicateKey) {
-			return nil, NewError(ErrDuplicate, op, serviceName, "profile already exists for this user")
-		}
-
 		return nil, NewError(ErrInternal, op, serviceName, "failed to create profile")
 	}
 
@@ -100,7 +155,8 @@ func (s *userProfileService) CreateProfile(
 func (s *userProfileService) GetProfileByID(
 	ctx context.Context,
 	profileID uuid.UUID,
-	requestingUserID uuid.UUID,
+	actor Actor,
+	boostID uuid.UUID,
 ) (*dto.UserProfileResponse, error) {
 	const op = "GetProfileByID"
 
@@ -111,60 +167,168 @@ func (s *userProfileService) GetProfileByID(
 			zap.String("profile_id", profileID.String()),
 			zap.Error(err))
 
-		var repoErr *repository.RepositoryError
-		if errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrNotFound) {
+		if isRepoNotFound(err) {
 			return nil, NewError(ErrNotFound, op, serviceName, fmt.Sprintf("profile with ID %s not found", profileID))
 		}
 
 		return nil, NewError(ErrInternal, op, serviceName, "failed to retrieve profile")
 	}
 
-	// Check if user is authorized to view this profile
-	// In this case, we're allowing any authenticated user to view profiles
-	// but logging the access for auditing purposes
-	if profile.UserID != requestingUserID {
-		s.logger.UserProfileEvent(ctx, "profile_accessed", requestingUserID.String(), profileID.String(),
-			zap.String("owner_id", profile.UserID.String()))
-	} else {
-		s.logger.UserProfileEvent(ctx, "profile_accessed_by_owner", profile.UserID.String(), profileID.String())
+	if err := hideIfBlocked(ctx, s.blockRepo, s.logger, op, serviceName, actor.UserID, profile); err != nil {
+		return nil, err
 	}
 
+	s.recordAccess(ctx, profile, actor, boostID)
+
 	return dto.FromModel(profile), nil
 }
 
-// GetProfileByUserID retrieves a profile by user ID
-func (s *userProfileService) GetProfileByUserID(
+// GetProfileByCode retrieves a profile by its public code
+func (s *userProfileService) GetProfileByCode(
 	ctx context.Context,
-	userID uuid.UUID,
-	requestingUserID uuid.UUID,
+	code string,
+	actor Actor,
+	boostID uuid.UUID,
 ) (*dto.UserProfileResponse, error) {
-	const op = "GetProfileByUserID"
+	const op = "GetProfileByCode"
 
-	// Get the profile
-	profile, err := s.repo.GetByUserID(ctx, userID)
+	profile, err := s.getByCode(ctx, op, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hideIfBlocked(ctx, s.blockRepo, s.logger, op, serviceName, actor.UserID, profile); err != nil {
+		return nil, err
+	}
+
+	s.recordAccess(ctx, profile, actor, boostID)
+
+	return dto.FromModel(profile), nil
+}
+
+// ResolveProfileRef returns the ID of the profile referred to by its ID or its public code.
+// IDs are returned as given; whether such a profile exists is left to the operation using it.
+func (s *userProfileService) ResolveProfileRef(ctx context.Context, ref string) (uuid.UUID, error) {
+	const op = "ResolveProfileRef"
+
+	if profileID, err := uuid.Parse(ref); err == nil {
+		return profileID, nil
+	}
+
+	profile, err := s.getByCode(ctx, op, ref)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return profile.ID, nil
+}
+
+// getByCode retrieves a profile by a public code as typed by a person
+func (s *userProfileService) getByCode(ctx context.Context, op, code string) (*model.UserProfile, error) {
+	normalized, err := profilecode.Parse(code)
+	if err != nil {
+		return nil, NewError(ErrValidation, op, serviceName, fmt.Sprintf("%q is not a valid profile ID or code", code))
+	}
+
+	profile, err := s.repo.GetByCode(ctx, normalized)
 	if err != nil {
-		s.logger.Error("Failed to get profile by user ID",
+		if isRepoNotFound(err) {
+			return nil, NewError(ErrNotFound, op, serviceName, fmt.Sprintf("profile with code %s not found", normalized))
+		}
+
+		s.logger.Error("Failed to get profile by code",
+			zap.String("profile_code", normalized),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, serviceName, "failed to retrieve profile")
+	}
+
+	return profile, nil
+}
+
+// recordAccess audits a profile being opened. Any authenticated user may view profiles,
+// but views by other users also count as profile views, and as clicks of the boost
+// that placed the profile in search results when opened from there.
+func (s *userProfileService) recordAccess(ctx context.Context, profile *model.UserProfile, actor Actor, boostID uuid.UUID) {
+	if profile.UserID == actor.UserID {
+		s.logger.UserProfileEvent(ctx, "profile_accessed_by_owner", profile.UserID.String(), profile.ID.String())
+		return
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_accessed", actor.UserID.String(), profile.ID.String(),
+		zap.String("owner_id", profile.UserID.String()))
+	s.recordView(ctx, profile.ID, actor)
+
+	if boostID == uuid.Nil {
+		return
+	}
+	if err := s.boostRepo.RecordClick(ctx, boostID, profile.ID, actor.UserID, time.Now().UTC()); err != nil {
+		s.logger.Error("Failed to record boost click",
+			zap.String("profile_id", profile.ID.String()),
+			zap.String("boost_id", boostID.String()),
+			zap.Error(err))
+	}
+}
+
+// ListAccountProfiles lists the profiles owned by the user's account, marking the active one
+func (s *userProfileService) ListAccountProfiles(ctx context.Context, userID uuid.UUID) ([]*dto.AccountProfileResponse, error) {
+	const op = "ListAccountProfiles"
+
+	profiles, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list account profiles",
 			zap.String("user_id", userID.String()),
 			zap.Error(err))
+		return nil, NewError(ErrInternal, op, serviceName, "failed to list profiles")
+	}
 
-		var repoErr *repository.RepositoryError
-		if errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrNotFound) {
-			return nil, NewError(ErrNotFound, op, serviceName, fmt.Sprintf("profile for user %s not found", userID))
+	activeID := uuid.Nil
+	if len(profiles) > 0 {
+		active, err := getActingProfile(ctx, s.repo, s.logger, op, serviceName, Actor{UserID: userID})
+		if err == nil {
+			activeID = active.ID
+		} else if !errors.Is(err, ErrValidation) {
+			return nil, err
 		}
+	}
 
-		return nil, NewError(ErrInternal, op, serviceName, "failed to retrieve profile")
+	response := make([]*dto.AccountProfileResponse, len(profiles))
+	for i, profile := range profiles {
+		response[i] = &dto.AccountProfileResponse{
+			UserProfileResponse: dto.FromModel(profile),
+			Active:              profile.ID == activeID,
+		}
 	}
 
-	// Check if user is authorized to view this profile
-	// Similar to GetProfileByID, allowing any authenticated user to view
-	if userID != requestingUserID {
-		s.logger.UserProfileEvent(ctx, "profile_accessed", requestingUserID.String(), profile.ID.String(),
-			zap.String("owner_id", userID.String()))
-	} else {
-		s.logger.UserProfileEvent(ctx, "profile_accessed_by_owner", userID.String(), profile.ID.String())
+	return response, nil
+}
+
+// SwitchProfile makes one of the account's profiles the one requests act as by default
+func (s *userProfileService) SwitchProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	profileID uuid.UUID,
+) (*dto.AccountProfileResponse, error) {
+	const op = "SwitchProfile"
+
+	profile, err := getActingProfile(ctx, s.repo, s.logger, op, serviceName, Actor{UserID: userID, ProfileID: profileID})
+	if err != nil {
+		return nil, err
 	}
 
-	return dto.FromModel(profile), nil
+	if err := s.repo.SetActive(ctx, userID, profile.ID); err != nil {
+		s.logger.Error("Failed to switch active profile",
+			zap.String("user_id", userID.String()),
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, serviceName, "failed to switch profile")
+	}
+
+	s.logger.UserProfileEvent(ctx, "profile_switched", userID.String(), profile.ID.String())
+
+	return &dto.AccountProfileResponse{
+		UserProfileResponse: dto.FromModel(profile),
+		Active:              true,
+	}, nil
 }
 
 // UpdateProfile updates an existing profile
@@ -189,36 +353,57 @@ func (s *userProfileService) UpdateProfile(
 			zap.String("profile_id", profileID.String()),
 			zap.Error(err))
 
-		var repoErr *repository.RepositoryError
-		if errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrNotFound) {
+		if isRepoNotFound(err) {
 			return nil, NewError(ErrNotFound, op, serviceName, fmt.Sprintf("profile with ID %s not found", profileID))
 		}
 
 		return nil, NewError(ErrInternal, op, serviceName, "failed to retrieve profile for update")
 	}
 
-	// Check authorization - only the owner can update their profile
-	if existingProfile.UserID != userID {
+	// Check authorization - the owner or a manager with edit permission can update the profile
+	manager, err := authorizeProfileAction(ctx, s.managerRepo, s.logger, op, serviceName,
+		existingProfile, userID, model.ProfileManagerScopeEdit,
+		"you can only update your own profile or one you manage with edit permission")
+	if err != nil {
 		s.logger.Warn("Unauthorized profile update attempt",
 			zap.String("requester_id", userID.String()),
 			zap.String("profile_id", profileID.String()),
-			zap.String("owner_id", existingProfile.UserID.String()))
-		return nil, NewError(ErrUnauthorized, op, serviceName, "you can only update your own profile")
+			zap.String("owner_id", existingProfile.UserID.String()),
+			zap.Error(err))
+		return nil, err
 	}
 
-	// Convert request to model
-	updatedProfile, err := req.ToModel(userID)
+	// Convert request to model, keeping the profile with its owner
+	updatedProfile, err := req.ToModel(existingProfile.UserID)
 	if err != nil {
 		return nil, NewError(ErrValidation, op, serviceName, err.Error())
 	}
+	setResidenceLocation(updatedProfile)
 
-	// Preserve ID and creation time
+	// Preserve ID, code, creation time and score, and record who made the change
 	updatedProfile.ID = profileID
+	updatedProfile.ProfileCode = existingProfile.ProfileCode
 	updatedProfile.CreatedAt = existingProfile.CreatedAt
+	updatedProfile.CompletenessScore = existingProfile.CompletenessScore
 	updatedProfile.UpdatedAt = time.Now()
-
-	// Update profile
-	err = s.repo.Update(ctx, updatedProfile)
+	updatedProfile.UpdatedBy = &userID
+	s.scoreCompleteness(ctx, updatedProfile)
+
+	// Update profile, recording the changed fields as a revision
+	rev := &model.ProfileRevision{
+		Action:    model.ProfileRevisionActionUpdate,
+		ActorID:   userID,
+		ActorRole: model.ProfileRevisionActorOwner,
+	}
+	if manager != nil {
+		rev.ActorRole = model.ProfileRevisionActorManager
+	}
+	err = s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, updatedProfile); err != nil {
+			return err
+		}
+		return s.revisions.record(txCtx, rev, existingProfile, updatedProfile)
+	})
 	if err != nil {
 		s.logger.Error("Failed to update profile",
 			zap.String("profile_id", profileID.String()),
@@ -227,8 +412,11 @@ func (s *userProfileService) UpdateProfile(
 	}
 
 	// Log the update
-	s.logger.UserProfileEvent(ctx, "profile_updated", userID.String(), profileID.String(),
-		zap.String("name", updatedProfile.Name))
+	fields := append(actorFields(existingProfile, manager), zap.String("name", updatedProfile.Name))
+	if rev.ID != uuid.Nil {
+		fields = append(fields, zap.String("revision_id", rev.ID.String()), zap.Bool("flagged", rev.Flagged))
+	}
+	s.logger.UserProfileEvent(ctx, "profile_updated", userID.String(), profileID.String(), fields...)
 
 	return dto.FromModel(updatedProfile), nil
 }
@@ -248,25 +436,28 @@ func (s *userProfileService) DeleteProfile(
 			zap.String("profile_id", profileID.String()),
 			zap.Error(err))
 
-		var repoErr *repository.RepositoryError
-		if errors.As(err, &repoErr) && errors.Is(repoErr.Unwrap(), repository.ErrNotFound) {
+		if isRepoNotFound(err) {
 			return NewError(ErrNotFound, op, serviceName, fmt.Sprintf("profile with ID %s not found", profileID))
 		}
 
 		return NewError(ErrInternal, op, serviceName, "failed to retrieve profile for deletion")
 	}
 
-	// Check authorization - only the owner can delete their profile
-	if existingProfile.UserID != userID {
+	// Check authorization - the owner or a manager with delete permission can delete the profile
+	manager, err := authorizeProfileAction(ctx, s.managerRepo, s.logger, op, serviceName,
+		existingProfile, userID, model.ProfileManagerScopeDelete,
+		"you can only delete your own profile or one you manage with delete permission")
+	if err != nil {
 		s.logger.Warn("Unauthorized profile deletion attempt",
 			zap.String("requester_id", userID.String()),
 			zap.String("profile_id", profileID.String()),
-			zap.String("owner_id", existingProfile.UserID.String()))
-		return NewError(ErrUnauthorized, op, serviceName, "you can only delete your own profile")
+			zap.String("owner_id", existingProfile.UserID.String()),
+			zap.Error(err))
+		return err
 	}
 
 	// Delete the profile
-	err = s.repo.Delete(ctx, profileID)
+	err = s.repo.Delete(ctx, profileID, userID)
 	if err != nil {
 		s.logger.Error("Failed to delete profile",
 			zap.String("profile_id", profileID.String()),
@@ -275,7 +466,8 @@ func (s *userProfileService) DeleteProfile(
 	}
 
 	// Log the deletion
-	s.logger.UserProfileEvent(ctx, "profile_deleted", userID.String(), profileID.String())
+	s.logger.UserProfileEvent(ctx, "profile_deleted", userID.String(), profileID.String(),
+		actorFields(existingProfile, manager)...)
 
 	return nil
 }
@@ -284,29 +476,327 @@ func (s *userProfileService) DeleteProfile(
 func (s *userProfileService) SearchProfiles(
 	ctx context.Context,
 	filter repository.ProfileFilter,
-	page, limit int,
-) ([]*dto.UserProfileResponse, int64, error) {
+	opts SearchOptions,
+) (*dto.ProfileSearchResponse, error) {
 	const op = "SearchProfiles"
 
+	if validationErrors := validateSearchFilter(filter); len(validationErrors) > 0 {
+		return nil, NewValidationError(op, serviceName, validationErrors)
+	}
+
+	if opts.UserID != uuid.Nil {
+		if usesAdvancedFilters(filter) {
+			if err := requireFeature(ctx, s.entitler, op, serviceName, opts.UserID, FeatureAdvancedFilters); err != nil {
+				return nil, err
+			}
+		}
+		filter.ViewerUserID = &opts.UserID
+	}
+
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = s.searchCfg.DefaultLimit
+	}
+	if opts.Limit > s.searchCfg.MaxLimit {
+		opts.Limit = s.searchCfg.MaxLimit
+	}
+
+	pageReq := repository.PageRequest{
+		Page:  opts.Page,
+		Limit: opts.Limit,
+		Count: opts.Count,
+	}
+
+	if opts.Cursor != "" {
+		cursor, err := s.decodeCursor(opts.Cursor, filter)
+		if err != nil {
+			return nil, NewError(ErrValidation, op, serviceName, "invalid cursor")
+		}
+		pageReq.After = cursor
+	}
+
+	if pageReq.Count == "" {
+		pageReq.Count = repository.CountExact
+		if pageReq.After != nil {
+			pageReq.Count = repository.CountNone
+		}
+	}
+
+	// Feature boosted profiles near the top of the first page, in place of organic hits.
+	// Later pages reached by number start earlier by as many rows as the first page gave
+	// up to boosted profiles, so that none of the organic hits is skipped.
+	var boosted []*repository.ProfileSearchHit
+	if pageReq.After == nil {
+		if opts.Page == 1 {
+			boosted = s.searchBoosted(ctx, filter, opts.Limit-1)
+			pageReq.Reserved = len(boosted)
+		} else {
+			pageReq.Reserved = s.countBoosted(ctx, filter, opts.Limit-1)
+		}
+	}
+
 	// Search profiles
-	profiles, total, err := s.repo.SearchProfiles(ctx, filter, page, limit)
+	page, err := s.repo.SearchProfiles(ctx, filter, pageReq)
 	if err != nil {
+		if isRepoInvalidOperation(err) {
+			return nil, NewError(ErrValidation, op, serviceName, "cursor does not match the current search")
+		}
+
 		s.logger.Error("Failed to search profiles", zap.Error(err))
-		return nil, 0, NewError(ErrInternal, op, serviceName, "failed to search profiles")
+		return nil, NewError(ErrInternal, op, serviceName, "failed to search profiles")
+	}
+
+	hits := page.Hits
+	if len(boosted) > 0 {
+		hits = interleaveBoosted(hits, boosted, s.boostCfg.SlotInterval, opts.Limit)
+		s.recordImpressions(ctx, boosted)
 	}
 
 	// Convert to DTOs
-	results := make([]*dto.UserProfileResponse, len(profiles))
-	for i, profile := range profiles {
-		results[i] = dto.FromModel(profile)
+	results := make([]*dto.ProfileSearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = &dto.ProfileSearchResult{
+			UserProfileResponse: dto.FromModel(hit.Profile),
+			Relevance:           hit.Rank,
+			Highlights:          hit.Highlights,
+			DistanceKm:          hit.DistanceKm,
+			Boosted:             hit.BoostID != nil,
+			BoostID:             hit.BoostID,
+		}
+	}
+
+	response := &dto.ProfileSearchResponse{
+		Profiles:       results,
+		Total:          page.Total,
+		TotalEstimated: page.TotalIsEstimate,
+		Limit:          opts.Limit,
+	}
+
+	if pageReq.After == nil {
+		response.Page = opts.Page
+	}
+
+	if page.Next != nil {
+		// The cursor keeps the boosted profiles out of the organic hits of the following pages
+		for _, hit := range boosted {
+			page.Next.Exclude = append(page.Next.Exclude, hit.Profile.ID)
+		}
+		response.NextCursor = s.encodeCursor(page.Next, filter)
+	}
+
+	if opts.Facets {
+		facets, err := s.repo.CountFacets(ctx, filter)
+		if err != nil {
+			s.logger.Error("Failed to count search facets", zap.Error(err))
+			return nil, NewError(ErrInternal, op, serviceName, "failed to count search facets")
+		}
+		response.Facets = toSearchFacets(facets)
+	}
+
+	return response, nil
+}
+
+// searchBoosted retrieves up to the configured number of boosted profiles matching the
+// filter, and at most max. Boosts are best effort: failures are logged and none returned.
+func (s *userProfileService) searchBoosted(
+	ctx context.Context,
+	filter repository.ProfileFilter,
+	max int,
+) []*repository.ProfileSearchHit {
+	limit := s.boostCfg.MaxPerPage
+	if max < limit {
+		limit = max
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	boosted, err := s.boostRepo.SearchBoosted(ctx, filter, time.Now().UTC(), limit)
+	if err != nil {
+		s.logger.Error("Failed to search boosted profiles", zap.Error(err))
+		return nil
+	}
+
+	return boosted
+}
+
+// countBoosted counts the boosted profiles the first page of the search places, at most
+// the configured number and max. Failures are logged and none counted, as when the first
+// page fails to retrieve them.
+func (s *userProfileService) countBoosted(ctx context.Context, filter repository.ProfileFilter, max int) int {
+	limit := min(s.boostCfg.MaxPerPage, max)
+	if limit <= 0 {
+		return 0
+	}
+
+	count, err := s.boostRepo.CountBoosted(ctx, filter, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("Failed to count boosted profiles", zap.Error(err))
+		return 0
+	}
+
+	return int(min(count, int64(limit)))
+}
+
+// recordImpressions counts an impression for the boosts of the boosted hits shown
+func (s *userProfileService) recordImpressions(ctx context.Context, boosted []*repository.ProfileSearchHit) {
+	boostIDs := make([]uuid.UUID, len(boosted))
+	for i, hit := range boosted {
+		boostIDs[i] = *hit.BoostID
+	}
+	if err := s.boostRepo.RecordImpressions(ctx, boostIDs); err != nil {
+		s.logger.Error("Failed to record boost impressions", zap.Error(err))
+	}
+}
+
+// usesAdvancedFilters reports whether a search filter uses filters reserved for plans with advanced filters
+func usesAdvancedFilters(filter repository.ProfileFilter) bool {
+	return filter.Keywords != nil || filter.Near != nil
+}
+
+// recordView records a view of a profile by the actor's profile. Users without a
+// profile, or who haven't chosen which of their profiles to act as, aren't recorded,
+// and failures are only logged since they mustn't fail the request.
+func (s *userProfileService) recordView(ctx context.Context, profileID uuid.UUID, actor Actor) {
+	viewer, err := getActingProfile(ctx, s.repo, s.logger, "recordView", serviceName, actor)
+	if err != nil {
+		return
+	}
+
+	if err := s.viewRepo.Record(ctx, profileID, viewer.ID, time.Now()); err != nil {
+		s.logger.Error("Failed to record profile view",
+			zap.String("profile_id", profileID.String()),
+			zap.String("viewer_profile_id", viewer.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// validateSearchFilter validates the parts of a search filter that can't be expressed as query syntax
+func validateSearchFilter(filter repository.ProfileFilter) []ValidationError {
+	var errors []ValidationError
+
+	if filter.Near != nil {
+		if !filter.Near.Valid() {
+			errors = append(errors, ValidationError{
+				Field:   "near",
+				Message: "Location coordinates are out of range",
+			})
+		}
+		if filter.RadiusKm == nil {
+			errors = append(errors, ValidationError{
+				Field:   "radius_km",
+				Message: "Radius is required when searching near a location",
+			})
+		}
+	}
+
+	if filter.RadiusKm != nil && (*filter.RadiusKm <= 0 || *filter.RadiusKm > maxSearchRadiusKm) {
+		errors = append(errors, ValidationError{
+			Field:   "radius_km",
+			Message: fmt.Sprintf("Radius must be between 0 and %d km", maxSearchRadiusKm),
+		})
 	}
 
-	return results, total, nil
+	// The values are compared with enum columns, which reject unknown values outright
+	for _, v := range filter.Community {
+		if !v.IsValid() {
+			errors = append(errors, ValidationError{Field: "community", Message: fmt.Sprintf("Unknown community %q", v)})
+		}
+	}
+	for _, v := range filter.Nationality {
+		if !v.IsValid() {
+			errors = append(errors, ValidationError{Field: "nationality", Message: fmt.Sprintf("Unknown nationality %q", v)})
+		}
+	}
+	for _, v := range filter.MaritalStatus {
+		if !v.IsValid() {
+			errors = append(errors, ValidationError{Field: "marital_status", Message: fmt.Sprintf("Unknown marital status %q", v)})
+		}
+	}
+	for _, v := range filter.HomeDistrict {
+		if !v.IsValid() {
+			errors = append(errors, ValidationError{Field: "home_district", Message: fmt.Sprintf("Unknown home district %q", v)})
+		}
+	}
+
+	if filter.MinCompleteness != nil && (*filter.MinCompleteness < 0 || *filter.MinCompleteness > 100) {
+		errors = append(errors, ValidationError{
+			Field:   "min_completeness",
+			Message: "Minimum completeness must be a percentage between 0 and 100",
+		})
+	}
+
+	return errors
+}
+
+// encodeCursor serializes a search cursor into an opaque URL-safe string, signed
+// together with the filter so that it can't be altered or reused for another search
+func (s *userProfileService) encodeCursor(cursor *repository.Cursor, filter repository.ProfileFilter) string {
+	data, _ := json.Marshal(cursor)
+	return s.cursors.SignData(data, filterBinding(filter))
+}
+
+// decodeCursor parses a cursor produced by encodeCursor for the same filter
+func (s *userProfileService) decodeCursor(raw string, filter repository.ProfileFilter) (*repository.Cursor, error) {
+	data, err := s.cursors.VerifyData(raw, filterBinding(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor repository.Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.ID == uuid.Nil || cursor.Sort == "" {
+		return nil, errors.New("incomplete cursor")
+	}
+	// Only the boosted profiles of the first page are carried over
+	if len(cursor.Exclude) > s.boostCfg.MaxPerPage {
+		return nil, errors.New("cursor excludes too many profiles")
+	}
+
+	return &cursor, nil
+}
+
+// filterBinding returns the serialized filter a cursor is signed with
+func filterBinding(filter repository.ProfileFilter) []byte {
+	data, _ := json.Marshal(filter)
+	return data
+}
+
+// toSearchFacets converts repository facet counts to their DTO
+func toSearchFacets(facets *repository.ProfileFacets) *dto.SearchFacets {
+	convert := func(counts []repository.FacetCount) []dto.FacetValue {
+		values := make([]dto.FacetValue, len(counts))
+		for i, c := range counts {
+			values[i] = dto.FacetValue{Value: c.Value, Count: c.Count}
+		}
+		return values
+	}
+
+	return &dto.SearchFacets{
+		Community:     convert(facets.Community),
+		Nationality:   convert(facets.Nationality),
+		MaritalStatus: convert(facets.MaritalStatus),
+		HomeDistrict:  convert(facets.HomeDistrict),
+		AgeRange:      convert(facets.AgeRange),
+	}
 }
 
 // validateProfileRequest validates the profile request
 func (s *userProfileService) validateProfileRequest(req *dto.CreateUserProfileRequest) []ValidationError {
 	var errors []ValidationError
+	errors = append(errors, validateBasicDetails(req)...)
+	errors = append(errors, validatePhysicalDetails(req)...)
+	errors = append(errors, validateLocationDetails(req)...)
+	return errors
+}
+
+// validateBasicDetails validates the name and date of birth of a profile request
+func validateBasicDetails(req *dto.CreateUserProfileRequest) []ValidationError {
+	var errors []ValidationError
 
 	// Validate name
 	if len(req.Name) < 2 {
@@ -316,22 +806,27 @@ func (s *userProfileService) validateProfileRequest(req *dto.CreateUserProfileRe
 		})
 	}
 
-	// Validate date of birth
-	dob, err := time.Parse("2006-01-02", req.DateOfBirth)
+	// Validate date of birth, converting it to Gregorian when given in Hijri
+	dob, err := req.GregorianDateOfBirth()
 	if err != nil {
+		message := "Invalid date format, expected YYYY-MM-DD"
+		if req.Calendar() == model.DateCalendarHijri {
+			first, last := hijri.Range()
+			message = fmt.Sprintf("Invalid Hijri date, expected YYYY-MM-DD between %d and %d AH", first, last)
+		}
 		errors = append(errors, ValidationError{
 			Field:   "date_of_birth",
-			Message: "Invalid date format, expected YYYY-MM-DD",
+			Message: message,
 		})
 	} else {
 		// Check age range
-		age := calculateAge(dob)
-		if age < minAge {
+		years := age.Of(dob)
+		if years < minAge {
 			errors = append(errors, ValidationError{
 				Field:   "date_of_birth",
 				Message: fmt.Sprintf("Age must be at least %d years", minAge),
 			})
-		} else if age > maxAge {
+		} else if years > maxAge {
 			errors = append(errors, ValidationError{
 				Field:   "date_of_birth",
 				Message: fmt.Sprintf("Age must not exceed %d years", maxAge),
@@ -339,6 +834,13 @@ func (s *userProfileService) validateProfileRequest(req *dto.CreateUserProfileRe
 		}
 	}
 
+	return errors
+}
+
+// validatePhysicalDetails validates the height and weight of a profile request
+func validatePhysicalDetails(req *dto.CreateUserProfileRequest) []ValidationError {
+	var errors []ValidationError
+
 	// Validate height
 	if req.Height <= 0 {
 		errors = append(errors, ValidationError{
@@ -368,15 +870,125 @@ func (s *userProfileService) validateProfileRequest(req *dto.CreateUserProfileRe
 	return errors
 }
 
-// calculateAge calculates age from date of birth
-func calculateAge(dob time.Time) int {
-	now := time.Now()
-	years := now.Year() - dob.Year()
+// validateLocationDetails validates the residence of a profile request
+func validateLocationDetails(req *dto.CreateUserProfileRequest) []ValidationError {
+	var errors []ValidationError
 
-	// Adjust age if birthday hasn't occurred yet this year
-	if now.Month() < dob.Month() || (now.Month() == dob.Month() && now.Day() < dob.Day()) {
-		years--
+	// Validate residence city against the gazetteer so it can be located
+	if req.ResidenceCity != "" {
+		if _, ok := geo.Lookup(req.ResidenceCity); !ok {
+			errors = append(errors, ValidationError{
+				Field:   "residence_city",
+				Message: "Unknown residence city",
+			})
+		}
 	}
 
-	return years
+	return errors
+}
+
+// setResidenceLocation normalizes the residence city name and sets the coordinates of
+// the residence from the gazetteer, falling back to the home district without a city
+func setResidenceLocation(profile *model.UserProfile) {
+	profile.Latitude, profile.Longitude = nil, nil
+
+	place, ok := geo.LookupResidence(profile.ResidenceCity, string(profile.HomeDistrict))
+	if !ok {
+		return
+	}
+
+	lat, lng := place.Lat, place.Lng
+	if profile.ResidenceCity != "" {
+		profile.ResidenceCity = place.Name
+	}
+	profile.Latitude = &lat
+	profile.Longitude = &lng
+}
+
+// scoreCompleteness sets the completeness score of a profile about to be saved. A
+// failure leaves the score as it is rather than failing the save.
+func (s *userProfileService) scoreCompleteness(ctx context.Context, profile *model.UserProfile) {
+	score, err := s.scorer.ScoreProfile(ctx, profile)
+	if err != nil {
+		s.logger.Warn("Failed to score profile completeness",
+			zap.String("profile_id", profile.ID.String()),
+			zap.Error(err))
+		return
+	}
+	profile.CompletenessScore = score
+}
+
+// actorFields describes who acted on a profile for event logs: the owner, and the
+// delegation used when the actor is one of the profile's managers
+func actorFields(profile *model.UserProfile, manager *model.ProfileManager) []zap.Field {
+	fields := []zap.Field{zap.String("owner_id", profile.UserID.String())}
+	if manager != nil {
+		fields = append(fields,
+			zap.String("acted_as", "manager"),
+			zap.String("profile_manager_id", manager.ID.String()))
+	}
+	return fields
+}
+
+// getActingProfile retrieves the profile the actor acts as. A named profile must be owned
+// by the actor's account; otherwise the account's active profile is used, or its only
+// profile when it has just one.
+func getActingProfile(
+	ctx context.Context,
+	repo repository.UserProfileRepository,
+	log *logger.Logger,
+	op, svcName string,
+	actor Actor,
+) (*model.UserProfile, error) {
+	if actor.ProfileID != uuid.Nil || actor.ProfileCode != "" {
+		ref := actor.ProfileCode
+		var profile *model.UserProfile
+		var err error
+		if ref != "" {
+			profile, err = repo.GetByCode(ctx, ref)
+		} else {
+			ref = actor.ProfileID.String()
+			profile, err = repo.GetByID(ctx, actor.ProfileID)
+		}
+		if err != nil && !isRepoNotFound(err) {
+			log.Error("Failed to get acting profile",
+				zap.String("profile", ref),
+				zap.Error(err))
+			return nil, NewError(ErrInternal, op, svcName, "failed to retrieve profile")
+		}
+		if err != nil || profile.UserID != actor.UserID {
+			return nil, NewError(ErrNotFound, op, svcName, fmt.Sprintf("profile %s not found in your account", ref))
+		}
+		return profile, nil
+	}
+
+	profile, err := repo.GetActiveByUserID(ctx, actor.UserID)
+	if err == nil {
+		return profile, nil
+	}
+	if !isRepoNotFound(err) {
+		log.Error("Failed to get active profile",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, svcName, "failed to retrieve profile")
+	}
+
+	// The active profile was deleted or never set
+	profiles, err := repo.ListByUserID(ctx, actor.UserID)
+	if err != nil {
+		log.Error("Failed to list account profiles",
+			zap.String("user_id", actor.UserID.String()),
+			zap.Error(err))
+		return nil, NewError(ErrInternal, op, svcName, "failed to retrieve profile")
+	}
+
+	switch len(profiles) {
+	case 0:
+		return nil, NewError(ErrNotFound, op, svcName, "create a profile first")
+	case 1:
+		return profiles[0], nil
+	default:
+		return nil, NewError(ErrValidation, op, svcName,
+			"your account has several profiles; switch to one or name it in the X-Acting-Profile-ID header")
+	}
 }
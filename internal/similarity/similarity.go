@@ -0,0 +1,90 @@
+package similarity
+
+import (
+	"math"
+	"strings"
+
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/domain/model"
+)
+
+// Func scores how similar candidate is to profile, from 0 (nothing in common) to 1 (identical)
+type Func func(profile, candidate *model.UserProfile) float64
+
+// Weights sets how much each attribute contributes to a weighted similarity.
+// Attributes with a zero weight are ignored.
+type Weights struct {
+	Age        float64
+	Height     float64
+	Community  float64
+	District   float64
+	Education  float64
+	Occupation float64
+}
+
+// Distances at which numeric attributes stop contributing to the similarity
+const (
+	ageScaleYears = 10.0
+	heightScaleCm = 25.0
+)
+
+// Weighted returns a similarity function that averages per-attribute similarities with the given weights
+func Weighted(w Weights) Func {
+	total := w.Age + w.Height + w.Community + w.District + w.Education + w.Occupation
+
+	return func(profile, candidate *model.UserProfile) float64 {
+		if total <= 0 {
+			return 0
+		}
+
+		score := w.Age*closeness(float64(profile.Age()-candidate.Age()), ageScaleYears) +
+			w.Height*closeness(profile.Height-candidate.Height, heightScaleCm) +
+			w.Community*equal(string(profile.Community), string(candidate.Community)) +
+			w.District*equal(string(profile.HomeDistrict), string(candidate.HomeDistrict)) +
+			w.Education*wordOverlap(profile.Education, candidate.Education) +
+			w.Occupation*wordOverlap(profile.Occupation, candidate.Occupation)
+
+		return score / total
+	}
+}
+
+// closeness maps a difference to 1 when equal, falling linearly to 0 at scale
+func closeness(diff, scale float64) float64 {
+	return math.Max(0, 1-math.Abs(diff)/scale)
+}
+
+// equal returns 1 when both values are set and the same, ignoring case
+func equal(a, b string) float64 {
+	if a != "" && strings.EqualFold(a, b) {
+		return 1
+	}
+	return 0
+}
+
+// wordOverlap returns the Jaccard similarity of the words of two free-text values,
+// so "Software Engineer" and "Senior Software Engineer" score 2/3
+func wordOverlap(a, b string) float64 {
+	wordsA, wordsB := words(a), words(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+// words returns the set of lower-case words of s
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,120 @@
+package hijri
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalid is returned for text that is not a Hijri date, or a day past the end of its month
+	ErrInvalid = errors.New("invalid Hijri date")
+
+	// ErrOutOfRange is returned for dates the Umm al-Qura table doesn't cover
+	ErrOutOfRange = errors.New("date outside the supported Hijri range")
+)
+
+// lastYear is the last Hijri year in the table
+const lastYear = firstYear + len(monthLengths) - 1
+
+// monthNames are the transliterated names of the Hijri months
+var monthNames = [12]string{
+	"Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani", "Jumada al-Ula", "Jumada al-Akhirah",
+	"Rajab", "Shaban", "Ramadan", "Shawwal", "Dhu al-Qadah", "Dhu al-Hijjah",
+}
+
+// Date is a day of the Umm al-Qura calendar
+type Date struct {
+	Year  int
+	Month int // 1 for Muharram to 12 for Dhu al-Hijjah
+	Day   int
+}
+
+// Parse reads a Hijri date written as YYYY-MM-DD, e.g. 1420-09-15 for 15 Ramadan 1420
+func Parse(s string) (Date, error) {
+	var d Date
+	var rest string
+	n, _ := fmt.Sscanf(s, "%4d-%2d-%2d%s", &d.Year, &d.Month, &d.Day, &rest)
+	if n != 3 || len(s) != len("2006-01-02") {
+		return Date{}, ErrInvalid
+	}
+
+	if _, err := d.Gregorian(); err != nil {
+		return Date{}, err
+	}
+	return d, nil
+}
+
+// FromGregorian returns the Hijri date of the calendar day of t
+func FromGregorian(t time.Time) (Date, error) {
+	days := daysSinceEpoch(t) - firstDay
+	if days < 0 {
+		return Date{}, ErrOutOfRange
+	}
+
+	for year := firstYear; year <= lastYear; year++ {
+		for month := 1; month <= 12; month++ {
+			length := monthLength(year, month)
+			if days < length {
+				return Date{Year: year, Month: month, Day: days + 1}, nil
+			}
+			days -= length
+		}
+	}
+
+	return Date{}, ErrOutOfRange
+}
+
+// Gregorian returns the Gregorian date of d at midnight UTC
+func (d Date) Gregorian() (time.Time, error) {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 {
+		return time.Time{}, ErrInvalid
+	}
+	if d.Year < firstYear || d.Year > lastYear {
+		return time.Time{}, ErrOutOfRange
+	}
+	if d.Day > monthLength(d.Year, d.Month) {
+		return time.Time{}, ErrInvalid
+	}
+
+	days := firstDay + d.Day - 1
+	for year := firstYear; year <= d.Year; year++ {
+		for month := 1; month <= 12 && (year < d.Year || month < d.Month); month++ {
+			days += monthLength(year, month)
+		}
+	}
+
+	return time.Unix(int64(days)*86400, 0).UTC(), nil
+}
+
+// String formats d as YYYY-MM-DD
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Format spells d out for display, e.g. "15 Ramadan 1420 AH"
+func (d Date) Format() string {
+	if d.Month < 1 || d.Month > 12 {
+		return d.String()
+	}
+	return fmt.Sprintf("%d %s %d AH", d.Day, monthNames[d.Month-1], d.Year)
+}
+
+// Range returns the first and last Hijri years that can be converted
+func Range() (int, int) {
+	return firstYear, lastYear
+}
+
+// monthLength returns the number of days of a month covered by the table
+func monthLength(year, month int) int {
+	if monthLengths[year-firstYear]&(1<<(month-1)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// daysSinceEpoch returns the calendar day of t as days since 1 January 1970
+func daysSinceEpoch(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
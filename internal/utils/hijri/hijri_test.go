@@ -0,0 +1,133 @@
+package hijri
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Pairs from the published Umm al-Qura calendar, spread across the table
+var knownDates = []struct {
+	name      string
+	hijri     Date
+	gregorian time.Time
+}{
+	{"first day of the table", Date{1365, 1, 1}, date(1945, time.December, 6)},
+	{"mid-year", Date{1380, 6, 15}, date(1960, time.December, 4)},
+	{"1 Muharram 1400", Date{1400, 1, 1}, date(1979, time.November, 21)},
+	{"last day of 1401", Date{1401, 12, 30}, date(1981, time.October, 28)},
+	{"1 Muharram 1402", Date{1402, 1, 1}, date(1981, time.October, 29)},
+	{"1 Muharram 1420", Date{1420, 1, 1}, date(1999, time.April, 17)},
+	{"15 Ramadan 1420", Date{1420, 9, 15}, date(1999, time.December, 23)},
+	{"Eid al-Fitr 1435", Date{1435, 10, 1}, date(2014, time.July, 28)},
+	{"1 Muharram 1440", Date{1440, 1, 1}, date(2018, time.September, 11)},
+	{"1 Ramadan 1444", Date{1444, 9, 1}, date(2023, time.March, 23)},
+	{"1 Ramadan 1445", Date{1445, 9, 1}, date(2024, time.March, 11)},
+	{"Eid al-Fitr 1445", Date{1445, 10, 1}, date(2024, time.April, 10)},
+	{"1 Muharram 1446", Date{1446, 1, 1}, date(2024, time.July, 7)},
+	{"future date", Date{1460, 3, 12}, date(2038, time.April, 17)},
+	{"29th of a month", Date{1480, 7, 29}, date(2058, time.January, 24)},
+	{"last day of the table", Date{1500, 12, 30}, date(2077, time.November, 16)},
+}
+
+func TestGregorian(t *testing.T) {
+	for _, tt := range knownDates {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.hijri.Gregorian()
+			if err != nil {
+				t.Fatalf("%s.Gregorian() error = %v", tt.hijri, err)
+			}
+			if !got.Equal(tt.gregorian) {
+				t.Errorf("%s.Gregorian() = %s, want %s", tt.hijri, got.Format("2006-01-02"), tt.gregorian.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestFromGregorian(t *testing.T) {
+	for _, tt := range knownDates {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromGregorian(tt.gregorian)
+			if err != nil {
+				t.Fatalf("FromGregorian(%s) error = %v", tt.gregorian.Format("2006-01-02"), err)
+			}
+			if got != tt.hijri {
+				t.Errorf("FromGregorian(%s) = %s, want %s", tt.gregorian.Format("2006-01-02"), got, tt.hijri)
+			}
+		})
+	}
+}
+
+func TestYearLengths(t *testing.T) {
+	// A lunar year has 354 or 355 days; anything else is a mistake in the table
+	for year := firstYear; year <= lastYear; year++ {
+		days := 0
+		for month := 1; month <= 12; month++ {
+			days += monthLength(year, month)
+		}
+		if days != 354 && days != 355 {
+			t.Errorf("year %d has %d days, want 354 or 355", year, days)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	first, _ := Date{firstYear, 1, 1}.Gregorian()
+	last, _ := Date{lastYear, 12, monthLength(lastYear, 12)}.Gregorian()
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		d, err := FromGregorian(day)
+		if err != nil {
+			t.Fatalf("FromGregorian(%s) error = %v", day.Format("2006-01-02"), err)
+		}
+		back, err := d.Gregorian()
+		if err != nil || !back.Equal(day) {
+			t.Fatalf("FromGregorian(%s) = %s, which converts back to %s (error %v)",
+				day.Format("2006-01-02"), d, back.Format("2006-01-02"), err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Date
+		wantErr error
+	}{
+		{"valid date", "1420-09-15", Date{1420, 9, 15}, nil},
+		{"30th of a 30-day month", "1401-12-30", Date{1401, 12, 30}, nil},
+		{"30th of a 29-day month", "1446-01-30", Date{}, ErrInvalid},
+		{"month 13", "1420-13-01", Date{}, ErrInvalid},
+		{"day 0", "1420-09-00", Date{}, ErrInvalid},
+		{"not zero-padded", "1420-9-15", Date{}, ErrInvalid},
+		{"trailing text", "1420-09-15x", Date{}, ErrInvalid},
+		{"not a date", "yesterday", Date{}, ErrInvalid},
+		{"before the table", "1364-12-01", Date{}, ErrOutOfRange},
+		{"after the table", "1501-01-01", Date{}, ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromGregorianOutOfRange(t *testing.T) {
+	for _, day := range []time.Time{date(1945, time.December, 5), date(2077, time.November, 17)} {
+		if _, err := FromGregorian(day); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("FromGregorian(%s) error = %v, want %v", day.Format("2006-01-02"), err, ErrOutOfRange)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package hijri
+
+// firstYear is the first Hijri year in the table
+const firstYear = 1365
+
+// firstDay is 1 Muharram of firstYear (6 December 1945) as days since the Unix epoch
+const firstDay = -8792
+
+// monthLengths holds, for each Hijri year from firstYear to 1500 AH (2077), which months
+// have 30 days rather than 29: bit 0 is Muharram and bit 11 is Dhu al-Hijjah. The lengths
+// follow the Umm al-Qura calendar of Saudi Arabia as published, and match the
+// islamic-umalqura calendar of ICU, which carries the same table.
+var monthLengths = [...]uint16{
+	0xa93, 0x52b, 0xa5b, 0x53a, 0x6b5, 0xea9, 0xd52, 0xd29, 0xa55, 0x4ad,
+	0x56d, 0xaea, 0x6e4, 0xed1, 0xda2, 0xaaa, 0x95a, 0x2da, 0x5b9, 0xbb2,
+	0x764, 0x6c9, 0x555, 0x2ab, 0x4db, 0xaba, 0x5b4, 0xda9, 0xd52, 0xaa5,
+	0x92d, 0x26d, 0x8ed, 0x2da, 0xad5, 0xaa5, 0xa4b, 0x497, 0x937, 0x2b6,
+	0x975, 0xd69, 0xd52, 0xc95, 0x92b, 0x25b, 0x4db, 0x9d5, 0x5d2, 0xda5,
+	0xd4a, 0xa95, 0x54d, 0xaad, 0x3aa, 0xbd2, 0xbc4, 0xb89, 0xa95, 0x52d,
+	0x5ad, 0xb6a, 0x6d4, 0xdc9, 0xd92, 0xaa6, 0x956, 0x2ae, 0x56d, 0x36a,
+	0xb55, 0xaaa, 0x94d, 0x49d, 0x95d, 0x2ba, 0x5b5, 0x5aa, 0xd55, 0xa9a,
+	0x92e, 0x26e, 0x55d, 0xada, 0x6d4, 0x6a5, 0xb27, 0xa4d, 0x4ad, 0x56d,
+	0xb5a, 0x754, 0xf49, 0xe92, 0xd26, 0xa56, 0x356, 0x6b5, 0xbaa, 0xb92,
+	0xb25, 0x68b, 0xa9b, 0x55a, 0xada, 0x5b4, 0xda9, 0xb52, 0xa9a, 0x536,
+	0x276, 0x575, 0xaf2, 0x6d4, 0x6a9, 0x555, 0x2ad, 0x4bd, 0x9ba, 0x574,
+	0xb69, 0xb52, 0xa95, 0x52d, 0xa5d, 0x4da, 0xad9, 0x6b2, 0xe95, 0xe2a,
+	0xc96, 0x92e, 0xaad, 0x56a, 0xd65, 0xd4a,
+}
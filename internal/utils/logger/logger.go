@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 
+	"github.com/mohamedfawas/user-service-qubool-kallyaanam/internal/utils/requestinfo"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -55,7 +56,7 @@ func (l *Logger) UserProfileEvent(ctx context.Context, event string, userID stri
 	}
 
 	// Add request ID if available in context
-	if requestID, ok := ctx.Value("request_id").(string); ok {
+	if requestID := requestinfo.RequestID(ctx); requestID != "" {
 		baseFields = append(baseFields, zap.String("request_id", requestID))
 	}
 
@@ -0,0 +1,126 @@
+package phonetic
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// wordAliases expands common abbreviations to the full name they stand for
+var wordAliases = map[string]string{
+	"md":   "muhammad",
+	"mhd":  "muhammad",
+	"mohd": "muhammad",
+	"muhd": "muhammad",
+	"abd":  "abdul",
+}
+
+// substitutions folds transliteration variants common in Malayalam and Arabic
+// names onto a single spelling. Order matters: longer patterns are applied first.
+// Upper-case letters are used as placeholders for sounds that must stay distinct.
+var substitutions = []struct {
+	from string
+	to   string
+}{
+	{"x", "ks"},
+	{"sh", "X"},
+	{"ch", "C"},
+	{"zh", "s"},
+	{"ph", "f"},
+	{"kh", "k"},
+	{"gh", "g"},
+	{"th", "t"},
+	{"dh", "d"},
+	{"bh", "b"},
+	{"jh", "j"},
+	{"ck", "k"},
+	{"q", "k"},
+	{"c", "k"},
+	{"z", "s"},
+	{"w", "v"},
+}
+
+// Key returns the phonetic key for a full name. Each word is encoded separately
+// and the codes are joined with single spaces so that individual words can be
+// matched, e.g. "Muhammed Fathima" and "Mohamad Fatima" both yield "MHMD FTM".
+func Key(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(foldAccents(name)), func(r rune) bool {
+		return r < 'a' || r > 'z'
+	})
+
+	codes := make([]string, 0, len(words))
+	for _, word := range words {
+		if code := encodeWord(word); code != "" {
+			codes = append(codes, code)
+		}
+	}
+
+	return strings.Join(codes, " ")
+}
+
+// foldAccents strips diacritics so that "Fátima" is encoded like "Fatima"
+// instead of being split at the accented letter
+func foldAccents(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		return name
+	}
+	return folded
+}
+
+// Tokens returns the individual word codes of a name's phonetic key
+func Tokens(name string) []string {
+	return strings.Fields(Key(name))
+}
+
+// encodeWord reduces a single lower-case word to its consonant skeleton
+func encodeWord(word string) string {
+	if alias, ok := wordAliases[word]; ok {
+		word = alias
+	}
+
+	for _, s := range substitutions {
+		word = strings.ReplaceAll(word, s.from, s.to)
+	}
+
+	var b strings.Builder
+	var last rune
+	prevVowel := false
+
+	for i, r := range word {
+		switch {
+		case i == 0 && isVowel(r):
+			// A leading vowel is kept as a single marker so that Ayesha/Aisha match
+			r = 'A'
+		case isVowel(r) || r == 'y' && i > 0:
+			prevVowel = true
+			last = 0
+			continue
+		case r == 'h' && (!prevVowel || i == len(word)-1):
+			// Aspiration after a consonant or a trailing h carries no meaning
+			continue
+		}
+
+		prevVowel = r == 'A'
+		r = unicode.ToUpper(r)
+		if r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+
+	return b.String()
+}
+
+// isVowel reports whether r is a lower-case vowel
+func isVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package phonetic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyMatchesSpellingVariants(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"Muhammed and Mohamad", "Muhammed Fathima", "Mohamad Fatima"},
+		{"abbreviated Muhammad", "Md Ashraf", "Muhammad Ashraf"},
+		{"leading vowels", "Ayesha", "Aisha"},
+		{"z and s", "Zubair", "Subair"},
+		{"zh and z", "Azhar", "Azar"},
+		{"zh and z in Mazhar", "Mazhar", "Mazar"},
+		{"accented letters", "Fátima Zoë", "Fatima Zoe"},
+		{"kh and k", "Khadeeja", "Kadeeja"},
+		{"abbreviated Abdul", "Abd Rahman", "Abdul Rahman"},
+		{"case and punctuation", "K.P. ANWAR", "k p anwar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ka, kb := Key(tt.a), Key(tt.b); ka != kb {
+				t.Errorf("Key(%q) = %q, Key(%q) = %q, want equal", tt.a, ka, tt.b, kb)
+			}
+		})
+	}
+}
+
+func TestKeyKeepsDistinctSounds(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"sh and s", "Shameer", "Sameer"},
+		{"Fathima and Fathimath", "Fathima", "Fathimath"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ka, kb := Key(tt.a), Key(tt.b); ka == kb {
+				t.Errorf("Key(%q) = Key(%q) = %q, want different keys", tt.a, tt.b, ka)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"two words", "Muhammed Fathima", "MHMD FTM"},
+		{"zh", "Azhar", "ASR"},
+		{"accented letter", "Fátima", "FTM"},
+		{"x", "Xavier", "KSVR"},
+		{"q", "Quadir", "KDR"},
+		{"no letters", "123", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Key(tt.input); got != tt.want {
+				t.Errorf("Key(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"Kunhi Mohammed", []string{"KN", "MHMD"}},
+		{"  Abdul   Rahman ", []string{"ABDL", "RHMN"}},
+		{"123", []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := Tokens(tt.input); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tokens(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package profilecode
+
+import (
+	"errors"
+	"strings"
+)
+
+// Prefix starts every profile code
+const Prefix = "QK-"
+
+// minSerialDigits is the length of the smallest serial, see profile_code_seq
+const minSerialDigits = 5
+
+// ErrInvalid is returned for text that is not a well-formed profile code
+var ErrInvalid = errors.New("invalid profile code")
+
+// Parse normalizes a profile code as typed or read out by a person and verifies its
+// check digit. Case, spaces and the dash don't matter and the prefix may be left out,
+// so "qk 104521" and "104521" both yield "QK-104521". Codes are assigned by the
+// database (next_profile_code), whose check digit must match CheckDigit.
+func Parse(s string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(s))
+	digits = strings.TrimPrefix(digits, strings.TrimSuffix(Prefix, "-"))
+
+	if len(digits) < minSerialDigits+1 {
+		return "", ErrInvalid
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalid
+		}
+	}
+
+	serial, check := digits[:len(digits)-1], int(digits[len(digits)-1]-'0')
+	if CheckDigit(serial) != check {
+		return "", ErrInvalid
+	}
+
+	return Prefix + digits, nil
+}
+
+// CheckDigit returns the Luhn check digit of a string of decimal digits, which
+// catches any single mistyped digit and almost all swapped neighbours
+func CheckDigit(digits string) int {
+	total := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		// Every second digit from the right is doubled, starting with the rightmost
+		// since the check digit will be appended after it
+		if i%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		total += d
+	}
+
+	return (10 - total%10) % 10
+}
@@ -0,0 +1,84 @@
+package profilecode
+
+import (
+	"errors"
+	"testing"
+)
+
+// Check digits computed by profile_code_check_digit in migration 000014
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   int
+	}{
+		{"10000", 8},
+		{"10001", 6},
+		{"10452", 1},
+		{"12345", 5},
+		{"99999", 5},
+		{"7992739871", 3},
+	}
+
+	for _, tt := range tests {
+		if got := CheckDigit(tt.digits); got != tt.want {
+			t.Errorf("CheckDigit(%q) = %d, want %d", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"canonical", "QK-104521", "QK-104521", false},
+		{"first code of the sequence", "QK-100008", "QK-100008", false},
+		{"lower case", "qk-104521", "QK-104521", false},
+		{"space in place of the dash", "qk 104521", "QK-104521", false},
+		{"no dash", "QK104521", "QK-104521", false},
+		{"no prefix", "104521", "QK-104521", false},
+		{"spaces between digits", " QK 104 521 ", "QK-104521", false},
+		{"longer serial", "QK-79927398713", "QK-79927398713", false},
+		{"mistyped digit", "QK-104621", "", true},
+		{"mistyped check digit", "QK-104522", "", true},
+		{"swapped neighbours", "QK-105421", "", true},
+		{"too short", "QK-1000", "", true},
+		{"letters in the serial", "QK-10A521", "", true},
+		{"other prefix", "AB-104521", "", true},
+		{"empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Parse(%q) = %q, %v, want ErrInvalid", tt.input, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Parse(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// A single mistyped digit anywhere in a code is always caught
+func TestParseRejectsEverySingleDigitError(t *testing.T) {
+	const code = "104521"
+
+	for i := 0; i < len(code); i++ {
+		for d := byte('0'); d <= '9'; d++ {
+			if d == code[i] {
+				continue
+			}
+			typo := code[:i] + string(d) + code[i+1:]
+			if _, err := Parse(typo); err == nil {
+				t.Errorf("Parse(%q) accepted a mistyped %q", typo, code)
+			}
+		}
+	}
+}
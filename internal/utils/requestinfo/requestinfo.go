@@ -0,0 +1,51 @@
+// Package requestinfo carries details of the HTTP request being served, such as its
+// request ID and client IP, through a context.Context.
+package requestinfo
+
+import (
+	"context"
+	"regexp"
+)
+
+// MaxRequestIDLength is the longest request ID accepted from a client. Request IDs are
+// stored in audit records, whose request_id columns hold at most this many characters.
+const MaxRequestIDLength = 64
+
+// contextKey is the type of the context keys of this package, so that they can't collide
+// with keys defined elsewhere
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	clientIPKey
+)
+
+// requestIDPattern matches the request IDs accepted from clients
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// ValidRequestID reports whether a request ID sent by a client may be used as is
+func ValidRequestID(requestID string) bool {
+	return requestIDPattern.MatchString(requestID)
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, or "" if there is none
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithClientIP returns a copy of ctx carrying the IP address of the client
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey, clientIP)
+}
+
+// ClientIP returns the client IP address carried by ctx, or "" if there is none
+func ClientIP(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPKey).(string)
+	return clientIP
+}
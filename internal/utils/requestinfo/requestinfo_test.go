@@ -0,0 +1,50 @@
+package requestinfo
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		want      bool
+	}{
+		{name: "uuid", requestID: "3f2b8c1e-5d4a-4e7b-9c0d-1a2b3c4d5e6f", want: true},
+		{name: "dotted", requestID: "edge.req_42", want: true},
+		{name: "longest", requestID: strings.Repeat("a", MaxRequestIDLength), want: true},
+		{name: "empty", requestID: "", want: false},
+		{name: "too long", requestID: strings.Repeat("a", MaxRequestIDLength+1), want: false},
+		{name: "spaces", requestID: "req 42", want: false},
+		{name: "newline", requestID: "req\n42", want: false},
+		{name: "non-ascii", requestID: "req-é", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidRequestID(tt.requestID); got != tt.want {
+				t.Errorf("ValidRequestID(%q) = %v, want %v", tt.requestID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextValues(t *testing.T) {
+	ctx := context.Background()
+	if got := RequestID(ctx); got != "" {
+		t.Errorf("RequestID() = %q, want empty", got)
+	}
+	if got := ClientIP(ctx); got != "" {
+		t.Errorf("ClientIP() = %q, want empty", got)
+	}
+
+	ctx = WithClientIP(WithRequestID(ctx, "req-1"), "203.0.113.7")
+	if got := RequestID(ctx); got != "req-1" {
+		t.Errorf("RequestID() = %q, want %q", got, "req-1")
+	}
+	if got := ClientIP(ctx); got != "203.0.113.7" {
+		t.Errorf("ClientIP() = %q, want %q", got, "203.0.113.7")
+	}
+}
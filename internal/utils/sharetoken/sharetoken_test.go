@@ -0,0 +1,64 @@
+package sharetoken
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerify(t *testing.T) {
+	signer := NewSigner("secret")
+	id := uuid.New()
+	now := time.Now()
+	token := signer.Sign(id, now.Add(time.Hour))
+
+	got, err := signer.Verify(token, now)
+	if err != nil || got != id {
+		t.Fatalf("Verify() = %s, %v, want %s, nil", got, err, id)
+	}
+
+	if _, err := signer.Verify(token, now.Add(2*time.Hour)); !errors.Is(err, ErrExpired) {
+		t.Errorf("Verify() after expiry error = %v, want %v", err, ErrExpired)
+	}
+	if _, err := NewSigner("other").Verify(token, now); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Verify() with another secret error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+func TestVerifyData(t *testing.T) {
+	signer := NewSigner("secret")
+	data := []byte(`{"i":"cursor"}`)
+	token := signer.SignData(data, []byte("filter"))
+	_, signature, _ := strings.Cut(token, ".")
+	altered := base64.RawURLEncoding.EncodeToString([]byte(`{"i":"other"}`)) + "." + signature
+
+	got, err := signer.VerifyData(token, []byte("filter"))
+	if err != nil || string(got) != string(data) {
+		t.Fatalf("VerifyData() = %q, %v, want %q, nil", got, err, data)
+	}
+
+	tests := []struct {
+		name    string
+		signer  *Signer
+		token   string
+		binding string
+	}{
+		{"another binding", signer, token, "other filter"},
+		{"another secret", NewSigner("other"), token, "filter"},
+		{"altered data", signer, altered, "filter"},
+		{"no signature", signer, "e30", "filter"},
+		{"empty secret", NewSigner(""), NewSigner("").SignData(data, []byte("filter")), "filter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.VerifyData(tt.token, []byte(tt.binding)); !errors.Is(err, ErrInvalid) {
+				t.Errorf("VerifyData() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_user_profiles_name_phonetic_words_trgm;
+DROP INDEX IF EXISTS idx_user_profiles_name_trgm;
+
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS name_phonetic;
+
+-- Note: The "pg_trgm" extension is left installed.
@@ -0,0 +1,10 @@
+-- Enable trigram matching for fuzzy name search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Phonetic key of the name, maintained by the application
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS name_phonetic VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Trigram indexes for similarity and substring matching. Name search matches word
+-- prefixes with (' ' || name_phonetic) LIKE '% TOKEN%', so that expression is indexed
+CREATE INDEX IF NOT EXISTS idx_user_profiles_name_trgm ON user_profiles USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_user_profiles_name_phonetic_words_trgm ON user_profiles USING GIN ((' ' || name_phonetic) gin_trgm_ops);
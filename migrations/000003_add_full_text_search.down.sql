@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_user_profiles_search_vector;
+
+DROP TRIGGER IF EXISTS trg_user_profiles_search_vector ON user_profiles;
+DROP FUNCTION IF EXISTS user_profiles_search_vector_update();
+
+ALTER TABLE user_profiles
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS occupation,
+    DROP COLUMN IF EXISTS about_me;
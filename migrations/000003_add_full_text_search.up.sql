@@ -0,0 +1,27 @@
+-- Narrative and occupation fields used by keyword search
+ALTER TABLE user_profiles
+    ADD COLUMN IF NOT EXISTS about_me TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS occupation VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+-- Keep the search vector in sync with the searchable fields.
+-- Weights: A = name, B = occupation and location, C = about me
+CREATE OR REPLACE FUNCTION user_profiles_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.occupation, '')), 'B') ||
+        setweight(to_tsvector('english', concat_ws(' ', NEW.home_district::text, NEW.nationality::text)), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.about_me, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_user_profiles_search_vector
+    BEFORE INSERT OR UPDATE ON user_profiles
+    FOR EACH ROW EXECUTE FUNCTION user_profiles_search_vector_update();
+
+-- Populate the vector for existing rows (fires the trigger)
+UPDATE user_profiles SET search_vector = NULL;
+
+CREATE INDEX IF NOT EXISTS idx_user_profiles_search_vector ON user_profiles USING GIN (search_vector);
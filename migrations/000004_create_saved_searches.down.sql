@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_search_alerts_user_id;
+DROP TABLE IF EXISTS search_alerts;
+
+DROP INDEX IF EXISTS idx_saved_searches_due;
+DROP INDEX IF EXISTS idx_saved_searches_deleted_at;
+DROP INDEX IF EXISTS idx_saved_searches_user_id;
+DROP TABLE IF EXISTS saved_searches;
+
+DROP TYPE IF EXISTS alert_frequency_type;
@@ -0,0 +1,35 @@
+CREATE TYPE alert_frequency_type AS ENUM (
+    'instant', 'daily', 'weekly'
+);
+
+-- Saved search filters, stored as the serialized repository.ProfileFilter
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL,
+    frequency alert_frequency_type NOT NULL,
+    last_run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_saved_searches_user_id ON saved_searches(user_id);
+CREATE INDEX idx_saved_searches_deleted_at ON saved_searches(deleted_at);
+CREATE INDEX idx_saved_searches_due ON saved_searches(frequency, last_run_at) WHERE deleted_at IS NULL;
+
+-- New matches found for saved searches
+CREATE TABLE IF NOT EXISTS search_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    saved_search_id UUID NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    notified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    -- Alert about a profile at most once per saved search
+    CONSTRAINT unique_search_alert UNIQUE (saved_search_id, profile_id)
+);
+
+CREATE INDEX idx_search_alerts_user_id ON search_alerts(user_id, created_at DESC);
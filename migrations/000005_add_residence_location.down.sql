@@ -0,0 +1,17 @@
+CREATE OR REPLACE FUNCTION user_profiles_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.occupation, '')), 'B') ||
+        setweight(to_tsvector('english', concat_ws(' ', NEW.home_district::text, NEW.nationality::text)), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.about_me, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_user_profiles_location;
+
+ALTER TABLE user_profiles
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS residence_city;
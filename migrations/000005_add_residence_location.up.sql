@@ -0,0 +1,21 @@
+-- Residence city and its coordinates from the bundled gazetteer
+ALTER TABLE user_profiles
+    ADD COLUMN IF NOT EXISTS residence_city VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS latitude DECIMAL(9,6),
+    ADD COLUMN IF NOT EXISTS longitude DECIMAL(9,6);
+
+-- Supports the bounding-box prefilter of distance searches
+CREATE INDEX IF NOT EXISTS idx_user_profiles_location ON user_profiles(latitude, longitude)
+    WHERE latitude IS NOT NULL;
+
+-- Include the residence city in keyword search
+CREATE OR REPLACE FUNCTION user_profiles_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.occupation, '')), 'B') ||
+        setweight(to_tsvector('english', concat_ws(' ', NEW.residence_city, NEW.home_district::text, NEW.nationality::text)), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.about_me, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_recommendations_candidate;
+DROP TABLE IF EXISTS recommendations;
+DROP TABLE IF EXISTS recommendation_runs;
+DROP TYPE IF EXISTS recommendation_status_type;
+
+DROP INDEX IF EXISTS idx_profile_blocks_blocked_profile_id;
+DROP TABLE IF EXISTS profile_blocks;
+
+DROP TABLE IF EXISTS partner_preferences;
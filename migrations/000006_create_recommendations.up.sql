@@ -0,0 +1,55 @@
+-- Partner preferences of a profile, stored as the serialized repository.ProfileFilter
+CREATE TABLE IF NOT EXISTS partner_preferences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    criteria JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    -- One set of preferences per profile
+    CONSTRAINT unique_partner_preference UNIQUE (profile_id)
+);
+
+-- Profiles blocked by other profiles; a block hides both profiles from each other
+CREATE TABLE IF NOT EXISTS profile_blocks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    blocked_profile_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_profile_block UNIQUE (profile_id, blocked_profile_id)
+);
+
+CREATE INDEX idx_profile_blocks_blocked_profile_id ON profile_blocks(blocked_profile_id);
+
+CREATE TYPE recommendation_status_type AS ENUM (
+    'new', 'skipped', 'not_interested'
+);
+
+-- Daily recommendation runs, one per profile and day, even when no candidate was found
+CREATE TABLE IF NOT EXISTS recommendation_runs (
+    profile_id UUID NOT NULL,
+    generated_on DATE NOT NULL,
+    candidates INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (profile_id, generated_on)
+);
+
+-- Ranked candidates recommended to a profile
+CREATE TABLE IF NOT EXISTS recommendations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    candidate_id UUID NOT NULL,
+    generated_on DATE NOT NULL,
+    rank INTEGER NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    status recommendation_status_type NOT NULL DEFAULT 'new',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_recommendation UNIQUE (profile_id, generated_on, candidate_id)
+);
+
+-- Supports excluding previously recommended and declined candidates
+CREATE INDEX idx_recommendations_candidate ON recommendations(profile_id, candidate_id);
@@ -0,0 +1,13 @@
+CREATE OR REPLACE FUNCTION user_profiles_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(NEW.occupation, '')), 'B') ||
+        setweight(to_tsvector('english', concat_ws(' ', NEW.residence_city, NEW.home_district::text, NEW.nationality::text)), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.about_me, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE user_profiles
+    DROP COLUMN IF EXISTS education;
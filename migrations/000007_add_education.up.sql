@@ -0,0 +1,14 @@
+-- Highest education of the profile, used for similar-profile matching and keyword search
+ALTER TABLE user_profiles
+    ADD COLUMN IF NOT EXISTS education VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE OR REPLACE FUNCTION user_profiles_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', concat_ws(' ', NEW.occupation, NEW.education)), 'B') ||
+        setweight(to_tsvector('english', concat_ws(' ', NEW.residence_city, NEW.home_district::text, NEW.nationality::text)), 'B') ||
+        setweight(to_tsvector('english', coalesce(NEW.about_me, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_contact_reveals_quota;
+DROP INDEX IF EXISTS unique_contact_reveal;
+DROP TABLE IF EXISTS contact_reveals;
+DROP TYPE IF EXISTS contact_reveal_basis_type;
+DROP TYPE IF EXISTS contact_reveal_outcome_type;
+
+DROP TABLE IF EXISTS contact_details;
+
+DROP INDEX IF EXISTS idx_interests_receiver;
+DROP TABLE IF EXISTS interests;
+DROP TYPE IF EXISTS interest_status_type;
@@ -0,0 +1,58 @@
+CREATE TYPE interest_status_type AS ENUM (
+    'pending', 'accepted', 'declined'
+);
+
+-- Interests sent from one profile to another
+CREATE TABLE IF NOT EXISTS interests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    sender_profile_id UUID NOT NULL,
+    receiver_profile_id UUID NOT NULL,
+    status interest_status_type NOT NULL DEFAULT 'pending',
+    message VARCHAR(500) NOT NULL DEFAULT '',
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_interest UNIQUE (sender_profile_id, receiver_profile_id)
+);
+
+CREATE INDEX idx_interests_receiver ON interests(receiver_profile_id, created_at DESC);
+
+-- Contact details, encrypted by the application with AES-GCM
+CREATE TABLE IF NOT EXISTS contact_details (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    details_encrypted BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_contact_details UNIQUE (profile_id)
+);
+
+CREATE TYPE contact_reveal_outcome_type AS ENUM (
+    'revealed', 'already_revealed', 'denied'
+);
+
+CREATE TYPE contact_reveal_basis_type AS ENUM (
+    'interest', 'quota'
+);
+
+-- Audit trail of every contact reveal request
+CREATE TABLE IF NOT EXISTS contact_reveals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    viewer_profile_id UUID NOT NULL,
+    viewer_user_id UUID NOT NULL,
+    profile_id UUID NOT NULL,
+    outcome contact_reveal_outcome_type NOT NULL,
+    basis contact_reveal_basis_type,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Contact details are handed out once per viewer and profile
+CREATE UNIQUE INDEX unique_contact_reveal ON contact_reveals(viewer_profile_id, profile_id)
+    WHERE outcome = 'revealed';
+
+-- Supports counting the reveals charged to the monthly quota
+CREATE INDEX idx_contact_reveals_quota ON contact_reveals(viewer_profile_id, created_at)
+    WHERE outcome = 'revealed' AND basis = 'quota';
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_profile_views_last_viewed_at;
+DROP TABLE IF EXISTS profile_views;
+
+DROP TABLE IF EXISTS payment_events;
+
+DROP INDEX IF EXISTS idx_subscriptions_user_expires_at;
+DROP TABLE IF EXISTS subscriptions;
+DROP TYPE IF EXISTS subscription_status_type;
+
+DROP TABLE IF EXISTS membership_plans;
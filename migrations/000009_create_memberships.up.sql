@@ -0,0 +1,72 @@
+-- Membership plans and the entitlements they grant
+CREATE TABLE IF NOT EXISTS membership_plans (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    price_paise BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'INR',
+    duration_days INTEGER NOT NULL DEFAULT 0,
+    monthly_contact_reveals INTEGER NOT NULL DEFAULT 0,
+    who_viewed_me BOOLEAN NOT NULL DEFAULT FALSE,
+    advanced_filters BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- The free plan applies to every user without an active subscription
+INSERT INTO membership_plans (code, name, price_paise, duration_days, monthly_contact_reveals, who_viewed_me, advanced_filters)
+VALUES
+    ('free', 'Free', 0, 0, 5, FALSE, FALSE),
+    ('premium_3m', 'Premium - 3 months', 299900, 90, 30, TRUE, TRUE),
+    ('premium_12m', 'Premium - 12 months', 799900, 365, 50, TRUE, TRUE)
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TYPE subscription_status_type AS ENUM (
+    'pending', 'active', 'failed', 'cancelled'
+);
+
+-- Subscriptions of users to paid plans. Pending subscriptions await payment;
+-- active ones grant their plan from starts_at until expires_at.
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    plan_code VARCHAR(50) NOT NULL REFERENCES membership_plans(code),
+    status subscription_status_type NOT NULL DEFAULT 'pending',
+    provider VARCHAR(50) NOT NULL,
+    provider_reference VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_subscription_reference UNIQUE (provider, provider_reference)
+);
+
+CREATE INDEX idx_subscriptions_user_expires_at ON subscriptions(user_id, expires_at) WHERE status = 'active';
+
+-- Payment provider webhook events, kept so that redelivered events are applied only once
+CREATE TABLE IF NOT EXISTS payment_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    provider VARCHAR(50) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    subscription_id UUID,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_payment_event UNIQUE (provider, event_id)
+);
+
+-- Who viewed a profile, one row per viewer with the latest view
+CREATE TABLE IF NOT EXISTS profile_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    viewer_profile_id UUID NOT NULL,
+    view_count INTEGER NOT NULL DEFAULT 1,
+    last_viewed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_profile_view UNIQUE (profile_id, viewer_profile_id)
+);
+
+CREATE INDEX idx_profile_views_last_viewed_at ON profile_views(profile_id, last_viewed_at DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_profile_boosts_user_created_at;
+DROP INDEX IF EXISTS idx_profile_boosts_profile_id;
+DROP INDEX IF EXISTS idx_profile_boosts_window;
+DROP TABLE IF EXISTS profile_boosts;
+
+ALTER TABLE membership_plans DROP COLUMN IF EXISTS monthly_profile_boosts;
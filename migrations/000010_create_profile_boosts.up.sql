@@ -0,0 +1,25 @@
+-- Boosts allowed per month by each membership plan
+ALTER TABLE membership_plans ADD COLUMN IF NOT EXISTS monthly_profile_boosts INTEGER NOT NULL DEFAULT 0;
+
+UPDATE membership_plans SET monthly_profile_boosts = 2 WHERE code = 'premium_3m';
+UPDATE membership_plans SET monthly_profile_boosts = 4 WHERE code = 'premium_12m';
+
+-- Time-bounded boosts that feature a profile near the top of search results,
+-- with the impressions and clicks they generated
+CREATE TABLE IF NOT EXISTS profile_boosts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    impressions BIGINT NOT NULL DEFAULT 0,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_boost_window CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_profile_boosts_window ON profile_boosts(ends_at, starts_at);
+CREATE INDEX idx_profile_boosts_profile_id ON profile_boosts(profile_id);
+CREATE INDEX idx_profile_boosts_user_created_at ON profile_boosts(user_id, created_at);
@@ -0,0 +1,7 @@
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS updated_by;
+
+DROP INDEX IF EXISTS idx_profile_managers_manager_user_id;
+DROP INDEX IF EXISTS unique_profile_manager;
+DROP TABLE IF EXISTS profile_managers;
+DROP TYPE IF EXISTS profile_manager_status_type;
@@ -0,0 +1,31 @@
+CREATE TYPE profile_manager_status_type AS ENUM (
+    'invited', 'active', 'revoked'
+);
+
+-- Users other than the owner who may act on a profile, such as the parents or
+-- siblings of the bride or groom, each with their own scoped permissions
+CREATE TABLE IF NOT EXISTS profile_managers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    manager_user_id UUID NOT NULL,
+    invited_by UUID NOT NULL,
+    status profile_manager_status_type NOT NULL DEFAULT 'invited',
+    can_edit BOOLEAN NOT NULL DEFAULT FALSE,
+    can_respond_interests BOOLEAN NOT NULL DEFAULT FALSE,
+    can_view_contacts BOOLEAN NOT NULL DEFAULT FALSE,
+    can_delete BOOLEAN NOT NULL DEFAULT FALSE,
+    accepted_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Revoked delegations are kept for auditing, so only one live delegation per manager
+CREATE UNIQUE INDEX unique_profile_manager ON profile_managers(profile_id, manager_user_id)
+    WHERE status <> 'revoked';
+
+CREATE INDEX idx_profile_managers_manager_user_id ON profile_managers(manager_user_id);
+
+-- Who last changed or deleted a profile, which is not necessarily its owner
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS updated_by UUID;
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS deleted_by UUID;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_contact_reveals_quota;
+CREATE INDEX idx_contact_reveals_quota ON contact_reveals(viewer_profile_id, created_at)
+    WHERE outcome = 'revealed' AND basis = 'quota';
+
+DROP TABLE IF EXISTS active_profiles;
+
+DROP INDEX IF EXISTS idx_user_profiles_user_id;
+DROP INDEX IF EXISTS unique_user_profile;
+ALTER TABLE user_profiles ADD CONSTRAINT unique_user_profile UNIQUE (user_id);
@@ -0,0 +1,23 @@
+-- An account may own several profiles, e.g. a parent managing matrimony for two
+-- children, but only one profile of its own
+ALTER TABLE user_profiles DROP CONSTRAINT IF EXISTS unique_user_profile;
+CREATE UNIQUE INDEX unique_user_profile ON user_profiles(user_id)
+    WHERE profile_created_by = 'Self';
+
+CREATE INDEX idx_user_profiles_user_id ON user_profiles(user_id, created_at);
+
+-- The profile each account last switched to, used when a request doesn't name one
+CREATE TABLE IF NOT EXISTS active_profiles (
+    user_id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO active_profiles (user_id, profile_id)
+SELECT user_id, id FROM user_profiles WHERE deleted_at IS NULL
+ON CONFLICT (user_id) DO NOTHING;
+
+-- Plans are held by accounts, so reveal quotas are shared by an account's profiles
+DROP INDEX IF EXISTS idx_contact_reveals_quota;
+CREATE INDEX idx_contact_reveals_quota ON contact_reveals(viewer_user_id, created_at)
+    WHERE outcome = 'revealed' AND basis = 'quota';
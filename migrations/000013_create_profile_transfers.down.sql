@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_profile_transfers_to_user_id;
+DROP INDEX IF EXISTS idx_profile_transfers_from_user_id;
+DROP INDEX IF EXISTS unique_pending_profile_transfer;
+DROP TABLE IF EXISTS profile_transfers;
+DROP TYPE IF EXISTS profile_transfer_status_type;
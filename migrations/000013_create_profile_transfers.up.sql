@@ -0,0 +1,30 @@
+CREATE TYPE profile_transfer_status_type AS ENUM (
+    'pending', 'accepted', 'cancelled', 'expired'
+);
+
+-- Hand-overs of a profile from one account to another, e.g. a brother handing the
+-- profile he created to his sister. Rows are never deleted and serve as the audit trail.
+CREATE TABLE IF NOT EXISTS profile_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    from_user_id UUID NOT NULL,
+    to_user_id UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    profile_created_by profile_created_by NOT NULL,
+    status profile_transfer_status_type NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    cancelled_by UUID,
+    request_id VARCHAR(100),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_profile_transfer_token UNIQUE (token_hash)
+);
+
+-- A profile can only be on offer to one account at a time
+CREATE UNIQUE INDEX unique_pending_profile_transfer ON profile_transfers(profile_id)
+    WHERE status = 'pending';
+
+CREATE INDEX idx_profile_transfers_from_user_id ON profile_transfers(from_user_id);
+CREATE INDEX idx_profile_transfers_to_user_id ON profile_transfers(to_user_id);
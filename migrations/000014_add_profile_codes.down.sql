@@ -0,0 +1,6 @@
+ALTER TABLE user_profiles DROP CONSTRAINT IF EXISTS unique_profile_code;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS profile_code;
+
+DROP FUNCTION IF EXISTS next_profile_code();
+DROP SEQUENCE IF EXISTS profile_code_seq;
+DROP FUNCTION IF EXISTS profile_code_check_digit(TEXT);
@@ -0,0 +1,50 @@
+-- Luhn check digit of a string of decimal digits. Must match profilecode.CheckDigit.
+CREATE OR REPLACE FUNCTION profile_code_check_digit(digits TEXT) RETURNS INT AS $$
+DECLARE
+    total INT := 0;
+    n INT := length(digits);
+    d INT;
+BEGIN
+    -- Double every second digit counting from the right, starting with the rightmost,
+    -- since the check digit will be appended after it
+    FOR i IN 1..n LOOP
+        d := substr(digits, n - i + 1, 1)::INT;
+        IF i % 2 = 1 THEN
+            d := d * 2;
+            IF d > 9 THEN
+                d := d - 9;
+            END IF;
+        END IF;
+        total := total + d;
+    END LOOP;
+
+    RETURN (10 - total % 10) % 10;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE STRICT;
+
+-- Serials start at five digits so that codes read like QK-104523
+CREATE SEQUENCE IF NOT EXISTS profile_code_seq START WITH 10000;
+
+CREATE OR REPLACE FUNCTION next_profile_code() RETURNS TEXT AS $$
+DECLARE
+    serial TEXT := nextval('profile_code_seq')::TEXT;
+BEGIN
+    RETURN 'QK-' || serial || profile_code_check_digit(serial);
+END;
+$$ LANGUAGE plpgsql VOLATILE;
+
+-- Short public code families share over WhatsApp and phone in place of the UUID
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS profile_code VARCHAR(20);
+
+-- Existing profiles get codes in the order they were created
+UPDATE user_profiles p
+SET profile_code = c.code
+FROM (
+    SELECT o.id, next_profile_code() AS code
+    FROM (SELECT id FROM user_profiles WHERE profile_code IS NULL ORDER BY created_at, id) o
+) c
+WHERE p.id = c.id;
+
+ALTER TABLE user_profiles ALTER COLUMN profile_code SET DEFAULT next_profile_code();
+ALTER TABLE user_profiles ALTER COLUMN profile_code SET NOT NULL;
+ALTER TABLE user_profiles ADD CONSTRAINT unique_profile_code UNIQUE (profile_code);
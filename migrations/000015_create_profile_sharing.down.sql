@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_profile_share_links_profile_id;
+DROP TABLE IF EXISTS profile_share_links;
+DROP TABLE IF EXISTS profile_privacy_settings;
@@ -0,0 +1,31 @@
+-- What a profile shows on its biodata and share links. Profiles without a row use
+-- the defaults below, which show the age in place of the exact date of birth.
+CREATE TABLE IF NOT EXISTS profile_privacy_settings (
+    profile_id UUID PRIMARY KEY,
+    show_photo BOOLEAN NOT NULL DEFAULT TRUE,
+    show_date_of_birth BOOLEAN NOT NULL DEFAULT FALSE,
+    show_residence BOOLEAN NOT NULL DEFAULT TRUE,
+    show_education BOOLEAN NOT NULL DEFAULT TRUE,
+    show_occupation BOOLEAN NOT NULL DEFAULT TRUE,
+    show_about_me BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Public links to a read-only snapshot of a profile, for relatives without an account.
+-- The snapshot is taken when the link is minted and already has the privacy settings applied.
+CREATE TABLE IF NOT EXISTS profile_share_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    created_by UUID NOT NULL,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    snapshot JSONB NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    revoked_by UUID,
+    view_count BIGINT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_share_links_profile_id ON profile_share_links(profile_id, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE user_profiles DROP CONSTRAINT IF EXISTS check_date_of_birth_calendar;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS date_of_birth_calendar;
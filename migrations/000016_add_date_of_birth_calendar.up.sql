@@ -0,0 +1,6 @@
+-- The calendar the date of birth was entered in. It is always stored as a Gregorian
+-- date; profiles entered in Hijri also show the Hijri equivalent.
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS date_of_birth_calendar VARCHAR(10) NOT NULL DEFAULT 'gregorian';
+
+ALTER TABLE user_profiles ADD CONSTRAINT check_date_of_birth_calendar
+    CHECK (date_of_birth_calendar IN ('gregorian', 'hijri'));
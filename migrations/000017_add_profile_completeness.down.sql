@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_user_profiles_completeness_score;
+ALTER TABLE user_profiles DROP CONSTRAINT IF EXISTS check_completeness_score;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS completeness_score;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS siblings;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS mother_occupation;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS father_occupation;
@@ -0,0 +1,13 @@
+-- Family details, asked for on the onboarding checklist
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS father_occupation VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS mother_occupation VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS siblings VARCHAR(200) NOT NULL DEFAULT '';
+
+-- Percentage of the profile that is filled in, kept up to date by the service. It
+-- weighs in search relevance and can be filtered on.
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS completeness_score SMALLINT NOT NULL DEFAULT 0;
+
+ALTER TABLE user_profiles ADD CONSTRAINT check_completeness_score
+    CHECK (completeness_score BETWEEN 0 AND 100);
+
+CREATE INDEX IF NOT EXISTS idx_user_profiles_completeness_score ON user_profiles (completeness_score);
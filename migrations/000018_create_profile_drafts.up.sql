@@ -0,0 +1,14 @@
+-- Profiles being filled in step by step. An account has at most one draft, which is
+-- deleted when it is submitted as a profile. The data holds the fields of the saved
+-- steps with the same names as the create profile request.
+CREATE TABLE IF NOT EXISTS profile_drafts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    last_step VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_profile_draft_user UNIQUE (user_id),
+    CONSTRAINT check_profile_draft_last_step
+        CHECK (last_step IN ('basic', 'physical', 'location', 'religious', 'family'))
+);
@@ -0,0 +1,23 @@
+-- Changes made to profiles, one row per create, update or admin revert. The changes
+-- hold the old and new value of every field that changed, keyed by its JSON name, so
+-- that a profile can be restored to the state after any revision.
+CREATE TABLE IF NOT EXISTS profile_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    profile_id UUID NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    actor_id UUID NOT NULL,
+    actor_role VARCHAR(10) NOT NULL,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '{}',
+    reverted_to UUID,
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    flag_reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_profile_revision_action CHECK (action IN ('create', 'update', 'revert')),
+    CONSTRAINT check_profile_revision_actor_role CHECK (actor_role IN ('owner', 'manager', 'admin'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_revisions_profile_created ON profile_revisions(profile_id, created_at DESC);
+
+-- Flagged revisions are reviewed by moderators, newest first
+CREATE INDEX IF NOT EXISTS idx_profile_revisions_flagged ON profile_revisions(created_at DESC) WHERE flagged;
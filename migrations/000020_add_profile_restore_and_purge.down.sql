@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_user_profiles_purgeable;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS purged_at;
+
+DROP INDEX IF EXISTS unique_user_profile;
+CREATE UNIQUE INDEX unique_user_profile ON user_profiles(user_id)
+    WHERE profile_created_by = 'Self';
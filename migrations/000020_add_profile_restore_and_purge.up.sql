@@ -0,0 +1,11 @@
+-- Deleted profiles can be restored for a while and are anonymized once past retention,
+-- so they no longer count as the profile of its own an account may have
+DROP INDEX IF EXISTS unique_user_profile;
+CREATE UNIQUE INDEX unique_user_profile ON user_profiles(user_id)
+    WHERE profile_created_by = 'Self' AND deleted_at IS NULL;
+
+-- When a deleted profile was anonymized by the purge job
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS purged_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_user_profiles_purgeable ON user_profiles(deleted_at)
+    WHERE deleted_at IS NOT NULL AND purged_at IS NULL;
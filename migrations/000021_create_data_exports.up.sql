@@ -0,0 +1,28 @@
+-- Archives of everything the service holds about an account, requested by its owner
+-- and assembled by a background job. The archive is dropped once it expires; the row
+-- is kept as a record of the request.
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    archive BYTEA,
+    archive_size BIGINT NOT NULL DEFAULT 0,
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    CONSTRAINT check_data_export_status
+        CHECK (status IN ('pending', 'running', 'completed', 'failed', 'expired'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_created ON data_exports(user_id, created_at DESC);
+
+-- An account has at most one export being assembled at a time
+CREATE UNIQUE INDEX IF NOT EXISTS unique_active_data_export ON data_exports(user_id)
+    WHERE status IN ('pending', 'running');
+
+-- The job picks up queued exports oldest first
+CREATE INDEX IF NOT EXISTS idx_data_exports_queued ON data_exports(created_at)
+    WHERE status IN ('pending', 'running');
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_account_erasures_protect_receipt ON account_erasures;
+DROP FUNCTION IF EXISTS account_erasures_protect_receipt();
+DROP TABLE IF EXISTS account_erasures;
@@ -0,0 +1,49 @@
+-- Erasures of accounts at their owner's request. The erasure works through the
+-- records of the account step by step, so that an interrupted one resumes where it
+-- stopped. Once complete, the account ID is cleared and only the hash of it is kept,
+-- along with a receipt chained to the previous one by its hash.
+CREATE TABLE IF NOT EXISTS account_erasures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID,
+    subject_hash VARCHAR(64) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    completed_steps SMALLINT NOT NULL DEFAULT 0,
+    summary JSONB NOT NULL DEFAULT '{}',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    sequence BIGINT,
+    previous_hash VARCHAR(64) NOT NULL DEFAULT '',
+    receipt_hash VARCHAR(64) NOT NULL DEFAULT '',
+    receipt TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    CONSTRAINT check_account_erasure_status CHECK (status IN ('pending', 'running', 'completed')),
+    CONSTRAINT unique_account_erasure_sequence UNIQUE (sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_erasures_subject ON account_erasures(subject_hash, created_at DESC);
+
+-- An account has at most one erasure in progress, so repeating the request is harmless
+CREATE UNIQUE INDEX IF NOT EXISTS unique_open_account_erasure ON account_erasures(subject_hash)
+    WHERE status IN ('pending', 'running');
+
+-- The job picks up unfinished erasures oldest first
+CREATE INDEX IF NOT EXISTS idx_account_erasures_queued ON account_erasures(created_at)
+    WHERE status IN ('pending', 'running');
+
+-- Receipts are append-only: a completed erasure can be neither changed nor removed
+CREATE OR REPLACE FUNCTION account_erasures_protect_receipt() RETURNS trigger AS $$
+BEGIN
+    IF OLD.status = 'completed' THEN
+        RAISE EXCEPTION 'completed account erasure % cannot be modified', OLD.id;
+    END IF;
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_account_erasures_protect_receipt
+    BEFORE UPDATE OR DELETE ON account_erasures
+    FOR EACH ROW EXECUTE FUNCTION account_erasures_protect_receipt();
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_consent_records_append_only ON consent_records;
+DROP FUNCTION IF EXISTS consent_records_append_only();
+DROP TABLE IF EXISTS consent_records;
@@ -0,0 +1,41 @@
+-- Ledger of what each account agreed to: every grant or withdrawal of a policy
+-- version is appended, with where the request came from, and never rewritten. The
+-- latest record of a policy is the account's current choice.
+CREATE TABLE IF NOT EXISTS consent_records (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    policy VARCHAR(20) NOT NULL,
+    policy_version VARCHAR(50) NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    source_ip VARCHAR(45),
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_consent_policy CHECK (policy IN ('terms', 'privacy_policy', 'photo_display', 'data_sharing')),
+    CONSTRAINT check_consent_action CHECK (action IN ('granted', 'withdrawn'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_consent_records_user_policy ON consent_records(user_id, policy, created_at DESC);
+
+-- Records can be neither removed nor changed, except by erasing the account, which
+-- replaces the account ID and clears the source IP
+CREATE OR REPLACE FUNCTION consent_records_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        RAISE EXCEPTION 'consent record % cannot be removed', OLD.id;
+    END IF;
+    IF NEW.id <> OLD.id
+        OR NEW.policy <> OLD.policy
+        OR NEW.policy_version <> OLD.policy_version
+        OR NEW.action <> OLD.action
+        OR NEW.request_id <> OLD.request_id
+        OR NEW.created_at <> OLD.created_at
+        OR (NEW.source_ip IS NOT NULL AND NEW.source_ip IS DISTINCT FROM OLD.source_ip) THEN
+        RAISE EXCEPTION 'consent record % cannot be modified', OLD.id;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_consent_records_append_only
+    BEFORE UPDATE OR DELETE ON consent_records
+    FOR EACH ROW EXECUTE FUNCTION consent_records_append_only();
@@ -0,0 +1,10 @@
+-- Members who clicked a boosted placement, so that each member counts one click per boost
+CREATE TABLE IF NOT EXISTS profile_boost_clicks (
+    boost_id UUID NOT NULL REFERENCES profile_boosts(id) ON DELETE CASCADE,
+    viewer_user_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (boost_id, viewer_user_id)
+);
+
+CREATE INDEX idx_profile_boost_clicks_viewer_user_id ON profile_boost_clicks(viewer_user_id);